@@ -21,6 +21,8 @@ Options:
     -service string    Service name to generate (default: all services)
     -output string     Output directory (default: current directory)
     -client string     Client output path (default: internal/grpc_client/{service})
+    -mock              Also generate a stub server for the service
+    -mock-path string  Mock output path (default: internal/grpc_mock/{service})
     -pb-import string  Import path for generated protobuf types (required)
     -module string     Go module name for imports (default: auto-detect from go.mod)
 
@@ -34,8 +36,12 @@ Examples:
     # Custom paths
     grpc-gen -client internal/grpc_client/player -pb-import "myproject/pb" player.proto
 
+    # Client and stub server for tests of services that call PlayerService
+    grpc-gen -mock -pb-import "myproject/pkg/pb/player" player.proto
+
 Generated files:
     - internal/grpc_client/{service}/client.go
+    - internal/grpc_mock/{service}/mock.go (with -mock)
 
 Note: This generator creates DSL wrapper methods. The protobuf types (messages)
 should be generated separately using protoc with go plugins.
@@ -45,6 +51,8 @@ func main() {
 	serviceName := flag.String("service", "", "Service name to generate (default: all)")
 	outputDir := flag.String("output", ".", "Output directory")
 	clientPath := flag.String("client", "", "Client output path")
+	withMock := flag.Bool("mock", false, "Also generate a stub server")
+	mockPath := flag.String("mock-path", "", "Mock output path")
 	pbImport := flag.String("pb-import", "", "Import path for generated protobuf types")
 	moduleName := flag.String("module", "", "Go module name (default: auto-detect)")
 
@@ -103,6 +111,15 @@ func main() {
 		}
 
 		fmt.Printf("  Client: %s (%d methods)\n", result.ClientFile, result.MethodsCount)
+
+		if *withMock {
+			mockResult, err := gen.GenerateMock(*outputDir, *mockPath)
+			if err != nil {
+				log.Fatalf("Mock generation failed for %s: %v", svcName, err)
+			}
+			result.MockFile = mockResult.MockFile
+			fmt.Printf("  Mock: %s\n", result.MockFile)
+		}
 		fmt.Println()
 
 		allResults = append(allResults, *result)
@@ -1,7 +1,17 @@
 package allure
 
 import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	// Registers the standard error detail types so they can be decoded.
+	_ "google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
 )
 
 func (r *Reporter) writeGRPCError(builder *ReportBuilder, err error) {
@@ -11,11 +21,33 @@ func (r *Reporter) writeGRPCError(builder *ReportBuilder, err error) {
 	if ok {
 		builder.WriteKeyValue("Code", st.Code().String())
 		builder.WriteKeyValue("Message", st.Message())
+		r.writeGRPCErrorDetails(builder, st)
 	} else {
 		builder.WriteKeyValue("Message", err.Error())
 	}
 }
 
+// writeGRPCErrorDetails writes status details (BadRequest, ErrorInfo, RetryInfo, ...) as JSON.
+// Details of types unknown to this binary are shown by their type URL only.
+func (r *Reporter) writeGRPCErrorDetails(builder *ReportBuilder, st *status.Status) {
+	details := st.Proto().GetDetails()
+	if len(details) == 0 {
+		return
+	}
+
+	decoded := make([]json.RawMessage, 0, len(details))
+	for _, detail := range details {
+		raw, err := protojson.Marshal(detail)
+		if err != nil {
+			raw, _ = json.Marshal(map[string]string{"@type": detail.GetTypeUrl()})
+		}
+		decoded = append(decoded, raw)
+	}
+
+	builder.WriteSection("Details")
+	builder.WriteJSONOrError(decoded)
+}
+
 func (r *Reporter) writeBody(builder *ReportBuilder, body any) {
 	if body == nil {
 		return
@@ -24,3 +56,58 @@ func (r *Reporter) writeBody(builder *ReportBuilder, body any) {
 	builder.WriteSection("Body")
 	builder.WriteJSONOrError(body)
 }
+
+func (r *Reporter) writeGRPCMetadata(builder *ReportBuilder, md metadata.MD) {
+	if len(md) == 0 {
+		return
+	}
+
+	builder.WriteSection("Metadata")
+	for key, values := range md {
+		for _, value := range values {
+			maskedValue := r.Config.MaskHeader(key, value)
+			builder.WriteKeyValue(key, maskedValue)
+		}
+	}
+}
+
+// grpcStreamFrameLimit caps the frames listed in a stream report.
+const grpcStreamFrameLimit = 200
+
+func (r *Reporter) writeGRPCStreamResultSection(builder *ReportBuilder, result GRPCStreamResultDTO) {
+	builder.WriteSectionHeader(fmt.Sprintf("STREAM [%s]", result.Status))
+
+	builder.WriteLine("Status: %s (%d)", result.Status, result.StatusCode)
+	if result.StoppedByClient {
+		builder.WriteLine("Closed by: client (Take / CollectFor)")
+	}
+	builder.WriteLine("Sent: %d, Received: %d", result.Sent, result.Received)
+	builder.WriteLine("Duration: %v", result.Duration)
+
+	if result.Error != nil {
+		r.writeGRPCError(builder, result.Error)
+	}
+
+	r.writeGRPCMetadata(builder, result.Metadata)
+
+	if len(result.Frames) == 0 {
+		return
+	}
+
+	builder.WriteSection("Frames")
+	sent, received := 0, 0
+	for i, frame := range result.Frames {
+		if i == grpcStreamFrameLimit {
+			builder.WriteLine("... %d more frames", len(result.Frames)-grpcStreamFrameLimit)
+			break
+		}
+		if frame.Direction == client.FrameSent {
+			sent++
+			builder.WriteLine("→ sent #%d (+%v)", sent, frame.Offset)
+		} else {
+			received++
+			builder.WriteLine("← received #%d (+%v)", received, frame.Offset)
+		}
+		builder.WriteJSONOrError(frame.Body)
+	}
+}
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	grpcClient "github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
 )
@@ -29,6 +31,18 @@ func TestWriteGRPCError(t *testing.T) {
 				"Message: user not found",
 			},
 		},
+		{
+			name: "status with details",
+			err:  statusWithDetails(),
+			contains: []string{
+				"Code: InvalidArgument",
+				"Details:",
+				`"@type": "type.googleapis.com/google.rpc.BadRequest"`,
+				`"field": "email"`,
+				`"reason": "INVALID_PLAYER"`,
+				`"retryDelay": "5s"`,
+			},
+		},
 		{
 			name: "generic error",
 			err:  errors.New("some generic error"),
@@ -63,6 +77,15 @@ func TestWriteGRPCError(t *testing.T) {
 	}
 }
 
+func statusWithDetails() error {
+	st, _ := status.New(codes.InvalidArgument, "validation failed").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "email", Description: "invalid"}}},
+		&errdetails.ErrorInfo{Reason: "INVALID_PLAYER"},
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(5 * time.Second)},
+	)
+	return st.Err()
+}
+
 func TestWriteBody(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -217,3 +240,36 @@ func TestToGRPCResponseDTO(t *testing.T) {
 		assert.Equal(t, -1, dto.StatusCode)
 	})
 }
+
+func TestWriteGRPCStreamResultSection(t *testing.T) {
+	resp := &grpcClient.StreamResponse[map[string]string]{
+		Messages: []*map[string]string{{"value": "echo: hi"}},
+		Frames: []grpcClient.StreamFrame{
+			{Direction: grpcClient.FrameSent, Offset: time.Millisecond, Body: map[string]string{"value": "hi"}},
+			{Direction: grpcClient.FrameReceived, Offset: 2 * time.Millisecond, Body: map[string]string{"value": "echo: hi"}},
+			{Direction: grpcClient.FrameSent, Offset: 3 * time.Millisecond, Body: map[string]string{"value": "fail"}},
+		},
+		Metadata: metadata.Pairs("x-request-id", "42"),
+		Error:    status.Error(codes.InvalidArgument, "fail requested"),
+	}
+
+	dto := ToGRPCStreamResultDTO(resp)
+	assert.Equal(t, "InvalidArgument", dto.Status)
+	assert.Equal(t, 2, dto.Sent)
+	assert.Equal(t, 1, dto.Received)
+
+	reporter := NewDefaultReporter()
+	builder := NewReportBuilder()
+	reporter.writeGRPCStreamResultSection(builder, dto)
+	content := builder.String()
+
+	assert.Contains(t, content, "STREAM [InvalidArgument]")
+	assert.Contains(t, content, "Sent: 2, Received: 1")
+	assert.Contains(t, content, "Message: fail requested")
+	assert.Contains(t, content, "→ sent #1 (+1ms)")
+	assert.Contains(t, content, "← received #1 (+2ms)")
+	assert.Contains(t, content, "→ sent #2 (+3ms)")
+	assert.Contains(t, content, `"echo: hi"`)
+	assert.Contains(t, content, "x-request-id: 42")
+	assert.Less(t, strings.Index(content, "sent #1"), strings.Index(content, "received #1"))
+}
@@ -48,19 +48,7 @@ func ToGRPCResponseDTO[T any](resp *client.Response[T]) GRPCResponseDTO {
 	dto.Metadata = resp.Metadata
 	dto.Error = resp.Error
 
-	if resp.Error != nil {
-		st, ok := status.FromError(resp.Error)
-		if ok {
-			dto.Status = st.Code().String()
-			dto.StatusCode = int(st.Code())
-		} else {
-			dto.Status = "UNKNOWN"
-			dto.StatusCode = -1
-		}
-	} else {
-		dto.Status = "OK"
-		dto.StatusCode = 0
-	}
+	dto.Status, dto.StatusCode = grpcStatus(resp.Error)
 
 	if resp.Body != nil {
 		dto.Body = resp.Body
@@ -68,3 +56,62 @@ func ToGRPCResponseDTO[T any](resp *client.Response[T]) GRPCResponseDTO {
 
 	return dto
 }
+
+// grpcStatus returns the status name and code for err, "UNKNOWN" (-1) for non-status errors.
+func grpcStatus(err error) (string, int) {
+	if err == nil {
+		return "OK", 0
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return "UNKNOWN", -1
+	}
+	return st.Code().String(), int(st.Code())
+}
+
+type GRPCStreamRequestDTO struct {
+	Target     string
+	Method     string
+	Kind       client.StreamKind
+	Metadata   metadata.MD
+	Take       int
+	CollectFor time.Duration
+	Timeout    time.Duration
+}
+
+type GRPCStreamResultDTO struct {
+	StatusCode      int
+	Status          string
+	Metadata        metadata.MD
+	Frames          []client.StreamFrame
+	Sent            int
+	Received        int
+	StoppedByClient bool
+	Error           error
+	Duration        time.Duration
+}
+
+func ToGRPCStreamResultDTO[T any](resp *client.StreamResponse[T]) GRPCStreamResultDTO {
+	dto := GRPCStreamResultDTO{}
+
+	if resp == nil {
+		return dto
+	}
+
+	dto.Status, dto.StatusCode = grpcStatus(resp.Error)
+	dto.Metadata = resp.Metadata
+	dto.Frames = resp.Frames
+	dto.StoppedByClient = resp.StoppedByClient
+	dto.Error = resp.Error
+	dto.Duration = resp.Duration
+
+	for _, frame := range resp.Frames {
+		if frame.Direction == client.FrameSent {
+			dto.Sent++
+		} else {
+			dto.Received++
+		}
+	}
+
+	return dto
+}
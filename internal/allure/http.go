@@ -2,8 +2,10 @@ package allure
 
 import (
 	"encoding/json"
+	"net/http"
 	"strings"
 
+	"github.com/gorelov-m-v/go-test-framework/internal/redact"
 	"github.com/gorelov-m-v/go-test-framework/pkg/http/client"
 
 	"github.com/ozontech/allure-go/pkg/allure"
@@ -14,9 +16,11 @@ func (r *Reporter) AttachHTTPRequest(sCtx provider.StepCtx, httpClient *client.C
 	builder := NewReportBuilder()
 
 	r.writeRequestBasicInfo(builder, httpClient, req.Method, req.Path, req.PathParams, req.QueryParams)
+	writeHTTPAuth(builder, req.Auth)
 	r.writeParams(builder, req.PathParams, "Path Params")
 	r.writeParams(builder, req.QueryParams, "Query Params")
 	r.writeRequestHeaders(builder, httpClient, req.Headers)
+	writeHTTPCookies(builder, req.Cookies, r.Config.MaskValue)
 	r.writeRequestBody(builder, req.Body, req.RawBody, req.Multipart)
 
 	sCtx.WithNewAttachment("HTTP Request", allure.Text, builder.Bytes())
@@ -67,11 +71,7 @@ func (r *Reporter) writeRequestHeaders(builder *ReportBuilder, httpClient *clien
 	}
 	builder.WriteSection("Headers")
 	for k, v := range headers {
-		maskedValue := v
-		if httpClient != nil && httpClient.ShouldMaskHeader(k) {
-			maskedValue = maskHeaderValue(k, v)
-		}
-		builder.WriteKeyValue(k, maskedValue)
+		builder.WriteKeyValue(k, maskHTTPHeader(k, v, httpClient != nil && httpClient.ShouldMaskHeader(k), r.Config.MaskValue))
 	}
 }
 
@@ -116,11 +116,7 @@ func (r *Reporter) writeResponseHeaders(builder *ReportBuilder, httpClient *clie
 	builder.WriteSection("Headers")
 	for k, values := range headers {
 		for _, v := range values {
-			maskedValue := v
-			if httpClient != nil && httpClient.ShouldMaskHeader(k) {
-				maskedValue = maskHeaderValue(k, v)
-			}
-			builder.WriteKeyValue(k, maskedValue)
+			builder.WriteKeyValue(k, maskHTTPHeader(k, v, httpClient != nil && httpClient.ShouldMaskHeader(k), r.Config.MaskValue))
 		}
 	}
 }
@@ -165,13 +161,56 @@ func (r *Reporter) writeResponseBody(builder *ReportBuilder, rawBody []byte) {
 	builder.WriteTruncated(rawBody, 1000)
 }
 
-func maskHeaderValue(key, value string) string {
+// writeHTTPAuth names the auth scheme a request was sent with; the credentials
+// themselves never appear in reports.
+func writeHTTPAuth(builder *ReportBuilder, auth string) {
+	if auth != "" {
+		builder.WriteLine("Auth: %s", auth)
+	}
+}
+
+// credentialHeaders are masked in HTTP reports even when they are not listed in maskHeaders.
+var credentialHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+}
+
+// maskHTTPHeader replaces credential headers and headers configured via maskHeaders with mask.
+// Cookie and Set-Cookie keep cookie names and attributes but mask the values.
+func maskHTTPHeader(key, value string, configured bool, mask string) string {
+	lower := strings.ToLower(strings.TrimSpace(key))
+	if configured || credentialHeaders[lower] {
+		return maskHeaderValue(key, value, mask)
+	}
+	switch lower {
+	case "cookie":
+		return redact.Cookie(value, mask)
+	case "set-cookie":
+		return redact.SetCookie(value, mask)
+	}
+	return value
+}
+
+// writeHTTPCookies lists the cookies a request was sent with, e.g. from a cookie jar.
+// Values are masked like in Cookie and Set-Cookie headers: a jar cookie carries no
+// attributes, so there is no telling an HttpOnly session cookie from a harmless one.
+func writeHTTPCookies(builder *ReportBuilder, cookies []*http.Cookie, mask string) {
+	if len(cookies) == 0 {
+		return
+	}
+	builder.WriteSection("Cookies")
+	for _, cookie := range cookies {
+		builder.WriteKeyValue(cookie.Name, mask)
+	}
+}
+
+func maskHeaderValue(key, value, mask string) string {
 	key = strings.ToLower(strings.TrimSpace(key))
 	if key == "authorization" {
 		parts := strings.SplitN(strings.TrimSpace(value), " ", 2)
 		if len(parts) == 2 && parts[0] != "" {
-			return parts[0] + " " + MaskValue
+			return parts[0] + " " + mask
 		}
 	}
-	return MaskValue
+	return mask
 }
@@ -1,10 +1,14 @@
 package allure
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	httpClient "github.com/gorelov-m-v/go-test-framework/pkg/http/client"
 )
@@ -107,3 +111,92 @@ func TestToHTTPResponseDTO(t *testing.T) {
 		assert.Equal(t, "connection timeout", dto.NetworkError)
 	})
 }
+
+func TestWriteHTTPRequestSection_MasksCredentials(t *testing.T) {
+	c, err := httpClient.New(httpClient.Config{BaseURL: "http://api.local", MaskHeaders: "X-Api-Key"})
+	assert.NoError(t, err)
+
+	builder := NewReportBuilder()
+	NewDefaultReporter().writeHTTPRequestSection(builder, c, HTTPRequestDTO{
+		Method: "GET",
+		Path:   "/users",
+		Auth:   "oauth2 client_credentials (client svc)",
+		Headers: map[string]string{
+			"Authorization": "Bearer secret-token",
+			"X-Api-Key":     "secret-key",
+			"X-Request-Id":  "req-1",
+		},
+	})
+	out := builder.String()
+
+	assert.Contains(t, out, "Auth: oauth2 client_credentials (client svc)")
+	assert.Contains(t, out, "Bearer "+MaskValue)
+	assert.NotContains(t, out, "secret-token")
+	assert.NotContains(t, out, "secret-key")
+	assert.Contains(t, out, "req-1")
+}
+
+func TestWriteHTTPSections_UseConfiguredMaskValue(t *testing.T) {
+	c, err := httpClient.New(httpClient.Config{BaseURL: "http://api.local", MaskHeaders: "X-Api-Key"})
+	assert.NoError(t, err)
+	reporter := NewReporter(MaskingConfig{MaskValue: "[hidden]"})
+
+	builder := NewReportBuilder()
+	reporter.writeHTTPRequestSection(builder, c, HTTPRequestDTO{
+		Method:  "GET",
+		Path:    "/users",
+		Headers: map[string]string{"Authorization": "Bearer secret-token", "X-Api-Key": "secret-key"},
+		Cookies: []*http.Cookie{{Name: "session_id", Value: "secret-session"}},
+	})
+	reporter.writeHTTPResponseSection(builder, c, HTTPResponseDTO{
+		StatusCode: 200,
+		Headers:    map[string][]string{"Set-Cookie": {"sid=secret-sid; HttpOnly"}},
+	})
+	out := builder.String()
+
+	assert.Contains(t, out, "Bearer [hidden]")
+	assert.Contains(t, out, "sid=[hidden]; HttpOnly")
+	assert.NotContains(t, out, MaskValue)
+	assert.NotContains(t, out, "secret")
+}
+
+func TestMaskHTTPHeader_Cookies(t *testing.T) {
+	assert.Equal(t, "lang="+MaskValue+"; JSESSIONID="+MaskValue, maskHTTPHeader("Cookie", "lang=en;JSESSIONID=abc", false, MaskValue))
+	assert.Equal(t, "id="+MaskValue+"; Path=/; HttpOnly", maskHTTPHeader("Set-Cookie", "id=abc; Path=/; HttpOnly", false, MaskValue))
+	assert.Equal(t, "lang="+MaskValue+"; Path=/", maskHTTPHeader("Set-Cookie", "lang=en; Path=/", false, MaskValue))
+	assert.Equal(t, MaskValue, maskHTTPHeader("Set-Cookie", "lang=en; Path=/", true, MaskValue))
+
+	builder := NewReportBuilder()
+	writeHTTPCookies(builder, []*http.Cookie{{Name: "auth_token", Value: "secret"}, {Name: "lang", Value: "en"}}, MaskValue)
+	assert.Contains(t, builder.String(), "Cookies")
+	assert.Contains(t, builder.String(), "lang")
+	assert.NotContains(t, builder.String(), "secret")
+	assert.NotContains(t, builder.String(), ": en")
+}
+
+func TestHTTPReport_JarCookieMaskedOnRoundTrip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "id", Value: "s3ss10n", Path: "/", HttpOnly: true})
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := httpClient.New(httpClient.Config{BaseURL: srv.URL, CookieJar: true})
+	require.NoError(t, err)
+	login, err := c.Do(context.Background(), &httpClient.Request[any]{Method: http.MethodPost, Path: "/login"})
+	require.NoError(t, err)
+	profile, err := c.Do(context.Background(), &httpClient.Request[any]{Method: http.MethodGet, Path: "/profile"})
+	require.NoError(t, err)
+	require.Len(t, profile.SentCookies, 1)
+
+	reporter := NewDefaultReporter()
+	builder := NewReportBuilder()
+	reporter.writeHTTPResponseSection(builder, c, ToHTTPResponseDTO(login))
+	reporter.writeHTTPRequestSection(builder, c, HTTPRequestDTO{Method: http.MethodGet, Path: "/profile", Cookies: profile.SentCookies})
+	out := builder.String()
+
+	assert.Contains(t, out, "id="+MaskValue+"; Path=/; HttpOnly")
+	assert.Contains(t, out, "Cookies")
+	assert.NotContains(t, out, "s3ss10n")
+}
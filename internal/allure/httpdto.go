@@ -1,6 +1,7 @@
 package allure
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/gorelov-m-v/go-test-framework/pkg/http/client"
@@ -12,6 +13,8 @@ type HTTPRequestDTO struct {
 	PathParams  map[string]string
 	QueryParams map[string]string
 	Headers     map[string]string
+	Auth        string
+	Cookies     []*http.Cookie
 
 	Body      any
 	RawBody   []byte
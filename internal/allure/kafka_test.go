@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 func TestKafkaSearchDTO(t *testing.T) {
@@ -132,3 +134,55 @@ func TestReportBuilder_WriteSection(t *testing.T) {
 	assert.Contains(t, content, "Filters:")
 	assert.Contains(t, content, "key: value")
 }
+
+func TestToKafkaProduceMessageDTO(t *testing.T) {
+	t.Run("nil message", func(t *testing.T) {
+		dto := ToKafkaProduceMessageDTO(nil)
+
+		assert.Empty(t, dto.Topic)
+	})
+
+	t.Run("JSON value", func(t *testing.T) {
+		partition := int32(1)
+		dto := ToKafkaProduceMessageDTO(&types.ProducerMessage{
+			Topic:     "orders",
+			Partition: &partition,
+			Key:       []byte("order-1"),
+			Value:     []byte(`{"id": "order-1"}`),
+			Headers:   map[string]string{"traceId": "t1"},
+		})
+
+		assert.Equal(t, "orders", dto.Topic)
+		assert.Equal(t, "order-1", dto.Key)
+		assert.Equal(t, &partition, dto.Partition)
+		assert.NotNil(t, dto.Value)
+		assert.Equal(t, "t1", dto.Headers["traceId"])
+	})
+
+	t.Run("non-JSON value", func(t *testing.T) {
+		dto := ToKafkaProduceMessageDTO(&types.ProducerMessage{
+			Topic: "orders",
+			Value: []byte("plain text"),
+		})
+
+		assert.Nil(t, dto.Value)
+		assert.Equal(t, []byte("plain text"), dto.RawValue)
+	})
+}
+
+func TestToKafkaProduceResultDTO(t *testing.T) {
+	t.Run("delivered", func(t *testing.T) {
+		dto := ToKafkaProduceResultDTO(&types.KafkaMessage{Partition: 3, Offset: 42}, time.Millisecond, nil)
+
+		assert.True(t, dto.Delivered)
+		assert.Equal(t, int32(3), dto.Partition)
+		assert.Equal(t, int64(42), dto.Offset)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		dto := ToKafkaProduceResultDTO(nil, time.Millisecond, assert.AnError)
+
+		assert.False(t, dto.Delivered)
+		assert.Equal(t, assert.AnError, dto.Error)
+	})
+}
@@ -3,13 +3,17 @@ package allure
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 type KafkaSearchDTO struct {
-	Topic   string
-	Filters map[string]string
-	Timeout time.Duration
-	Unique  bool
+	Topic           string
+	Codec           string
+	Filters         map[string]string
+	Timeout         time.Duration
+	Unique          bool
+	ExpectNoMessage bool
 }
 
 func ToKafkaSearchDTO(topic string, filters map[string]string, timeout time.Duration, unique bool) KafkaSearchDTO {
@@ -77,3 +81,83 @@ func parseMessagesToAny(messages [][]byte) any {
 	}
 	return parsed
 }
+
+type KafkaProduceMessageDTO struct {
+	Topic     string
+	Partition *int32
+	Key       string
+	Headers   map[string]string
+	Value     any
+	RawValue  []byte
+}
+
+func ToKafkaProduceMessageDTO(msg *types.ProducerMessage) KafkaProduceMessageDTO {
+	if msg == nil {
+		return KafkaProduceMessageDTO{}
+	}
+
+	var valueAny any
+	if len(msg.Value) > 0 {
+		if err := json.Unmarshal(msg.Value, &valueAny); err != nil {
+			valueAny = nil
+		}
+	}
+
+	return KafkaProduceMessageDTO{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Key:       string(msg.Key),
+		Headers:   msg.Headers,
+		Value:     valueAny,
+		RawValue:  msg.Value,
+	}
+}
+
+type KafkaProduceResultDTO struct {
+	Delivered bool
+	Partition int32
+	Offset    int64
+	Duration  time.Duration
+	Error     error
+}
+
+func ToKafkaProduceResultDTO(delivered *types.KafkaMessage, duration time.Duration, err error) KafkaProduceResultDTO {
+	dto := KafkaProduceResultDTO{
+		Duration: duration,
+		Error:    err,
+	}
+	if delivered != nil && err == nil {
+		dto.Delivered = true
+		dto.Partition = delivered.Partition
+		dto.Offset = delivered.Offset
+	}
+	return dto
+}
+
+type KafkaSequenceStepDTO struct {
+	Name      string
+	Filters   map[string]string
+	Matched   bool
+	Partition int32
+	Offset    int64
+	Message   any
+}
+
+// ToKafkaSequenceStepDTO builds a step entry; msg and value are nil for steps that were not matched.
+func ToKafkaSequenceStepDTO(name string, filters map[string]string, msg *types.KafkaMessage, value []byte) KafkaSequenceStepDTO {
+	dto := KafkaSequenceStepDTO{
+		Name:    name,
+		Filters: filters,
+	}
+	if msg == nil {
+		return dto
+	}
+
+	dto.Matched = true
+	dto.Partition = msg.Partition
+	dto.Offset = msg.Offset
+	if err := json.Unmarshal(value, &dto.Message); err != nil {
+		dto.Message = string(value)
+	}
+	return dto
+}
@@ -1,7 +1,10 @@
 package allure
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
 )
 
 func (r *Reporter) writeRedisTTL(builder *ReportBuilder, ttl time.Duration) {
@@ -28,3 +31,116 @@ func (r *Reporter) writeRedisValue(builder *ReportBuilder, value string) {
 		builder.WriteLine("%s", value)
 	}
 }
+
+// writeRedisContent writes the string value or the hash, list, set, sorted set and stream contents.
+func (r *Reporter) writeRedisContent(builder *ReportBuilder, result RedisResultDTO) {
+	switch result.Type {
+	case "", client.TypeString:
+		r.writeRedisValue(builder, result.Value)
+	case client.TypeHash:
+		builder.WriteLine("Fields: %d", result.Length)
+		builder.WriteSection("Hash")
+		builder.WriteJSONOrError(result.Hash)
+	case client.TypeList:
+		builder.WriteLine("Length: %d", result.Length)
+		builder.WriteSection("List")
+		builder.WriteJSONOrError(result.List)
+	case client.TypeSet:
+		builder.WriteLine("Members: %d", result.Length)
+		builder.WriteSection("Set")
+		builder.WriteJSONOrError(result.Members)
+	case client.TypeZSet:
+		builder.WriteLine("Members: %d", result.Length)
+		builder.WriteSection("Sorted Set")
+		for i, z := range result.ZMembers {
+			builder.WriteLine("  %d. %s (score %v)", i, z.Member, z.Score)
+		}
+	case client.TypeStream:
+		builder.WriteLine("Length: %d", result.Length)
+		if int64(len(result.Stream)) < result.Length {
+			builder.WriteLine("Showing latest %d entries", len(result.Stream))
+		}
+		builder.WriteSection("Stream")
+		for _, entry := range result.Stream {
+			builder.WriteLine("  %s", entry.ID)
+			builder.WriteMap(entry.Values)
+		}
+	}
+}
+
+// redisScanValueLimit truncates each value in the matched key list.
+const redisScanValueLimit = 200
+
+func (r *Reporter) writeRedisScanResultSection(builder *ReportBuilder, result RedisScanResultDTO) {
+	builder.WriteSectionHeader(fmt.Sprintf("RESULT [%d keys]", len(result.Keys)))
+
+	builder.WriteLine("Duration: %v", result.Duration)
+	if result.Truncated {
+		builder.WriteLine("Truncated: more keys match, showing the first %d found", len(result.Keys))
+	}
+
+	if len(result.Keys) > 0 {
+		builder.WriteSection("Keys")
+		for i, key := range result.Keys {
+			value, ok := result.Values[key]
+			if !ok {
+				builder.WriteLine("  %d. %s", i+1, key)
+				continue
+			}
+			if len(value) > redisScanValueLimit {
+				value = value[:redisScanValueLimit] + "..."
+			}
+			builder.WriteLine("  %d. %s = %s", i+1, key, value)
+		}
+	}
+
+	if result.Error != nil {
+		builder.WriteSection("Error")
+		builder.WriteKeyValue("Message", result.Error.Error())
+	}
+}
+
+// redisPubSubMatchesLimit caps the number of earlier matches listed in the report.
+const redisPubSubMatchesLimit = 20
+
+func (r *Reporter) writeRedisPubSubResultSection(builder *ReportBuilder, result RedisPubSubResultDTO) {
+	status := "Not Found"
+	if result.Found {
+		status = "Found"
+	}
+	builder.WriteSectionHeader(fmt.Sprintf("RESULT [%s]", status))
+
+	builder.WriteLine("Messages checked: %d", result.Checked)
+	builder.WriteLine("Matches: %d", len(result.Matches))
+
+	if result.Message != nil {
+		builder.WriteSection("Latest Match")
+		builder.WriteKeyValue("Channel", result.Message.Channel)
+		if result.Message.Pattern != "" {
+			builder.WriteKeyValue("Pattern", result.Message.Pattern)
+		}
+		builder.WriteKeyValue("Received", time.UnixMilli(result.Message.ReceivedAt).Format(time.RFC3339Nano))
+		r.writeRedisValue(builder, result.Message.Payload)
+	}
+
+	if len(result.Matches) > 1 {
+		builder.WriteSection("All Matches")
+		start := 0
+		if len(result.Matches) > redisPubSubMatchesLimit {
+			start = len(result.Matches) - redisPubSubMatchesLimit
+			builder.WriteLine("Showing latest %d", redisPubSubMatchesLimit)
+		}
+		for i, msg := range result.Matches[start:] {
+			payload := msg.Payload
+			if len(payload) > redisScanValueLimit {
+				payload = payload[:redisScanValueLimit] + "..."
+			}
+			builder.WriteLine("  %d. [%s] %s", start+i+1, msg.Channel, payload)
+		}
+	}
+
+	if result.Error != nil {
+		builder.WriteSection("Error")
+		builder.WriteKeyValue("Message", result.Error.Error())
+	}
+}
@@ -1,6 +1,7 @@
 package allure
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -176,3 +177,117 @@ func TestToRedisResultDTO(t *testing.T) {
 		assert.Nil(t, dto.Error)
 	})
 }
+
+func TestWriteRedisContent_Collections(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   RedisResultDTO
+		contains []string
+	}{
+		{
+			name:     "hash",
+			result:   RedisResultDTO{Type: redisClient.TypeHash, Length: 1, Hash: map[string]string{"status": "active"}},
+			contains: []string{"Fields: 1", "Hash:", `"status": "active"`},
+		},
+		{
+			name:     "list",
+			result:   RedisResultDTO{Type: redisClient.TypeList, Length: 2, List: []string{"a", "b"}},
+			contains: []string{"Length: 2", "List:", `"a"`, `"b"`},
+		},
+		{
+			name:     "sorted set",
+			result:   RedisResultDTO{Type: redisClient.TypeZSet, Length: 1, ZMembers: []redisClient.ZMember{{Member: "alice", Score: 42}}},
+			contains: []string{"Sorted Set:", "0. alice (score 42)"},
+		},
+		{
+			name: "stream",
+			result: RedisResultDTO{Type: redisClient.TypeStream, Length: 5, Stream: []redisClient.StreamEntry{
+				{ID: "1-0", Values: map[string]string{"event": "created"}},
+			}},
+			contains: []string{"Length: 5", "Showing latest 1 entries", "1-0", "event: created"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := NewDefaultReporter()
+			builder := NewReportBuilder()
+
+			reporter.writeRedisContent(builder, tt.result)
+
+			for _, s := range tt.contains {
+				assert.Contains(t, builder.String(), s)
+			}
+		})
+	}
+}
+
+func TestWriteRedisRequestSection_Node(t *testing.T) {
+	reporter := NewDefaultReporter()
+	builder := NewReportBuilder()
+
+	reporter.writeRedisRequestSection(builder, RedisRequestDTO{
+		Server:  "node1:7000,node2:7000",
+		Mode:    "cluster",
+		Node:    "10.0.0.7:7001",
+		Command: "GET",
+		Key:     "player:1",
+	})
+
+	output := builder.String()
+	assert.Contains(t, output, "Server: node1:7000,node2:7000")
+	assert.Contains(t, output, "Mode: cluster")
+	assert.Contains(t, output, "Node: 10.0.0.7:7001")
+}
+
+func TestWriteRedisScanResultSection(t *testing.T) {
+	reporter := NewDefaultReporter()
+	builder := NewReportBuilder()
+
+	reporter.writeRedisScanResultSection(builder, RedisScanResultDTO{
+		Keys:      []string{"session:1", "session:2"},
+		Truncated: true,
+		Values:    map[string]string{"session:1": `{"id":1}`},
+	})
+
+	output := builder.String()
+	assert.Contains(t, output, "RESULT [2 keys]")
+	assert.Contains(t, output, "Truncated: more keys match")
+	assert.Contains(t, output, `1. session:1 = {"id":1}`)
+	assert.Contains(t, output, "2. session:2\n")
+}
+
+func TestToRedisScanResultDTO_Values(t *testing.T) {
+	dto := ToRedisScanResultDTO(&redisClient.ScanResult{
+		Keys: []string{"a", "b", "c"},
+		Values: map[string]*redisClient.Result{
+			"a": {Exists: true, Value: "1"},
+			"b": {},
+			"c": {Error: errors.New("WRONGTYPE")},
+		},
+	})
+
+	assert.Equal(t, map[string]string{"a": "1", "b": "<missing>", "c": "<error: WRONGTYPE>"}, dto.Values)
+	assert.Equal(t, RedisScanResultDTO{}, ToRedisScanResultDTO(nil))
+}
+
+func TestWriteRedisPubSubResultSection(t *testing.T) {
+	reporter := NewDefaultReporter()
+	builder := NewReportBuilder()
+
+	first := &redisClient.Message{Channel: "cache:invalidate", Payload: `{"playerId":42}`}
+	latest := &redisClient.Message{Channel: "__keyspace@0__:player:42", Pattern: "__keyspace@*__:player:*", Payload: "del"}
+	reporter.writeRedisPubSubResultSection(builder, RedisPubSubResultDTO{
+		Found:   true,
+		Checked: 5,
+		Message: latest,
+		Matches: []*redisClient.Message{first, latest},
+	})
+
+	output := builder.String()
+	assert.Contains(t, output, "RESULT [Found]")
+	assert.Contains(t, output, "Messages checked: 5")
+	assert.Contains(t, output, "Pattern: __keyspace@*__:player:*")
+	assert.Contains(t, output, `1. [cache:invalidate] {"playerId":42}`)
+	assert.Contains(t, output, "2. [__keyspace@0__:player:42] del")
+}
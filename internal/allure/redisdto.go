@@ -7,8 +7,11 @@ import (
 )
 
 type RedisRequestDTO struct {
-	Server string
-	Key    string
+	Server  string
+	Mode    string
+	Node    string
+	Command string
+	Key     string
 }
 
 func ToRedisRequestDTO(server, key string) RedisRequestDTO {
@@ -20,8 +23,15 @@ func ToRedisRequestDTO(server, key string) RedisRequestDTO {
 
 type RedisResultDTO struct {
 	Key      string
+	Type     client.KeyType
 	Exists   bool
 	Value    string
+	Length   int64
+	Hash     map[string]string
+	List     []string
+	Members  []string
+	ZMembers []client.ZMember
+	Stream   []client.StreamEntry
 	TTL      time.Duration
 	Duration time.Duration
 	Error    error
@@ -33,10 +43,72 @@ func ToRedisResultDTO(result *client.Result) RedisResultDTO {
 	}
 	return RedisResultDTO{
 		Key:      result.Key,
+		Type:     result.Type,
 		Exists:   result.Exists,
 		Value:    result.Value,
+		Length:   result.Length,
+		Hash:     result.Hash,
+		List:     result.List,
+		Members:  result.Members,
+		ZMembers: result.ZMembers,
+		Stream:   result.Stream,
 		TTL:      result.TTL,
 		Duration: result.Duration,
 		Error:    result.Error,
 	}
 }
+
+type RedisScanRequestDTO struct {
+	Server  string
+	Mode    string
+	Pattern string
+	Limit   int
+}
+
+type RedisScanResultDTO struct {
+	Keys      []string
+	Truncated bool
+	Values    map[string]string
+	Duration  time.Duration
+	Error     error
+}
+
+func ToRedisScanResultDTO(result *client.ScanResult) RedisScanResultDTO {
+	if result == nil {
+		return RedisScanResultDTO{}
+	}
+	dto := RedisScanResultDTO{
+		Keys:      result.Keys,
+		Truncated: result.Truncated,
+		Duration:  result.Duration,
+		Error:     result.Error,
+	}
+	if result.Values != nil {
+		dto.Values = make(map[string]string, len(result.Values))
+		for key, value := range result.Values {
+			switch {
+			case value.Error != nil:
+				dto.Values[key] = "<error: " + value.Error.Error() + ">"
+			case !value.Exists:
+				dto.Values[key] = "<missing>"
+			default:
+				dto.Values[key] = value.Value
+			}
+		}
+	}
+	return dto
+}
+
+type RedisPubSubRequestDTO struct {
+	Server        string
+	Subscriptions []string
+	Filters       map[string]string
+}
+
+type RedisPubSubResultDTO struct {
+	Found   bool
+	Checked int
+	Message *client.Message
+	Matches []*client.Message
+	Error   error
+}
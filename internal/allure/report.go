@@ -3,11 +3,16 @@ package allure
 import (
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
 )
 
 type PollingSummaryDTO struct {
@@ -79,6 +84,7 @@ func (r *Reporter) writeHTTPRequestSection(builder *ReportBuilder, httpClient HT
 			builder.WriteLine("URL: %s", eff)
 		}
 	}
+	writeHTTPAuth(builder, req.Auth)
 
 	if len(req.PathParams) > 0 {
 		builder.WriteSection("Path Params")
@@ -93,14 +99,12 @@ func (r *Reporter) writeHTTPRequestSection(builder *ReportBuilder, httpClient HT
 	if len(req.Headers) > 0 {
 		builder.WriteSection("Headers")
 		for k, v := range req.Headers {
-			maskedValue := v
-			if httpClient != nil && httpClient.ShouldMaskHeader(k) {
-				maskedValue = r.Config.MaskHeader(k, v)
-			}
-			builder.WriteKeyValue(k, maskedValue)
+			builder.WriteKeyValue(k, maskHTTPHeader(k, v, httpClient != nil && httpClient.ShouldMaskHeader(k), r.Config.MaskValue))
 		}
 	}
 
+	writeHTTPCookies(builder, req.Cookies, r.Config.MaskValue)
+
 	r.writeRequestBody(builder, req.Body, req.RawBody, req.Multipart)
 }
 
@@ -124,11 +128,7 @@ func (r *Reporter) writeHTTPResponseSection(builder *ReportBuilder, httpClient H
 		builder.WriteSection("Headers")
 		for k, values := range resp.Headers {
 			for _, v := range values {
-				maskedValue := v
-				if httpClient != nil && httpClient.ShouldMaskHeader(k) {
-					maskedValue = r.Config.MaskHeader(k, v)
-				}
-				builder.WriteKeyValue(k, maskedValue)
+				builder.WriteKeyValue(k, maskHTTPHeader(k, v, httpClient != nil && httpClient.ShouldMaskHeader(k), r.Config.MaskValue))
 			}
 		}
 	}
@@ -160,6 +160,129 @@ func (r *Reporter) writePollingSection(builder *ReportBuilder, polling *PollingS
 	}
 }
 
+// ═══════════════════════════════════════════════════════════════════════════
+// HTTP Mock Report
+// ═══════════════════════════════════════════════════════════════════════════
+
+// mockOthersLimit caps how many non-matching mock journal entries are listed.
+const mockOthersLimit = 20
+
+// HTTPMockReportDTO describes a mock server verification: the requests matching
+// the pattern with the stub responses, and the other journaled requests.
+type HTTPMockReportDTO struct {
+	Pattern string
+	Matched []HTTPMockRequestDTO
+	Others  []HTTPMockRequestDTO
+	Total   int
+	Polling *PollingSummaryDTO
+}
+
+type HTTPMockRequestDTO struct {
+	ReceivedAt time.Time
+	Request    HTTPRequestDTO
+	Response   HTTPResponseDTO
+}
+
+func (r *Reporter) AttachHTTPMockReport(sCtx provider.StepCtx, report HTTPMockReportDTO) {
+	builder := NewReportBuilder()
+
+	builder.WriteHeader(fmt.Sprintf("HTTP mock %s → matched %d of %d received",
+		report.Pattern, len(report.Matched), report.Total))
+
+	for i, received := range report.Matched {
+		builder.WriteSectionHeader(fmt.Sprintf("RECEIVED #%d at %s", i+1, received.ReceivedAt.Format("15:04:05.000")))
+		r.writeHTTPRequestSection(builder, nil, received.Request)
+		r.writeHTTPResponseSection(builder, nil, received.Response)
+	}
+
+	if len(report.Others) > 0 {
+		builder.WriteSectionHeader("OTHER RECEIVED REQUESTS")
+		for i, received := range report.Others {
+			if i == mockOthersLimit {
+				builder.WriteLine("... %d more", len(report.Others)-mockOthersLimit)
+				break
+			}
+			builder.WriteLine("%s %s %s → %d", received.ReceivedAt.Format("15:04:05.000"),
+				received.Request.Method, received.Request.Path, received.Response.StatusCode)
+		}
+	}
+
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("HTTP Mock", allure.Text, builder.Bytes())
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// Contract Coverage Report
+// ═══════════════════════════════════════════════════════════════════════════
+
+// AttachContractCoverageReport lists, per spec, the coverage totals, the operations that
+// were never called, the documented responses never received and undocumented responses.
+func (r *Reporter) AttachContractCoverageReport(t provider.T, reports []contract.CoverageReport) {
+	builder := NewReportBuilder()
+
+	for i, report := range reports {
+		if i > 0 {
+			builder.WriteLine("")
+		}
+		header := fmt.Sprintf("Contract coverage: %s %s", report.Title, report.Version)
+		if report.Spec != "" {
+			header += " (" + report.Spec + ")"
+		}
+		builder.WriteHeader(header)
+		builder.WriteLine("Operations: %s", coverageRatio(report.Summary.TestedOperations, report.Summary.Operations))
+		builder.WriteLine("Responses: %s", coverageRatio(report.Summary.TestedResponses, report.Summary.Responses))
+
+		var untestedOps, untestedResponses, undocumented []string
+		for _, op := range report.Operations {
+			if !op.Tested {
+				untestedOps = append(untestedOps, op.Method+" "+op.Path)
+				continue
+			}
+			var missing, extra []string
+			for _, resp := range op.Responses {
+				switch {
+				case !resp.Documented:
+					extra = append(extra, fmt.Sprintf("%s (%d calls)", resp.Code, resp.Calls))
+				case !resp.Tested:
+					missing = append(missing, resp.Code)
+				}
+			}
+			if len(missing) > 0 {
+				untestedResponses = append(untestedResponses, fmt.Sprintf("%s %s → %s", op.Method, op.Path, strings.Join(missing, ", ")))
+			}
+			if len(extra) > 0 {
+				undocumented = append(undocumented, fmt.Sprintf("%s %s → %s", op.Method, op.Path, strings.Join(extra, ", ")))
+			}
+		}
+
+		writeCoverageList(builder, "UNTESTED OPERATIONS", untestedOps)
+		writeCoverageList(builder, "UNTESTED RESPONSES", untestedResponses)
+		writeCoverageList(builder, "UNDOCUMENTED RESPONSES", undocumented)
+	}
+
+	t.WithNewAttachment("Contract Coverage", allure.Text, builder.Bytes())
+}
+
+func writeCoverageList(builder *ReportBuilder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	builder.WriteSectionHeader(fmt.Sprintf("%s (%d)", title, len(lines)))
+	for _, line := range lines {
+		builder.WriteLine("%s", line)
+	}
+}
+
+func coverageRatio(tested, total int) string {
+	if total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%.1f%%)", tested, total, float64(tested)*100/float64(total))
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // gRPC Report
 // ═══════════════════════════════════════════════════════════════════════════
@@ -195,15 +318,7 @@ func (r *Reporter) writeGRPCRequestSection(builder *ReportBuilder, req GRPCReque
 	builder.WriteLine("Target: %s", req.Target)
 	builder.WriteLine("Method: %s", req.Method)
 
-	if len(req.Metadata) > 0 {
-		builder.WriteSection("Metadata")
-		for key, values := range req.Metadata {
-			for _, value := range values {
-				maskedValue := r.Config.MaskHeader(key, value)
-				builder.WriteKeyValue(key, maskedValue)
-			}
-		}
-	}
+	r.writeGRPCMetadata(builder, req.Metadata)
 
 	r.writeBody(builder, req.Body)
 }
@@ -222,17 +337,101 @@ func (r *Reporter) writeGRPCResponseSection(builder *ReportBuilder, resp GRPCRes
 		r.writeGRPCError(builder, resp.Error)
 	}
 
-	if len(resp.Metadata) > 0 {
-		builder.WriteSection("Metadata")
-		for key, values := range resp.Metadata {
-			for _, value := range values {
-				maskedValue := r.Config.MaskHeader(key, value)
-				builder.WriteKeyValue(key, maskedValue)
+	r.writeGRPCMetadata(builder, resp.Metadata)
+
+	r.writeBody(builder, resp.Body)
+}
+
+type GRPCStreamReportDTO struct {
+	Request GRPCStreamRequestDTO
+	Result  GRPCStreamResultDTO
+	Polling *PollingSummaryDTO
+}
+
+func (r *Reporter) AttachGRPCStreamReport(sCtx provider.StepCtx, report GRPCStreamReportDTO) {
+	builder := NewReportBuilder()
+
+	builder.WriteHeader(fmt.Sprintf("gRPC stream %s → %s (sent %d, received %d)",
+		report.Request.Method, report.Result.Status, report.Result.Sent, report.Result.Received))
+
+	builder.WriteSectionHeader("REQUEST")
+	builder.WriteLine("Target: %s", report.Request.Target)
+	builder.WriteLine("Method: %s", report.Request.Method)
+	builder.WriteLine("Kind: %s", report.Request.Kind)
+	if report.Request.Take > 0 {
+		builder.WriteLine("Take: %d messages", report.Request.Take)
+	}
+	if report.Request.CollectFor > 0 {
+		builder.WriteLine("Collect for: %v", report.Request.CollectFor)
+	}
+	builder.WriteLine("Timeout: %v", report.Request.Timeout)
+	r.writeGRPCMetadata(builder, report.Request.Metadata)
+
+	r.writeGRPCStreamResultSection(builder, report.Result)
+
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("gRPC Stream", allure.Text, builder.Bytes())
+}
+
+// ═══════════════════════════════════════════════════════════════════════════
+// gRPC Mock Report
+// ═══════════════════════════════════════════════════════════════════════════
+
+// GRPCMockReportDTO describes a gRPC mock verification: the calls of the method matching
+// the filters with the status they were answered with, and the other calls of the method.
+type GRPCMockReportDTO struct {
+	Verification string
+	Matched      []GRPCMockCallDTO
+	Others       []GRPCMockCallDTO
+	Total        int
+	Polling      *PollingSummaryDTO
+}
+
+type GRPCMockCallDTO struct {
+	ReceivedAt time.Time
+	Metadata   metadata.MD
+	Requests   []any
+	Error      error
+}
+
+func (r *Reporter) AttachGRPCMockReport(sCtx provider.StepCtx, report GRPCMockReportDTO) {
+	builder := NewReportBuilder()
+
+	builder.WriteHeader(fmt.Sprintf("gRPC mock %s → matched %d of %d received",
+		report.Verification, len(report.Matched), report.Total))
+
+	for i, call := range report.Matched {
+		builder.WriteSectionHeader(fmt.Sprintf("RECEIVED #%d at %s → %s",
+			i+1, call.ReceivedAt.Format("15:04:05.000"), status.Code(call.Error)))
+		r.writeGRPCMetadata(builder, call.Metadata)
+		for _, req := range call.Requests {
+			r.writeBody(builder, req)
+		}
+		if call.Error != nil {
+			r.writeGRPCError(builder, call.Error)
+		}
+	}
+
+	if len(report.Others) > 0 {
+		builder.WriteSectionHeader("OTHER RECEIVED CALLS")
+		for i, call := range report.Others {
+			if i == mockOthersLimit {
+				builder.WriteLine("... %d more", len(report.Others)-mockOthersLimit)
+				break
 			}
+			builder.WriteLine("%s %d message(s) → %s", call.ReceivedAt.Format("15:04:05.000"),
+				len(call.Requests), status.Code(call.Error))
 		}
 	}
 
-	r.writeBody(builder, resp.Body)
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("gRPC Mock", allure.Text, builder.Bytes())
 }
 
 // ═══════════════════════════════════════════════════════════════════════════
@@ -252,7 +451,11 @@ func (r *Reporter) AttachRedisReport(sCtx provider.StepCtx, report RedisReportDT
 	if report.Result.Exists {
 		status = "Found"
 	}
-	title := fmt.Sprintf("Redis GET %s → %s", report.Request.Key, status)
+	command := report.Request.Command
+	if command == "" {
+		command = "GET"
+	}
+	title := fmt.Sprintf("Redis %s %s → %s", command, report.Request.Key, status)
 	builder.WriteHeader(title)
 
 	r.writeRedisRequestSection(builder, report.Request)
@@ -269,6 +472,15 @@ func (r *Reporter) writeRedisRequestSection(builder *ReportBuilder, req RedisReq
 	builder.WriteSectionHeader("REQUEST")
 
 	builder.WriteLine("Server: %s", req.Server)
+	if req.Mode != "" {
+		builder.WriteLine("Mode: %s", req.Mode)
+	}
+	if req.Node != "" {
+		builder.WriteLine("Node: %s", req.Node)
+	}
+	if req.Command != "" {
+		builder.WriteLine("Command: %s", req.Command)
+	}
 	builder.WriteLine("Key: %s", req.Key)
 }
 
@@ -284,7 +496,7 @@ func (r *Reporter) writeRedisResultSection(builder *ReportBuilder, result RedisR
 
 	if result.Exists {
 		r.writeRedisTTL(builder, result.TTL)
-		r.writeRedisValue(builder, result.Value)
+		r.writeRedisContent(builder, result)
 	}
 
 	if result.Error != nil {
@@ -293,6 +505,72 @@ func (r *Reporter) writeRedisResultSection(builder *ReportBuilder, result RedisR
 	}
 }
 
+type RedisScanReportDTO struct {
+	Request RedisScanRequestDTO
+	Result  RedisScanResultDTO
+	Polling *PollingSummaryDTO
+}
+
+func (r *Reporter) AttachRedisScanReport(sCtx provider.StepCtx, report RedisScanReportDTO) {
+	builder := NewReportBuilder()
+
+	count := fmt.Sprintf("%d keys", len(report.Result.Keys))
+	if report.Result.Truncated {
+		count = fmt.Sprintf("%d+ keys", len(report.Result.Keys))
+	}
+	builder.WriteHeader(fmt.Sprintf("Redis SCAN %s → %s", report.Request.Pattern, count))
+
+	builder.WriteSectionHeader("REQUEST")
+	builder.WriteLine("Server: %s", report.Request.Server)
+	if report.Request.Mode != "" {
+		builder.WriteLine("Mode: %s", report.Request.Mode)
+	}
+	builder.WriteLine("Pattern: %s", report.Request.Pattern)
+	if report.Request.Limit > 0 {
+		builder.WriteLine("Limit: %d", report.Request.Limit)
+	}
+
+	r.writeRedisScanResultSection(builder, report.Result)
+
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("Redis Scan", allure.Text, builder.Bytes())
+}
+
+type RedisPubSubReportDTO struct {
+	Request RedisPubSubRequestDTO
+	Result  RedisPubSubResultDTO
+	Polling *PollingSummaryDTO
+}
+
+func (r *Reporter) AttachRedisPubSubReport(sCtx provider.StepCtx, report RedisPubSubReportDTO) {
+	builder := NewReportBuilder()
+
+	status := "Not Found"
+	if report.Result.Found {
+		status = fmt.Sprintf("Found (%d)", len(report.Result.Matches))
+	}
+	builder.WriteHeader(fmt.Sprintf("Redis Pub/Sub → %s", status))
+
+	builder.WriteSectionHeader("SEARCH")
+	builder.WriteLine("Server: %s", report.Request.Server)
+	builder.WriteLine("Subscriptions: %s", strings.Join(report.Request.Subscriptions, ", "))
+	if len(report.Request.Filters) > 0 {
+		builder.WriteSection("Filters")
+		builder.WriteMap(report.Request.Filters)
+	}
+
+	r.writeRedisPubSubResultSection(builder, report.Result)
+
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("Redis Pub/Sub", allure.Text, builder.Bytes())
+}
+
 // ═══════════════════════════════════════════════════════════════════════════
 // Kafka Report
 // ═══════════════════════════════════════════════════════════════════════════
@@ -310,6 +588,12 @@ func (r *Reporter) AttachKafkaReport(sCtx provider.StepCtx, report KafkaReportDT
 	if report.Result.Found {
 		status = fmt.Sprintf("Found (%d)", report.Result.MatchCount)
 	}
+	if report.Search.ExpectNoMessage {
+		status = "No Message"
+		if report.Result.Found {
+			status = "Unexpected Message"
+		}
+	}
 	title := fmt.Sprintf("Kafka %s → %s", report.Search.Topic, status)
 	builder.WriteHeader(title)
 
@@ -327,8 +611,16 @@ func (r *Reporter) writeKafkaSearchSection(builder *ReportBuilder, search KafkaS
 	builder.WriteSectionHeader("SEARCH")
 
 	builder.WriteLine("Topic: %s", search.Topic)
-	builder.WriteLine("Timeout: %v", search.Timeout)
-	builder.WriteLine("Unique: %t", search.Unique)
+	if search.Codec != "" {
+		builder.WriteLine("Codec: %s", search.Codec)
+	}
+	if search.ExpectNoMessage {
+		builder.WriteLine("Expect No Message: %t", search.ExpectNoMessage)
+		builder.WriteLine("Window: %v", search.Timeout)
+	} else {
+		builder.WriteLine("Timeout: %v", search.Timeout)
+		builder.WriteLine("Unique: %t", search.Unique)
+	}
 
 	if len(search.Filters) > 0 {
 		builder.WriteSection("Filters")
@@ -356,3 +648,140 @@ func (r *Reporter) writeKafkaResultSection(builder *ReportBuilder, result KafkaR
 		builder.WriteTruncated(result.RawMessage, 2000)
 	}
 }
+
+type KafkaSequenceReportDTO struct {
+	Topic    string
+	Codec    string
+	Filters  map[string]string
+	Steps    []KafkaSequenceStepDTO
+	Complete bool
+	Failure  string
+	Polling  *PollingSummaryDTO
+}
+
+func (r *Reporter) AttachKafkaSequenceReport(sCtx provider.StepCtx, report KafkaSequenceReportDTO) {
+	builder := NewReportBuilder()
+
+	matched := 0
+	for _, step := range report.Steps {
+		if step.Matched {
+			matched++
+		}
+	}
+	status := "Complete"
+	if !report.Complete {
+		status = fmt.Sprintf("Incomplete (%d/%d)", matched, len(report.Steps))
+	}
+	builder.WriteHeader(fmt.Sprintf("Kafka %s → Sequence %s", report.Topic, status))
+
+	builder.WriteSectionHeader("SEARCH")
+	builder.WriteLine("Topic: %s", report.Topic)
+	if report.Codec != "" {
+		builder.WriteLine("Codec: %s", report.Codec)
+	}
+	if len(report.Filters) > 0 {
+		builder.WriteSection("Filters")
+		builder.WriteMap(report.Filters)
+	}
+
+	builder.WriteSectionHeader(fmt.Sprintf("STEPS [%s]", status))
+	for i, step := range report.Steps {
+		mark := "✗"
+		position := "not found"
+		if step.Matched {
+			mark = "✓"
+			position = fmt.Sprintf("partition %d, offset %d", step.Partition, step.Offset)
+		}
+		builder.WriteLine("%s [%d] %s: %s", mark, i+1, step.Name, position)
+		if len(step.Filters) > 0 {
+			builder.WriteMap(step.Filters)
+		}
+	}
+
+	if report.Failure != "" {
+		builder.WriteSection("Failure")
+		builder.WriteLine("%s", report.Failure)
+	}
+
+	for i, step := range report.Steps {
+		if step.Matched && step.Message != nil {
+			builder.WriteSection(fmt.Sprintf("Message [%d] %s", i+1, step.Name))
+			builder.WriteJSONOrError(step.Message)
+		}
+	}
+
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("Kafka Sequence", allure.Text, builder.Bytes())
+}
+
+type KafkaProduceReportDTO struct {
+	Message KafkaProduceMessageDTO
+	Result  KafkaProduceResultDTO
+}
+
+func (r *Reporter) AttachKafkaProduceReport(sCtx provider.StepCtx, report KafkaProduceReportDTO) {
+	builder := NewReportBuilder()
+
+	status := "Failed"
+	if report.Result.Delivered {
+		status = fmt.Sprintf("Delivered (partition %d, offset %d)", report.Result.Partition, report.Result.Offset)
+	}
+	title := fmt.Sprintf("Kafka %s ← %s", report.Message.Topic, status)
+	builder.WriteHeader(title)
+
+	r.writeKafkaProduceMessageSection(builder, report.Message)
+	r.writeKafkaProduceResultSection(builder, report.Result)
+
+	sCtx.WithNewAttachment("Kafka Produce", allure.Text, builder.Bytes())
+}
+
+func (r *Reporter) writeKafkaProduceMessageSection(builder *ReportBuilder, msg KafkaProduceMessageDTO) {
+	builder.WriteSectionHeader("MESSAGE")
+
+	builder.WriteLine("Topic: %s", msg.Topic)
+	if msg.Partition != nil {
+		builder.WriteLine("Partition: %d", *msg.Partition)
+	}
+	if msg.Key != "" {
+		builder.WriteLine("Key: %s", msg.Key)
+	}
+
+	if len(msg.Headers) > 0 {
+		builder.WriteSection("Headers")
+		for key, value := range msg.Headers {
+			builder.WriteKeyValue(key, r.Config.MaskHeader(key, value))
+		}
+	}
+
+	if msg.Value != nil {
+		builder.WriteSection("Value")
+		builder.WriteJSONOrError(msg.Value)
+	} else if len(msg.RawValue) > 0 {
+		builder.WriteSection("Value (raw)")
+		builder.WriteTruncated(msg.RawValue, 2000)
+	}
+}
+
+func (r *Reporter) writeKafkaProduceResultSection(builder *ReportBuilder, result KafkaProduceResultDTO) {
+	status := "Failed"
+	if result.Delivered {
+		status = "Delivered"
+	}
+	builder.WriteSectionHeader(fmt.Sprintf("RESULT [%s]", status))
+
+	builder.WriteLine("Delivered: %t", result.Delivered)
+	builder.WriteLine("Duration: %v", result.Duration)
+
+	if result.Delivered {
+		builder.WriteLine("Partition: %d", result.Partition)
+		builder.WriteLine("Offset: %d", result.Offset)
+	}
+
+	if result.Error != nil {
+		builder.WriteSection("Error")
+		builder.WriteKeyValue("Message", result.Error.Error())
+	}
+}
@@ -0,0 +1,160 @@
+package allure
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
+)
+
+const (
+	// ContractCoverageFile is written to the Allure results directory next to the test results.
+	ContractCoverageFile = "contract-coverage.json"
+	environmentFile      = "environment.properties"
+	contractEnvPrefix    = "contract."
+)
+
+// ResultsPath is the directory allure-go writes results to:
+// $ALLURE_OUTPUT_PATH/$ALLURE_OUTPUT_FOLDER, the folder defaulting to allure-results.
+func ResultsPath() string {
+	folder := os.Getenv("ALLURE_OUTPUT_FOLDER")
+	if folder == "" {
+		folder = "allure-results"
+	}
+	if path := os.Getenv("ALLURE_OUTPUT_PATH"); path != "" {
+		return filepath.Join(path, folder)
+	}
+	return folder
+}
+
+// resultsMu serializes writes of the shared results files by suites running in parallel.
+var resultsMu sync.Mutex
+
+// WriteContractCoverage writes the coverage reports as JSON to ContractCoverageFile and
+// their totals to environment.properties, keeping the entries other tools put there.
+// Files are replaced atomically, so readers never see a partial write.
+func WriteContractCoverage(dir string, reports []contract.CoverageReport) error {
+	resultsMu.Lock()
+	defer resultsMu.Unlock()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	keys := environmentKeys(reports)
+	raw, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(filepath.Join(dir, ContractCoverageFile), raw); err != nil {
+		return err
+	}
+
+	props := make(map[string]string)
+	for i, report := range reports {
+		key := contractEnvPrefix + keys[i]
+		props[key+".operations"] = coverageRatio(report.Summary.TestedOperations, report.Summary.Operations)
+		props[key+".responses"] = coverageRatio(report.Summary.TestedResponses, report.Summary.Responses)
+	}
+	return mergeEnvironment(filepath.Join(dir, environmentFile), props)
+}
+
+// mergeEnvironment replaces the contract.* entries of an environment.properties file.
+func mergeEnvironment(path string, props map[string]string) error {
+	var lines []string
+	if file, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			if line := scanner.Text(); !strings.HasPrefix(line, contractEnvPrefix) {
+				lines = append(lines, line)
+			}
+		}
+		file.Close()
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, key+"="+props[key])
+	}
+
+	return writeFileAtomic(path, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it over path.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// environmentKeys gives every report a distinct properties key segment: the title,
+// extended with the spec file name when titles collide or are empty, then with an index.
+func environmentKeys(reports []contract.CoverageReport) []string {
+	titles := make(map[string]int, len(reports))
+	for _, report := range reports {
+		titles[environmentKey(report.Title)]++
+	}
+
+	keys := make([]string, len(reports))
+	used := make(map[string]bool, len(reports))
+	for i, report := range reports {
+		key := environmentKey(report.Title)
+		if (titles[key] > 1 || strings.TrimSpace(report.Title) == "") && report.Spec != "" {
+			key += "-" + environmentKey(strings.TrimSuffix(report.Spec, filepath.Ext(report.Spec)))
+		}
+		base := key
+		for n := 2; used[key]; n++ {
+			key = fmt.Sprintf("%s-%d", base, n)
+		}
+		used[key] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+// environmentKey turns a spec title into a properties key segment, e.g. "Payments API" → "payments-api".
+func environmentKey(title string) string {
+	key := strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, title), "-")
+	if key == "" {
+		return "api"
+	}
+	return key
+}
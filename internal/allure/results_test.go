@@ -0,0 +1,113 @@
+package allure
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
+)
+
+func TestResultsPath(t *testing.T) {
+	t.Setenv("ALLURE_OUTPUT_PATH", "")
+	t.Setenv("ALLURE_OUTPUT_FOLDER", "")
+	assert.Equal(t, "allure-results", ResultsPath())
+
+	t.Setenv("ALLURE_OUTPUT_PATH", "/tmp/out")
+	assert.Equal(t, filepath.Join("/tmp/out", "allure-results"), ResultsPath())
+
+	t.Setenv("ALLURE_OUTPUT_FOLDER", "results")
+	assert.Equal(t, filepath.Join("/tmp/out", "results"), ResultsPath())
+}
+
+func TestWriteContractCoverage(t *testing.T) {
+	dir := t.TempDir()
+	env := filepath.Join(dir, "environment.properties")
+	require.NoError(t, os.WriteFile(env, []byte("Browser=none\ncontract.old.operations=1/1 (100.0%)\n"), 0644))
+
+	reports := []contract.CoverageReport{{
+		Title:   "Payments API",
+		Version: "1.0",
+		Summary: contract.CoverageSummary{Operations: 4, TestedOperations: 3, Responses: 10, TestedResponses: 5},
+	}}
+	require.NoError(t, WriteContractCoverage(dir, reports))
+
+	raw, err := os.ReadFile(filepath.Join(dir, ContractCoverageFile))
+	require.NoError(t, err)
+	var written []contract.CoverageReport
+	require.NoError(t, json.Unmarshal(raw, &written))
+	assert.Equal(t, reports[0].Summary, written[0].Summary)
+
+	props, err := os.ReadFile(env)
+	require.NoError(t, err)
+	assert.Equal(t, "Browser=none\n"+
+		"contract.payments-api.operations=3/4 (75.0%)\n"+
+		"contract.payments-api.responses=5/10 (50.0%)\n", string(props))
+}
+
+func TestEnvironmentKeys_Unique(t *testing.T) {
+	keys := environmentKeys([]contract.CoverageReport{
+		{Title: "Payments API", Spec: "payments.yaml"},
+		{Title: "Users API", Spec: "users.yaml"},
+		{Title: "Users API", Spec: "users-v2.yaml"},
+		{Spec: "billing.json"},
+		{},
+		{},
+	})
+	assert.Equal(t, []string{"payments-api", "users-api-users", "users-api-users-v2", "api-billing", "api", "api-2"}, keys)
+}
+
+func TestWriteContractCoverage_SameTitleKeepsBothSpecs(t *testing.T) {
+	dir := t.TempDir()
+	reports := []contract.CoverageReport{
+		{Title: "API", Spec: "orders.yaml", Summary: contract.CoverageSummary{Operations: 2, TestedOperations: 1}},
+		{Title: "API", Spec: "stock.yaml", Summary: contract.CoverageSummary{Operations: 4, TestedOperations: 4}},
+	}
+	require.NoError(t, WriteContractCoverage(dir, reports))
+
+	props, err := os.ReadFile(filepath.Join(dir, "environment.properties"))
+	require.NoError(t, err)
+	assert.Contains(t, string(props), "contract.api-orders.operations=1/2 (50.0%)")
+	assert.Contains(t, string(props), "contract.api-stock.operations=4/4 (100.0%)")
+}
+
+func TestWriteContractCoverage_Concurrent(t *testing.T) {
+	dir := t.TempDir()
+	env := filepath.Join(dir, "environment.properties")
+	require.NoError(t, os.WriteFile(env, []byte("Browser=none\n"), 0644))
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reports := []contract.CoverageReport{{
+				Title:   "Payments API",
+				Summary: contract.CoverageSummary{Operations: 20, TestedOperations: i},
+			}}
+			assert.NoError(t, WriteContractCoverage(dir, reports))
+		}()
+	}
+	wg.Wait()
+
+	props, err := os.ReadFile(env)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(props)), "\n")
+	assert.Len(t, lines, 3, "one write wins whole: %q", props)
+	assert.Equal(t, "Browser=none", lines[0])
+
+	raw, err := os.ReadFile(filepath.Join(dir, ContractCoverageFile))
+	require.NoError(t, err)
+	var written []contract.CoverageReport
+	require.NoError(t, json.Unmarshal(raw, &written))
+
+	leftovers, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, leftovers)
+}
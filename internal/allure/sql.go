@@ -124,3 +124,136 @@ func maskSQLResult(db *dbclient.Client, data any) any {
 		return data
 	}
 }
+
+type SQLExecReportDTO struct {
+	Request SQLRequestDTO
+	Result  SQLExecResultDTO
+}
+
+func (r *Reporter) AttachSQLExecReport(sCtx provider.StepCtx, db *dbclient.Client, report SQLExecReportDTO) {
+	builder := NewReportBuilder()
+
+	title := fmt.Sprintf("SQL Exec → %d row(s) affected", report.Result.RowsAffected)
+	if report.Result.Error != nil {
+		title = "SQL Exec → Error"
+	}
+	builder.WriteHeader(title)
+
+	r.writeSQLRequestSection(builder, report.Request)
+	r.writeSQLExecResultSection(builder, db, report.Result)
+
+	sCtx.WithNewAttachment("SQL Exec", allure.Text, builder.Bytes())
+}
+
+func (r *Reporter) writeSQLExecResultSection(builder *ReportBuilder, db *dbclient.Client, result SQLExecResultDTO) {
+	status := "OK"
+	if result.Error != nil {
+		status = "Error"
+	}
+	builder.WriteSectionHeader(fmt.Sprintf("RESULT [%s]", status))
+
+	if result.Duration > 0 {
+		builder.WriteLine("Duration: %v", result.Duration)
+	}
+	builder.WriteLine("Rows Affected: %d", result.RowsAffected)
+	if result.LastInsertID != 0 {
+		builder.WriteLine("Last Insert ID: %d", result.LastInsertID)
+	}
+	if result.Transactional {
+		if result.Committed {
+			builder.WriteLine("Transaction: committed")
+		} else {
+			builder.WriteLine("Transaction: rolled back")
+		}
+	}
+
+	if result.Error != nil {
+		builder.WriteSection("Error")
+		builder.WriteLine("%s", result.Error.Error())
+		return
+	}
+
+	if len(result.Rows) > 0 {
+		builder.WriteSection("Returning")
+		builder.WriteJSONOrError(maskSQLResult(db, CleanResult(result.Rows)))
+	}
+}
+
+type SQLDiffReportDTO struct {
+	Request SQLRequestDTO
+	Result  SQLDiffResultDTO
+	Polling *PollingSummaryDTO
+}
+
+func (r *Reporter) AttachSQLDiffReport(sCtx provider.StepCtx, db *dbclient.Client, report SQLDiffReportDTO) {
+	builder := NewReportBuilder()
+
+	res := report.Result
+	title := fmt.Sprintf("SQL Diff → +%d ~%d -%d", len(res.Inserted), len(res.Updated), len(res.Deleted))
+	if res.Error != nil {
+		title = "SQL Diff → Error"
+	} else if len(res.Inserted)+len(res.Updated)+len(res.Deleted) == 0 {
+		title = "SQL Diff → No Changes"
+	}
+	builder.WriteHeader(title)
+
+	r.writeSQLRequestSection(builder, report.Request)
+	r.writeSQLDiffResultSection(builder, db, res)
+
+	if report.Polling != nil && report.Polling.Attempts > 0 {
+		r.writePollingSection(builder, report.Polling)
+	}
+
+	sCtx.WithNewAttachment("SQL Diff", allure.Text, builder.Bytes())
+}
+
+func (r *Reporter) writeSQLDiffResultSection(builder *ReportBuilder, db *dbclient.Client, result SQLDiffResultDTO) {
+	status := "OK"
+	if result.Error != nil {
+		status = "Error"
+	} else if len(result.Failures) > 0 {
+		status = "Mismatch"
+	}
+	builder.WriteSectionHeader(fmt.Sprintf("DIFF %s [%s]", result.Table, status))
+
+	if result.Error != nil {
+		builder.WriteSection("Error")
+		builder.WriteLine("%s", result.Error.Error())
+		return
+	}
+
+	builder.WriteLine("Inserted: %d", len(result.Inserted))
+	builder.WriteLine("Updated: %d", len(result.Updated))
+	builder.WriteLine("Deleted: %d", len(result.Deleted))
+
+	if len(result.Inserted) > 0 {
+		builder.WriteSection("Inserted")
+		builder.WriteJSONOrError(maskSQLResult(db, CleanResult(result.Inserted)))
+	}
+
+	if len(result.Updated) > 0 {
+		builder.WriteSection("Updated")
+		for _, update := range result.Updated {
+			builder.WriteLine("  Row %s", update.Key)
+			for _, change := range update.Changes {
+				before, after := fmt.Sprintf("%v", change.Before), fmt.Sprintf("%v", change.After)
+				if db != nil && db.ShouldMaskColumn(change.Column) {
+					before, after = MaskValue, MaskValue
+				}
+				builder.WriteLine("    %s: %s → %s", change.Column, before, after)
+			}
+		}
+	}
+
+	if len(result.Deleted) > 0 {
+		builder.WriteSection("Deleted")
+		builder.WriteJSONOrError(maskSQLResult(db, CleanResult(result.Deleted)))
+	}
+
+	if len(result.Failures) > 0 {
+		builder.WriteSection("Failures")
+		for _, failure := range result.Failures {
+			builder.WriteLine("  ✗ %s", failure)
+		}
+	}
+}
@@ -58,3 +58,33 @@ func ToSQLResultDTO(p SQLResultParams) SQLResultDTO {
 
 	return dto
 }
+
+type SQLExecResultDTO struct {
+	RowsAffected  int64
+	LastInsertID  int64
+	Rows          []map[string]any
+	Duration      time.Duration
+	Transactional bool
+	Committed     bool
+	Error         error
+}
+
+type SQLColumnChangeDTO struct {
+	Column string
+	Before any
+	After  any
+}
+
+type SQLRowUpdateDTO struct {
+	Key     string
+	Changes []SQLColumnChangeDTO
+}
+
+type SQLDiffResultDTO struct {
+	Table    string
+	Inserted []any
+	Updated  []SQLRowUpdateDTO
+	Deleted  []any
+	Failures []string
+	Error    error
+}
@@ -2,9 +2,11 @@ package allure
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 )
 
 func CleanResult(v any) any {
@@ -116,6 +118,30 @@ func handleNullType(val reflect.Value) (bool, any) {
 			return true, nt.Time
 		}
 		return true, nil
+
+	case reflect.TypeOf(sql.NullInt16{}):
+		ni := val.Interface().(sql.NullInt16)
+		if ni.Valid {
+			return true, ni.Int16
+		}
+		return true, nil
+
+	case reflect.TypeOf(sql.NullByte{}):
+		nb := val.Interface().(sql.NullByte)
+		if nb.Valid {
+			return true, nb.Byte
+		}
+		return true, nil
+
+	case reflect.TypeOf(time.Time{}):
+		return true, val.Interface()
+	}
+
+	// sql.Null[T] and driver types such as ClickHouse/SQLite decimals report their value via driver.Valuer.
+	if valuer, ok := val.Interface().(driver.Valuer); ok {
+		if value, err := valuer.Value(); err == nil {
+			return true, value
+		}
 	}
 
 	return false, nil
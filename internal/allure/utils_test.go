@@ -0,0 +1,34 @@
+package allure
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanResult_NullAndDriverTypes(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := struct {
+		Status  sql.NullInt16     `db:"status"`
+		Flags   sql.NullByte      `db:"flags"`
+		Name    sql.Null[string]  `db:"name"`
+		Score   sql.Null[float64] `db:"score"`
+		Created time.Time         `db:"created_at"`
+		Deleted sql.NullTime      `db:"deleted_at"`
+	}{
+		Status:  sql.NullInt16{Int16: 2, Valid: true},
+		Name:    sql.Null[string]{V: "alice", Valid: true},
+		Created: created,
+	}
+
+	assert.Equal(t, map[string]any{
+		"status":     int16(2),
+		"flags":      nil,
+		"name":       "alice",
+		"score":      nil,
+		"created_at": created,
+		"deleted_at": nil,
+	}, CleanResult(row))
+}
@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -748,3 +750,59 @@ func TestMixedEnvStruct_HTTPAndGRPC(t *testing.T) {
 	assert.NotNil(t, env.Service.client)
 	assert.True(t, env.Async.Enabled)
 }
+
+func TestInjectGRPCClient_WaitReadyFails(t *testing.T) {
+	v := newTestViper(map[string]interface{}{
+		"grpc.test.target":    "127.0.0.1:1",
+		"grpc.test.insecure":  true,
+		"grpc.test.waitReady": "300ms",
+	})
+
+	type TestEnv struct {
+		GRPC mockGRPCLink `grpc_config:"grpc.test"`
+	}
+	env := &TestEnv{}
+
+	envValue := reflect.ValueOf(env).Elem()
+	err := grpcInjector.Inject(v, envValue.Field(0), envValue.Type().Field(0), "grpc.test", "TestEnv")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gRPC dependency is not ready")
+	assert.Nil(t, env.GRPC.client)
+}
+
+func TestInjectHTTPClient_WaitReady(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ready" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(srv.Close)
+
+	type TestEnv struct {
+		API mockHTTPLink `config:"http.api"`
+	}
+
+	env := &TestEnv{}
+	envValue := reflect.ValueOf(env).Elem()
+	v := newTestViper(map[string]interface{}{
+		"http.api.baseURL":    srv.URL,
+		"http.api.healthPath": "/ready",
+		"http.api.waitReady":  "1s",
+	})
+	require.NoError(t, httpInjector.Inject(v, envValue.Field(0), envValue.Type().Field(0), "http.api", "TestEnv"))
+	assert.NotNil(t, env.API.client)
+
+	env = &TestEnv{}
+	envValue = reflect.ValueOf(env).Elem()
+	v = newTestViper(map[string]interface{}{
+		"http.api.baseURL":   srv.URL,
+		"http.api.waitReady": "300ms",
+	})
+	err := httpInjector.Inject(v, envValue.Field(0), envValue.Type().Field(0), "http.api", "TestEnv")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "HTTP dependency is not ready")
+	assert.Contains(t, err.Error(), "404 Not Found")
+}
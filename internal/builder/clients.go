@@ -20,14 +20,26 @@ var httpInjector = &ClientInjector[httpclient.Client]{
 		if err := v.UnmarshalKey(configKey, &svcCfg); err != nil {
 			return nil, err
 		}
-		return httpclient.New(httpclient.Config{
-			BaseURL:          svcCfg.BaseURL,
-			Timeout:          svcCfg.Timeout,
-			DefaultHeaders:   svcCfg.DefaultHeaders,
-			MaskHeaders:      svcCfg.MaskHeaders,
-			ContractSpec:     svcCfg.ContractSpec,
-			ContractBasePath: svcCfg.ContractBasePath,
+		client, err := httpclient.New(httpclient.Config{
+			BaseURL:                  svcCfg.BaseURL,
+			Timeout:                  svcCfg.Timeout,
+			DefaultHeaders:           svcCfg.DefaultHeaders,
+			MaskHeaders:              svcCfg.MaskHeaders,
+			ContractSpec:             svcCfg.ContractSpec,
+			ContractBasePath:         svcCfg.ContractBasePath,
+			ContractValidateRequests: svcCfg.ContractValidateRequests,
+			HealthPath:               svcCfg.HealthPath,
+			Auth:                     svcCfg.Auth,
+			CookieJar:                svcCfg.CookieJar,
+			VCR:                      svcCfg.VCR,
 		})
+		if err != nil {
+			return nil, err
+		}
+		if err := waitReady("HTTP", svcCfg.WaitReady, client.WaitReady); err != nil {
+			return nil, err
+		}
+		return client, nil
 	},
 	SetOnTarget: func(target any, client *httpclient.Client) error {
 		if s, ok := target.(httpclient.HTTPSetter); ok {
@@ -82,6 +94,9 @@ var grpcInjector = (&ConfigClientInjector[grpcclient.Config, grpcclient.Client]{
 		}
 		return errNotSetter
 	},
+	WaitReady: func(cfg grpcclient.Config, client *grpcclient.Client) error {
+		return waitReady("gRPC", cfg.WaitReady, client.WaitReady)
+	},
 }).ToInjector()
 
 var kafkaInjector = (&ConfigClientInjector[kafkaclient.Config, kafkaclient.Client]{
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -62,6 +63,8 @@ type ConfigClientInjector[TConfig, TClient any] struct {
 	NewClient      func(TConfig) (*TClient, error)
 	SetAsync       func(*TConfig, config.AsyncConfig)
 	SetOnTarget    func(target any, client *TClient) error
+	// WaitReady, if set, blocks BuildEnv until the created client's dependency is up.
+	WaitReady func(TConfig, *TClient) error
 }
 
 func (i *ConfigClientInjector[TConfig, TClient]) ToInjector() *ClientInjector[TClient] {
@@ -92,9 +95,29 @@ func (i *ConfigClientInjector[TConfig, TClient]) createClient(v *viper.Viper, co
 		return nil, fmt.Errorf("failed to create %s client: %w", i.ClientName, err)
 	}
 
+	if i.WaitReady != nil {
+		if err := i.WaitReady(cfg, client); err != nil {
+			return nil, err
+		}
+	}
+
 	return client, nil
 }
 
+// waitReady calls wait when a waitReady timeout is configured (timeout > 0).
+func waitReady(clientName string, timeout time.Duration, wait func(time.Duration) error) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	debugLog("waiting up to %v for %s dependency to be ready", timeout, clientName)
+	if err := wait(timeout); err != nil {
+		return fmt.Errorf("%s dependency is not ready: %w", clientName, err)
+	}
+	debugLog("%s dependency is ready", clientName)
+	return nil
+}
+
 func loadAsyncConfig(v *viper.Viper, asyncKey, clientName string) config.AsyncConfig {
 	var asyncCfg config.AsyncConfig
 	if v.IsSet(asyncKey) {
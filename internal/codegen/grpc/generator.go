@@ -20,6 +20,7 @@ type Generator struct {
 
 type GenerationResult struct {
 	ClientFile   string
+	MockFile     string
 	MethodsCount int
 	ServiceName  string
 }
@@ -34,6 +35,9 @@ type MethodInfo struct {
 	InputType  string
 	OutputType string
 	FullMethod string
+
+	ClientStreaming bool
+	ServerStreaming bool
 }
 
 func NewGenerator(proto *parser.Proto, serviceName, moduleName, pbImport string) *Generator {
@@ -126,6 +130,9 @@ func (g *Generator) ParseService() (*ServiceInfo, error) {
 				Name:       rpc.RPCName,
 				InputType:  g.cleanTypeName(rpc.RPCRequest.MessageType),
 				OutputType: g.cleanTypeName(rpc.RPCResponse.MessageType),
+
+				ClientStreaming: rpc.RPCRequest.IsStream,
+				ServerStreaming: rpc.RPCResponse.IsStream,
 			}
 
 			if packageName != "" {
@@ -185,3 +192,38 @@ func (g *Generator) Generate(outputDir, clientPath string) (*GenerationResult, e
 		ServiceName:  serviceInfo.Name,
 	}, nil
 }
+
+// GenerateMock writes a stub server for the service to mockPath
+// (default: internal/grpc_mock/{service}).
+func (g *Generator) GenerateMock(outputDir, mockPath string) (*GenerationResult, error) {
+	sanitizedName := SanitizeServiceName(g.serviceName)
+
+	if mockPath == "" {
+		mockPath = filepath.Join(outputDir, "internal", "grpc_mock", sanitizedName)
+	}
+
+	if err := os.MkdirAll(mockPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create mock dir: %w", err)
+	}
+
+	serviceInfo, err := g.ParseService()
+	if err != nil {
+		return nil, err
+	}
+
+	mockFile := filepath.Join(mockPath, "mock.go")
+	mockCode, err := g.generateMock(serviceInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mock: %w", err)
+	}
+
+	if err := os.WriteFile(mockFile, []byte(mockCode), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write mock file: %w", err)
+	}
+
+	return &GenerationResult{
+		MockFile:     mockFile,
+		MethodsCount: len(serviceInfo.Methods),
+		ServiceName:  serviceInfo.Name,
+	}, nil
+}
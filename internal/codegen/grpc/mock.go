@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+func (g *Generator) generateMock(service *ServiceInfo) (string, error) {
+	var buf strings.Builder
+
+	buf.WriteString("// Code generated by grpc-gen. DO NOT EDIT.\n\n")
+
+	// The suffix keeps the package usable next to the generated client of the same service.
+	packageName := SanitizeServiceName(g.serviceName) + "mock"
+
+	buf.WriteString(fmt.Sprintf("package %s\n\n", packageName))
+
+	buf.WriteString("import (\n")
+	if hasStreamingMethods(service) {
+		buf.WriteString("\t\"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client\"\n")
+	}
+	buf.WriteString("\t\"github.com/gorelov-m-v/go-test-framework/pkg/grpc/mock\"\n")
+	if g.pbImport != "" {
+		buf.WriteString(fmt.Sprintf("\n\tpb \"%s\"\n", g.pbImport))
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString(fmt.Sprintf("// Mock is a programmable %s stub server. Every RPC of the service is\n", service.Name))
+	buf.WriteString("// registered; program it with Returns, ReturnsError, Handle or Delay and assert\n")
+	buf.WriteString("// the captured requests with Calls or ExpectCalled.\n")
+	buf.WriteString("type Mock struct {\n")
+	buf.WriteString("\t*mock.Server\n\n")
+	for _, method := range service.Methods {
+		buf.WriteString(fmt.Sprintf("\t%s *mock.Method[%s]\n", method.Name, g.mockTypeArgs(method)))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// New starts the %s mock on cfg.Addr; point the service under test at Target().\n", service.Name))
+	buf.WriteString("func New(cfg mock.Config) (*Mock, error) {\n")
+	buf.WriteString("\tserver, err := mock.New(cfg)\n")
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString("\t\treturn nil, err\n")
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\treturn &Mock{\n")
+	buf.WriteString("\t\tServer: server,\n\n")
+	for _, method := range service.Methods {
+		buf.WriteString(fmt.Sprintf("\t\t%s: %s,\n", method.Name, g.mockRegistration(method)))
+	}
+	buf.WriteString("\t}, nil\n")
+	buf.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return "", fmt.Errorf("generated mock is not valid Go: %w", err)
+	}
+	return string(formatted), nil
+}
+
+func (g *Generator) mockTypeArgs(method MethodInfo) string {
+	typePrefix := "pb."
+	if g.pbImport == "" {
+		typePrefix = ""
+	}
+	return fmt.Sprintf("%s%s, %s%s", typePrefix, method.InputType, typePrefix, method.OutputType)
+}
+
+func (g *Generator) mockRegistration(method MethodInfo) string {
+	var kind string
+	switch {
+	case method.ClientStreaming && method.ServerStreaming:
+		kind = "client.BidiStreaming"
+	case method.ClientStreaming:
+		kind = "client.ClientStreaming"
+	case method.ServerStreaming:
+		kind = "client.ServerStreaming"
+	default:
+		return fmt.Sprintf("mock.Register[%s](server, \"%s\")", g.mockTypeArgs(method), method.FullMethod)
+	}
+	return fmt.Sprintf("mock.RegisterStream[%s](server, \"%s\", %s)", g.mockTypeArgs(method), method.FullMethod, kind)
+}
+
+func hasStreamingMethods(service *ServiceInfo) bool {
+	for _, method := range service.Methods {
+		if method.ClientStreaming || method.ServerStreaming {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+// Package journal checks how many entries of a mock server's journal match a filter.
+// It backs the ExpectReceived and ExpectCalled verifications of the HTTP and gRPC mocks.
+package journal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+// Received is the journal state observed by a verification.
+type Received[T any] struct {
+	Matched []T
+	Total   int
+}
+
+// GetError implements retry.ErrorGetter; the journal itself never fails.
+func (r *Received[T]) GetError() error {
+	return nil
+}
+
+// Count is the expected number of matching entries.
+type Count struct {
+	want string
+	// check reports whether count is acceptable and whether more entries could still fix it.
+	check func(count int) (ok, retryable bool)
+}
+
+// Exactly expects n matching entries. Polling stops early once more than n arrived.
+func Exactly(n int) Count {
+	return Count{want: fmt.Sprintf("exactly %d", n), check: func(count int) (bool, bool) {
+		return count == n, count < n
+	}}
+}
+
+// AtLeast expects n or more matching entries.
+func AtLeast(n int) Count {
+	return Count{want: fmt.Sprintf("at least %d", n), check: func(count int) (bool, bool) {
+		return count >= n, true
+	}}
+}
+
+// None expects no matching entry at the time of the check; it is never retried.
+func None() Count {
+	return Count{want: "no", check: func(count int) (bool, bool) {
+		return count == 0, false
+	}}
+}
+
+// Verifier describes one verification of a mock journal.
+type Verifier[T any] struct {
+	// Step is the name of the Allure step, e.g. "HTTP mock received POST /payments".
+	Step string
+	// Noun names a journal entry in expectation messages, e.g. "request" or "call".
+	Noun        string
+	AsyncConfig config.AsyncConfig
+	// Read returns the matching entries and the journal size.
+	Read func() *Received[T]
+	// Attach adds the mock report of the final attempt to the step.
+	Attach func(stepCtx provider.StepCtx, result *Received[T], summary polling.PollingSummary)
+}
+
+// Verify checks count in a new step, polling the journal in async mode (AsyncStep),
+// and returns the matching entries of the final attempt.
+func (v Verifier[T]) Verify(ctx context.Context, stepCtx provider.StepCtx, count Count) []T {
+	var received *Received[T]
+	stepCtx.WithNewStep(v.Step, func(stepCtx provider.StepCtx) {
+		expectations := []*expect.Expectation[*Received[T]]{countExpectation[T](v.Noun, count)}
+
+		result, err, summary := retry.ExecuteDSL(retry.DSLConfig[*Received[T], *Received[T]]{
+			Ctx:          ctx,
+			StepCtx:      stepCtx,
+			AsyncConfig:  v.AsyncConfig,
+			Expectations: expectations,
+			Executor: func(context.Context) (*Received[T], error) {
+				return v.Read(), nil
+			},
+			PostProcess:      retry.PostProcessSummary[*Received[T]],
+			NilResultFactory: func(error) *Received[T] { return &Received[T]{} },
+		})
+		received = result
+
+		if v.Attach != nil {
+			v.Attach(stepCtx, result, summary)
+		}
+		expect.AssertExpectations(stepCtx, expectations, err, result, nil)
+	})
+
+	if received == nil {
+		return nil
+	}
+	return received.Matched
+}
+
+// countExpectation checks the number of matched entries against count.
+func countExpectation[T any](noun string, count Count) *expect.Expectation[*Received[T]] {
+	name := fmt.Sprintf("Expect %s matching %s(s)", count.want, noun)
+	return expect.New(
+		name,
+		func(err error, r *Received[T]) polling.CheckResult {
+			if err != nil {
+				return polling.CheckResult{Ok: false, Retryable: true, Reason: fmt.Sprintf("Journal read failed: %v", err)}
+			}
+			if r == nil {
+				return polling.CheckResult{Ok: false, Retryable: true, Reason: "Journal is nil"}
+			}
+			ok, retryable := count.check(len(r.Matched))
+			if !ok {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: retryable,
+					Reason:    fmt.Sprintf("Expected %s matching %s(s), got %d (of %d received)", count.want, noun, len(r.Matched), r.Total),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*Received[T]](name),
+	)
+}
@@ -0,0 +1,46 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func received(n int) *Received[string] {
+	return &Received[string]{Matched: make([]string, n), Total: n + 1}
+}
+
+func TestCountExpectation_Exactly(t *testing.T) {
+	exp := countExpectation[string]("request", Exactly(2))
+	assert.True(t, exp.Check(nil, received(2)).Ok)
+
+	res := exp.Check(nil, received(1))
+	assert.False(t, res.Ok)
+	assert.True(t, res.Retryable)
+	assert.Equal(t, "Expected exactly 2 matching request(s), got 1 (of 2 received)", res.Reason)
+
+	res = exp.Check(nil, received(3))
+	assert.False(t, res.Ok)
+	assert.False(t, res.Retryable, "more entries cannot fix an exceeded count")
+
+	assert.False(t, exp.Check(nil, nil).Ok)
+}
+
+func TestCountExpectation_AtLeastAndNone(t *testing.T) {
+	atLeast := countExpectation[string]("call", AtLeast(2))
+	assert.True(t, atLeast.Check(nil, received(3)).Ok)
+	res := atLeast.Check(nil, received(1))
+	assert.False(t, res.Ok)
+	assert.True(t, res.Retryable)
+	assert.Equal(t, "Expected at least 2 matching call(s), got 1 (of 2 received)", res.Reason)
+
+	none := countExpectation[string]("call", None())
+	assert.True(t, none.Check(nil, received(0)).Ok)
+	res = none.Check(nil, received(1))
+	assert.False(t, res.Ok)
+	assert.False(t, res.Retryable, "Never checks the journal once")
+}
+
+func TestReceived_GetError(t *testing.T) {
+	assert.NoError(t, received(1).GetError())
+}
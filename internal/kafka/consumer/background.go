@@ -51,7 +51,7 @@ func NewBackgroundConsumer(
 	}
 	saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
 
-	if err := applySaramaConfig(saramaConfig, cfg.SaramaConfig); err != nil {
+	if err := ApplySaramaConfig(saramaConfig, cfg.SaramaConfig); err != nil {
 		return nil, fmt.Errorf("failed to apply SaramaConfig: %w", err)
 	}
 
@@ -230,7 +230,9 @@ func (h *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 	}
 }
 
-func applySaramaConfig(saramaConfig *sarama.Config, userConfig map[string]interface{}) error {
+// ApplySaramaConfig sets user-provided values on saramaConfig by dot-separated field path
+// (e.g. "Consumer.Fetch.Default"). Shared by the background consumer and the producer.
+func ApplySaramaConfig(saramaConfig *sarama.Config, userConfig map[string]interface{}) error {
 	if userConfig == nil || len(userConfig) == 0 {
 		return nil
 	}
@@ -0,0 +1,31 @@
+package producer
+
+import "github.com/IBM/sarama"
+
+// explicitPartition marks a message whose Partition was set by the test
+// and must not be recalculated from the key.
+type explicitPartition struct{}
+
+type explicitPartitioner struct {
+	fallback sarama.Partitioner
+}
+
+// NewExplicitPartitioner routes messages marked with explicitPartition to their
+// Partition field and hashes the key for everything else.
+func NewExplicitPartitioner(topic string) sarama.Partitioner {
+	return &explicitPartitioner{fallback: sarama.NewHashPartitioner(topic)}
+}
+
+func (p *explicitPartitioner) Partition(message *sarama.ProducerMessage, numPartitions int32) (int32, error) {
+	if _, ok := message.Metadata.(explicitPartition); ok {
+		if message.Partition < 0 || message.Partition >= numPartitions {
+			return -1, sarama.ErrInvalidPartition
+		}
+		return message.Partition, nil
+	}
+	return p.fallback.Partition(message, numPartitions)
+}
+
+func (p *explicitPartitioner) RequiresConsistency() bool {
+	return p.fallback.RequiresConsistency()
+}
@@ -0,0 +1,121 @@
+package producer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/kafka/consumer"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
+)
+
+type ProducerConfig struct {
+	BootstrapServers []string
+	Version          string
+	SaramaConfig     map[string]interface{}
+}
+
+type SyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSyncProducer(cfg ProducerConfig) (*SyncProducer, error) {
+	saramaConfig, err := newSaramaConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	producer, err := sarama.NewSyncProducer(cfg.BootstrapServers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync producer: %w", err)
+	}
+
+	return NewSyncProducerFrom(producer), nil
+}
+
+// NewSyncProducerFrom wraps an existing sarama.SyncProducer (e.g. sarama/mocks in unit tests).
+func NewSyncProducerFrom(producer sarama.SyncProducer) *SyncProducer {
+	return &SyncProducer{producer: producer}
+}
+
+func newSaramaConfig(cfg ProducerConfig) (*sarama.Config, error) {
+	saramaConfig := sarama.NewConfig()
+
+	version, err := sarama.ParseKafkaVersion(cfg.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Kafka version: %w", err)
+	}
+	saramaConfig.Version = version
+
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.Return.Errors = true
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Partitioner = NewExplicitPartitioner
+
+	if err := consumer.ApplySaramaConfig(saramaConfig, cfg.SaramaConfig); err != nil {
+		return nil, fmt.Errorf("failed to apply SaramaConfig: %w", err)
+	}
+
+	return saramaConfig, nil
+}
+
+// Send publishes msg synchronously and returns it as delivered,
+// with the partition and offset assigned by the broker.
+func (p *SyncProducer) Send(msg *types.ProducerMessage) (*types.KafkaMessage, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("producer message is nil")
+	}
+
+	saramaMsg := toSaramaMessage(msg)
+	timestamp := time.Now()
+
+	partition, offset, err := p.producer.SendMessage(saramaMsg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send message to topic '%s': %w", msg.Topic, err)
+	}
+
+	return &types.KafkaMessage{
+		Topic:     msg.Topic,
+		Partition: partition,
+		Offset:    offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Timestamp: timestamp.UnixMilli(),
+		Headers:   msg.Headers,
+	}, nil
+}
+
+func (p *SyncProducer) Close() error {
+	if err := p.producer.Close(); err != nil {
+		return fmt.Errorf("failed to close producer: %w", err)
+	}
+	return nil
+}
+
+func toSaramaMessage(msg *types.ProducerMessage) *sarama.ProducerMessage {
+	saramaMsg := &sarama.ProducerMessage{
+		Topic: msg.Topic,
+	}
+
+	if msg.Key != nil {
+		saramaMsg.Key = sarama.ByteEncoder(msg.Key)
+	}
+	if msg.Value != nil {
+		saramaMsg.Value = sarama.ByteEncoder(msg.Value)
+	}
+
+	for name, value := range msg.Headers {
+		saramaMsg.Headers = append(saramaMsg.Headers, sarama.RecordHeader{
+			Key:   []byte(name),
+			Value: []byte(value),
+		})
+	}
+
+	if msg.Partition != nil {
+		saramaMsg.Partition = *msg.Partition
+		saramaMsg.Metadata = explicitPartition{}
+	}
+
+	return saramaMsg
+}
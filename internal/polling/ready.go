@@ -0,0 +1,29 @@
+package polling
+
+import (
+	"context"
+	"time"
+)
+
+// ReadyPollInterval is the pause between readiness checks.
+const ReadyPollInterval = 200 * time.Millisecond
+
+// WaitReady calls check every ReadyPollInterval until it returns nil.
+// When timeout expires first it returns the last error of check.
+func WaitReady(timeout time.Duration, check func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for {
+		lastErr := check(ctx)
+		if lastErr == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return lastErr
+		case <-time.After(ReadyPollInterval):
+		}
+	}
+}
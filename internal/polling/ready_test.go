@@ -0,0 +1,37 @@
+package polling
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitReady_RetriesUntilCheckPasses(t *testing.T) {
+	calls := 0
+	err := WaitReady(5*time.Second, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitReady_ReturnsLastErrorAfterTimeout(t *testing.T) {
+	calls := 0
+	err := WaitReady(300*time.Millisecond, func(ctx context.Context) error {
+		calls++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline, "check gets the timeout context")
+		return errors.New("attempt failed")
+	})
+
+	assert.EqualError(t, err, "attempt failed")
+	assert.GreaterOrEqual(t, calls, 2)
+}
@@ -0,0 +1,34 @@
+// Package redact masks secrets in HTTP header values while keeping them parseable.
+package redact
+
+import "strings"
+
+// Cookie masks every value of a Cookie header and keeps the names,
+// e.g. "lang=en;sid=abc" → "lang=***; sid=***".
+func Cookie(value, mask string) string {
+	pairs := strings.Split(value, ";")
+	for i, pair := range pairs {
+		pair = strings.TrimSpace(pair)
+		if name, _, found := strings.Cut(pair, "="); found {
+			pair = name + "=" + mask
+		}
+		pairs[i] = pair
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// SetCookie masks the value of a Set-Cookie header and keeps the name and attributes,
+// so the result still parses as a cookie, e.g. "sid=***; Path=/; HttpOnly".
+func SetCookie(value, mask string) string {
+	pair, attrs, _ := strings.Cut(value, ";")
+	name, _, found := strings.Cut(strings.TrimSpace(pair), "=")
+	if !found {
+		return value
+	}
+
+	masked := name + "=" + mask
+	if attrs != "" {
+		masked += ";" + attrs
+	}
+	return masked
+}
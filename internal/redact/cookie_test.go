@@ -0,0 +1,26 @@
+package redact
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCookie(t *testing.T) {
+	assert.Equal(t, "lang=***; sid=***", Cookie("lang=en;sid=abc", "***"))
+	assert.Equal(t, "flag", Cookie("flag", "***"))
+}
+
+func TestSetCookie(t *testing.T) {
+	masked := SetCookie("sid=abc; Path=/; HttpOnly", "***")
+	assert.Equal(t, "sid=***; Path=/; HttpOnly", masked)
+	assert.Equal(t, "lang=***", SetCookie("lang=en", "***"))
+	assert.Equal(t, "broken", SetCookie("broken", "***"))
+
+	cookie, err := http.ParseSetCookie(masked)
+	require.NoError(t, err, "the masked header is still a valid cookie")
+	assert.Equal(t, "sid", cookie.Name)
+	assert.True(t, cookie.HttpOnly)
+}
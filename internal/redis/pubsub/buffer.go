@@ -0,0 +1,83 @@
+package pubsub
+
+import (
+	"container/ring"
+	"sync"
+)
+
+// Message is a pub/sub message received by the background subscriber.
+// Pattern is set for messages delivered through a PSUBSCRIBE pattern.
+type Message struct {
+	Channel    string
+	Pattern    string
+	Payload    string
+	ReceivedAt int64
+}
+
+// MessageBuffer keeps the latest received messages in arrival order.
+type MessageBuffer struct {
+	mu   sync.Mutex
+	ring *ring.Ring
+	size int
+	cap  int
+}
+
+func NewMessageBuffer(bufferSize int) *MessageBuffer {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+
+	return &MessageBuffer{
+		ring: ring.New(bufferSize),
+		cap:  bufferSize,
+	}
+}
+
+func (mb *MessageBuffer) AddMessage(msg *Message) {
+	if msg == nil {
+		return
+	}
+
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.ring.Value = msg
+	mb.ring = mb.ring.Next()
+
+	if mb.size < mb.cap {
+		mb.size++
+	}
+}
+
+// GetMessages returns the buffered messages, oldest first.
+func (mb *MessageBuffer) GetMessages() []*Message {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	if mb.size == 0 {
+		return nil
+	}
+
+	messages := make([]*Message, 0, mb.size)
+
+	current := mb.ring
+	for i := 0; i < mb.size; i++ {
+		current = current.Prev()
+	}
+
+	for i := 0; i < mb.size; i++ {
+		if msg, ok := current.Value.(*Message); ok && msg != nil {
+			messages = append(messages, msg)
+		}
+		current = current.Next()
+	}
+
+	return messages
+}
+
+func (mb *MessageBuffer) Clear() {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+
+	mb.size = 0
+}
@@ -0,0 +1,94 @@
+package pubsub
+
+// MatchPattern reports whether channel matches a Redis glob pattern, with the semantics
+// of PSUBSCRIBE and KEYS: '*' matches any sequence (including '/'), '?' any single
+// character, '[abc]', '[^abc]' and '[a-z]' character classes, and '\' escapes.
+func MatchPattern(pattern, channel string) bool {
+	return globMatch([]byte(pattern), []byte(channel))
+}
+
+func globMatch(p, s []byte) bool {
+	for len(p) > 0 {
+		switch p[0] {
+		case '*':
+			for len(p) > 1 && p[1] == '*' {
+				p = p[1:]
+			}
+			if len(p) == 1 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if globMatch(p[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+			s = s[1:]
+		case '[':
+			if len(s) == 0 {
+				return false
+			}
+			var matched bool
+			matched, p = matchClass(p[1:], s[0])
+			if !matched {
+				return false
+			}
+			s = s[1:]
+			continue
+		case '\\':
+			if len(p) >= 2 {
+				p = p[1:]
+			}
+			fallthrough
+		default:
+			if len(s) == 0 || p[0] != s[0] {
+				return false
+			}
+			s = s[1:]
+		}
+		p = p[1:]
+	}
+	return len(s) == 0
+}
+
+// matchClass matches c against the class that follows '[' and returns the pattern after ']'.
+// An unterminated class ends at the end of the pattern, as in Redis.
+func matchClass(p []byte, c byte) (bool, []byte) {
+	negate := len(p) > 0 && p[0] == '^'
+	if negate {
+		p = p[1:]
+	}
+
+	matched := false
+	for len(p) > 0 && p[0] != ']' {
+		switch {
+		case p[0] == '\\' && len(p) >= 2:
+			if p[1] == c {
+				matched = true
+			}
+			p = p[2:]
+		case len(p) >= 3 && p[1] == '-':
+			lo, hi := p[0], p[2]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if c >= lo && c <= hi {
+				matched = true
+			}
+			p = p[3:]
+		default:
+			if p[0] == c {
+				matched = true
+			}
+			p = p[1:]
+		}
+	}
+	if len(p) > 0 {
+		p = p[1:]
+	}
+	return matched != negate, p
+}
@@ -0,0 +1,40 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		channel string
+		want    bool
+	}{
+		{"events:*", "events:player", true},
+		{"events:*", "events:player/42", true},
+		{"__keyspace@*__:player:*", "__keyspace@0__:player:42", true},
+		{"*", "", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxbyy", false},
+		{"user:?", "user:1", true},
+		{"user:?", "user:12", false},
+		{"user:[0-9]", "user:7", true},
+		{"user:[9-0]", "user:7", true},
+		{"user:[^0-9]", "user:7", false},
+		{"user:[abc]", "user:b", true},
+		{"user:[abc]", "user:d", false},
+		{`user:\*`, "user:*", true},
+		{`user:\*`, "user:1", false},
+		{"user:[", "user:x", false},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.channel, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchPattern(tt.pattern, tt.channel))
+		})
+	}
+}
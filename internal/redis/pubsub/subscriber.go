@@ -0,0 +1,235 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type SubscriberConfig struct {
+	Channels []string
+	Patterns []string
+}
+
+// Subscriber listens to channels and patterns in the background and stores every message in a buffer.
+// go-redis re-establishes the connection and subscriptions after network errors.
+//
+// Keyspace notifications (__keyspace@ and __keyevent@ channels) are published only on the node
+// that owns the key, so against a Redis Cluster they are subscribed on every master, while regular
+// channels, which PUBLISH broadcasts to the whole cluster, use a single connection.
+// Masters added after Start are not subscribed.
+type Subscriber struct {
+	rdb      redis.UniversalClient
+	buffer   *MessageBuffer
+	channels []string
+	patterns []string
+
+	pubsubs []*redis.PubSub
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	started bool
+}
+
+// subscription is a set of channels and patterns listened to on one connection.
+type subscription struct {
+	client interface {
+		Subscribe(ctx context.Context, channels ...string) *redis.PubSub
+	}
+	channels []string
+	patterns []string
+}
+
+func NewSubscriber(rdb redis.UniversalClient, cfg SubscriberConfig, buffer *MessageBuffer) (*Subscriber, error) {
+	if len(cfg.Channels) == 0 && len(cfg.Patterns) == 0 {
+		return nil, fmt.Errorf("no channels or patterns configured")
+	}
+
+	return &Subscriber{
+		rdb:      rdb,
+		buffer:   buffer,
+		channels: cfg.Channels,
+		patterns: cfg.Patterns,
+	}, nil
+}
+
+// Start subscribes and waits until Redis confirms every channel and pattern,
+// so messages published after Start returns are guaranteed to be buffered.
+func (s *Subscriber) Start(timeout time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("subscriber already started")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	subscriptions, err := s.plan(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pubsubs []*redis.PubSub
+	for _, sub := range subscriptions {
+		ps, err := s.subscribe(ctx, sub, timeout)
+		if err != nil {
+			closeAll(pubsubs)
+			return err
+		}
+		pubsubs = append(pubsubs, ps)
+	}
+
+	loopCtx, loopCancel := context.WithCancel(context.Background())
+	s.pubsubs = pubsubs
+	s.cancel = loopCancel
+	for _, ps := range pubsubs {
+		s.wg.Add(1)
+		go s.receiveLoop(loopCtx, ps.Channel())
+	}
+
+	s.started = true
+	return nil
+}
+
+// plan splits the subscriptions into connections: keyspace ones go to every cluster master.
+func (s *Subscriber) plan(ctx context.Context) ([]subscription, error) {
+	cluster, ok := s.rdb.(*redis.ClusterClient)
+	if !ok {
+		return []subscription{{client: s.rdb, channels: s.channels, patterns: s.patterns}}, nil
+	}
+
+	keyChannels, channels := splitKeyspace(s.channels)
+	keyPatterns, patterns := splitKeyspace(s.patterns)
+
+	var subscriptions []subscription
+	if len(channels) > 0 || len(patterns) > 0 {
+		subscriptions = append(subscriptions, subscription{client: s.rdb, channels: channels, patterns: patterns})
+	}
+	if len(keyChannels) == 0 && len(keyPatterns) == 0 {
+		return subscriptions, nil
+	}
+
+	var mu sync.Mutex
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+		subscriptions = append(subscriptions, subscription{client: node, channels: keyChannels, patterns: keyPatterns})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster masters for keyspace notifications: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (s *Subscriber) subscribe(ctx context.Context, sub subscription, timeout time.Duration) (*redis.PubSub, error) {
+	ps := sub.client.Subscribe(ctx)
+	if len(sub.channels) > 0 {
+		if err := ps.Subscribe(ctx, sub.channels...); err != nil {
+			_ = ps.Close()
+			return nil, fmt.Errorf("failed to subscribe to channels %v: %w", sub.channels, err)
+		}
+	}
+	if len(sub.patterns) > 0 {
+		if err := ps.PSubscribe(ctx, sub.patterns...); err != nil {
+			_ = ps.Close()
+			return nil, fmt.Errorf("failed to subscribe to patterns %v: %w", sub.patterns, err)
+		}
+	}
+
+	for confirmed := 0; confirmed < len(sub.channels)+len(sub.patterns); {
+		reply, err := ps.Receive(ctx)
+		if err != nil {
+			_ = ps.Close()
+			return nil, fmt.Errorf("subscription not confirmed within %v: %w", timeout, err)
+		}
+		switch m := reply.(type) {
+		case *redis.Subscription:
+			confirmed++
+		case *redis.Message:
+			s.buffer.AddMessage(toMessage(m))
+		}
+	}
+	return ps, nil
+}
+
+func (s *Subscriber) Stop() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		return nil
+	}
+
+	s.cancel()
+	err := closeAll(s.pubsubs)
+	s.wg.Wait()
+
+	s.pubsubs = nil
+	s.started = false
+	if err != nil {
+		return fmt.Errorf("failed to close subscription: %w", err)
+	}
+	return nil
+}
+
+func closeAll(pubsubs []*redis.PubSub) error {
+	var errs []error
+	for _, ps := range pubsubs {
+		if err := ps.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// splitKeyspace separates keyspace notification channels from regular ones.
+func splitKeyspace(names []string) (keyspace, regular []string) {
+	for _, name := range names {
+		if strings.HasPrefix(name, "__keyspace@") || strings.HasPrefix(name, "__keyevent@") {
+			keyspace = append(keyspace, name)
+		} else {
+			regular = append(regular, name)
+		}
+	}
+	return keyspace, regular
+}
+
+// Subscriptions returns the configured channels followed by the patterns.
+func (s *Subscriber) Subscriptions() []string {
+	all := make([]string, 0, len(s.channels)+len(s.patterns))
+	all = append(all, s.channels...)
+	return append(all, s.patterns...)
+}
+
+func (s *Subscriber) receiveLoop(ctx context.Context, messages <-chan *redis.Message) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			s.buffer.AddMessage(toMessage(msg))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func toMessage(msg *redis.Message) *Message {
+	return &Message{
+		Channel:    msg.Channel,
+		Pattern:    msg.Pattern,
+		Payload:    msg.Payload,
+		ReceivedAt: time.Now().UnixMilli(),
+	}
+}
@@ -0,0 +1,99 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageBuffer_KeepsLatestInOrder(t *testing.T) {
+	buffer := NewMessageBuffer(3)
+	for i := 1; i <= 5; i++ {
+		buffer.AddMessage(&Message{Channel: "c", Payload: fmt.Sprint(i)})
+	}
+	buffer.AddMessage(nil)
+
+	messages := buffer.GetMessages()
+	require.Len(t, messages, 3)
+	assert.Equal(t, "3", messages[0].Payload)
+	assert.Equal(t, "5", messages[2].Payload)
+
+	buffer.Clear()
+	assert.Empty(t, buffer.GetMessages())
+}
+
+func TestSubscriber_BuffersChannelAndPatternMessages(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	buffer := NewMessageBuffer(10)
+	subscriber, err := NewSubscriber(rdb, SubscriberConfig{
+		Channels: []string{"cache:invalidate"},
+		Patterns: []string{"events:*"},
+	}, buffer)
+	require.NoError(t, err)
+	require.NoError(t, subscriber.Start(5*time.Second))
+	assert.Equal(t, []string{"cache:invalidate", "events:*"}, subscriber.Subscriptions())
+
+	ctx := context.Background()
+	require.NoError(t, rdb.Publish(ctx, "cache:invalidate", `{"playerId":42}`).Err())
+	require.NoError(t, rdb.Publish(ctx, "events:player", "created").Err())
+	require.NoError(t, rdb.Publish(ctx, "other", "ignored").Err())
+
+	require.Eventually(t, func() bool { return len(buffer.GetMessages()) == 2 }, 2*time.Second, 10*time.Millisecond)
+	messages := buffer.GetMessages()
+	assert.Equal(t, "cache:invalidate", messages[0].Channel)
+	assert.Empty(t, messages[0].Pattern)
+	assert.Equal(t, "events:player", messages[1].Channel)
+	assert.Equal(t, "events:*", messages[1].Pattern)
+	assert.NotZero(t, messages[1].ReceivedAt)
+
+	require.NoError(t, subscriber.Stop())
+	require.NoError(t, subscriber.Stop())
+}
+
+func TestNewSubscriber_RequiresSubscriptions(t *testing.T) {
+	_, err := NewSubscriber(nil, SubscriberConfig{}, NewMessageBuffer(1))
+	assert.ErrorContains(t, err, "no channels or patterns")
+}
+
+func TestSubscriber_ClusterSubscribesKeyspaceOnEveryMaster(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	buffer := NewMessageBuffer(10)
+	subscriber, err := NewSubscriber(rdb, SubscriberConfig{
+		Channels: []string{"cache:invalidate"},
+		Patterns: []string{"__keyspace@*__:player:*"},
+	}, buffer)
+	require.NoError(t, err)
+
+	plan, err := subscriber.plan(context.Background())
+	require.NoError(t, err)
+	require.Len(t, plan, 2, "one connection for regular channels and one per master")
+	assert.Equal(t, []string{"cache:invalidate"}, plan[0].channels)
+	assert.Empty(t, plan[0].patterns)
+	assert.Empty(t, plan[1].channels)
+	assert.Equal(t, []string{"__keyspace@*__:player:*"}, plan[1].patterns)
+	_, onNode := plan[1].client.(*redis.Client)
+	assert.True(t, onNode)
+
+	require.NoError(t, subscriber.Start(5*time.Second))
+	t.Cleanup(func() { _ = subscriber.Stop() })
+
+	ctx := context.Background()
+	require.NoError(t, rdb.Publish(ctx, "cache:invalidate", "1").Err())
+	require.NoError(t, rdb.Publish(ctx, "__keyspace@0__:player:42", "set").Err())
+
+	require.Eventually(t, func() bool { return len(buffer.GetMessages()) == 2 }, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.Len(t, buffer.GetMessages(), 2, "regular channels are not received twice")
+}
@@ -0,0 +1,82 @@
+package testscope
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// Scope holds per-test resources (e.g. database transactions) that must be
+// released when the test ends. BaseSuite creates one scope per test and closes it in AfterEach.
+type Scope struct {
+	mu       sync.Mutex
+	values   map[any]any
+	cleanups []func() error
+	closed   bool
+}
+
+// Provider is implemented by step contexts that carry the scope of the running test.
+type Provider interface {
+	provider.StepCtx
+	TestScope() *Scope
+}
+
+func New() *Scope {
+	return &Scope{values: make(map[any]any)}
+}
+
+// FromStepCtx returns the scope of the test the step belongs to, or nil outside BaseSuite.
+func FromStepCtx(stepCtx provider.StepCtx) *Scope {
+	if p, ok := stepCtx.(Provider); ok {
+		return p.TestScope()
+	}
+	return nil
+}
+
+// GetOrCreate returns the value stored under key, creating it on first use.
+// The cleanup returned by create (may be nil) runs on Close. create runs under
+// the scope lock, so concurrent async steps share a single value.
+func (s *Scope) GetOrCreate(key any, create func() (value any, cleanup func() error, err error)) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, errors.New("test scope is already closed")
+	}
+	if v, ok := s.values[key]; ok {
+		return v, nil
+	}
+
+	v, cleanup, err := create()
+	if err != nil {
+		return nil, err
+	}
+	s.values[key] = v
+	if cleanup != nil {
+		s.cleanups = append(s.cleanups, cleanup)
+	}
+	return v, nil
+}
+
+// Close runs all cleanups in reverse order and returns their joined errors.
+// Calling Close more than once is a no-op.
+func (s *Scope) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	var errs []error
+	for i := len(s.cleanups) - 1; i >= 0; i-- {
+		if err := s.cleanups[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.cleanups = nil
+	s.values = nil
+	return errors.Join(errs...)
+}
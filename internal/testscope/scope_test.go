@@ -0,0 +1,100 @@
+package testscope
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScope_GetOrCreate_CreatesOnce(t *testing.T) {
+	s := New()
+	created := 0
+	create := func() (any, func() error, error) {
+		created++
+		return "tx", nil, nil
+	}
+
+	v1, err := s.GetOrCreate("db", create)
+	require.NoError(t, err)
+	v2, err := s.GetOrCreate("db", create)
+	require.NoError(t, err)
+
+	assert.Equal(t, "tx", v1)
+	assert.Equal(t, v1, v2)
+	assert.Equal(t, 1, created)
+}
+
+func TestScope_GetOrCreate_Concurrent(t *testing.T) {
+	s := New()
+	var mu sync.Mutex
+	created := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = s.GetOrCreate("db", func() (any, func() error, error) {
+				mu.Lock()
+				created++
+				mu.Unlock()
+				return 1, nil, nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, created)
+}
+
+func TestScope_GetOrCreate_ErrorIsNotCached(t *testing.T) {
+	s := New()
+
+	_, err := s.GetOrCreate("db", func() (any, func() error, error) { return nil, nil, errors.New("boom") })
+	assert.EqualError(t, err, "boom")
+
+	v, err := s.GetOrCreate("db", func() (any, func() error, error) { return "ok", nil, nil })
+	require.NoError(t, err)
+	assert.Equal(t, "ok", v)
+}
+
+func TestScope_Close_RunsCleanupsInReverseOrder(t *testing.T) {
+	s := New()
+	var order []string
+
+	for _, key := range []string{"first", "second"} {
+		_, err := s.GetOrCreate(key, func() (any, func() error, error) {
+			return key, func() error {
+				order = append(order, key)
+				return nil
+			}, nil
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, s.Close())
+	assert.Equal(t, []string{"second", "first"}, order)
+}
+
+func TestScope_Close_JoinsErrorsAndIsIdempotent(t *testing.T) {
+	s := New()
+	calls := 0
+	_, _ = s.GetOrCreate("a", func() (any, func() error, error) {
+		return 1, func() error { calls++; return errors.New("rollback failed") }, nil
+	})
+
+	err := s.Close()
+	assert.ErrorContains(t, err, "rollback failed")
+	assert.NoError(t, s.Close())
+	assert.Equal(t, 1, calls)
+
+	_, err = s.GetOrCreate("b", func() (any, func() error, error) { return 1, nil, nil })
+	assert.Error(t, err)
+}
+
+func TestFromStepCtx_Nil(t *testing.T) {
+	assert.Nil(t, FromStepCtx(nil))
+}
@@ -0,0 +1,22 @@
+package config
+
+// AuthConfig describes how HTTP requests are authenticated.
+// Type is "basic", "bearer" or "oauth2"; empty disables authentication.
+type AuthConfig struct {
+	Type     string       `mapstructure:"type" yaml:"type" json:"type"`
+	Username string       `mapstructure:"username" yaml:"username" json:"username"`
+	Password string       `mapstructure:"password" yaml:"password" json:"password"`
+	Token    string       `mapstructure:"token" yaml:"token" json:"token"`
+	OAuth2   OAuth2Config `mapstructure:"oauth2" yaml:"oauth2" json:"oauth2"`
+}
+
+// OAuth2Config is the token endpoint used by the "oauth2" auth type.
+// GrantType is "client_credentials" (default) or "password"; the password grant
+// uses AuthConfig.Username and AuthConfig.Password.
+type OAuth2Config struct {
+	TokenURL     string   `mapstructure:"tokenURL" yaml:"tokenURL" json:"tokenURL"`
+	GrantType    string   `mapstructure:"grantType" yaml:"grantType" json:"grantType"`
+	ClientID     string   `mapstructure:"clientID" yaml:"clientID" json:"clientID"`
+	ClientSecret string   `mapstructure:"clientSecret" yaml:"clientSecret" json:"clientSecret"`
+	Scopes       []string `mapstructure:"scopes" yaml:"scopes" json:"scopes"`
+}
@@ -24,6 +24,13 @@ type ServiceConfig struct {
 	MaskHeaders      string            `mapstructure:"maskHeaders"`
 	ContractSpec     string            `mapstructure:"contractSpec"`
 	ContractBasePath string            `mapstructure:"contractBasePath"`
+	// ContractValidateRequests validates every outgoing request against contractSpec.
+	ContractValidateRequests bool          `mapstructure:"contractValidateRequests"`
+	HealthPath               string        `mapstructure:"healthPath"`
+	WaitReady                time.Duration `mapstructure:"waitReady"`
+	Auth                     AuthConfig    `mapstructure:"auth"`
+	CookieJar                bool          `mapstructure:"cookieJar"`
+	VCR                      VCRConfig     `mapstructure:"vcr"`
 }
 
 type AllureConfig struct {
@@ -0,0 +1,8 @@
+package config
+
+// MockConfig configures an HTTP or gRPC mock server.
+type MockConfig struct {
+	// Addr is the listen address; empty picks a free local port.
+	Addr        string      `mapstructure:"addr" yaml:"addr" json:"addr"`
+	AsyncConfig AsyncConfig `mapstructure:"async" yaml:"async" json:"async"`
+}
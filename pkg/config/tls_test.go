@@ -0,0 +1,83 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestCert(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func TestTLSConfig_Build_Disabled(t *testing.T) {
+	tlsCfg, err := TLSConfig{CAFile: "ignored.pem"}.Build()
+
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+}
+
+func TestTLSConfig_Build_CAAndClientCert(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+
+	tlsCfg, err := TLSConfig{
+		Enabled:    true,
+		CAFile:     certFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "redis.internal",
+	}.Build()
+
+	require.NoError(t, err)
+	assert.NotNil(t, tlsCfg.RootCAs)
+	assert.Len(t, tlsCfg.Certificates, 1)
+	assert.Equal(t, "redis.internal", tlsCfg.ServerName)
+	assert.False(t, tlsCfg.InsecureSkipVerify)
+}
+
+func TestTLSConfig_Build_Errors(t *testing.T) {
+	certFile, keyFile := writeTestCert(t)
+
+	_, err := TLSConfig{Enabled: true, CAFile: filepath.Join(t.TempDir(), "missing.pem")}.Build()
+	assert.ErrorContains(t, err, "failed to read TLS CA file")
+
+	_, err = TLSConfig{Enabled: true, CAFile: keyFile}.Build()
+	assert.ErrorContains(t, err, "contains no PEM certificates")
+
+	_, err = TLSConfig{Enabled: true, CertFile: certFile}.Build()
+	assert.ErrorContains(t, err, "must be set together")
+}
@@ -0,0 +1,13 @@
+package config
+
+// VCRConfig enables recording HTTP exchanges to cassette files and replaying them
+// without the network. Mode is "record", "replay" or "auto" (replay recorded
+// exchanges, record missing ones); empty disables the VCR.
+//
+// Headers listed in maskHeaders and credential headers are redacted in cassettes;
+// RedactBodyFields lists JSON (and form) field names whose values are redacted too.
+type VCRConfig struct {
+	Mode             string   `mapstructure:"mode" yaml:"mode" json:"mode"`
+	CassetteDir      string   `mapstructure:"cassetteDir" yaml:"cassetteDir" json:"cassetteDir"`
+	RedactBodyFields []string `mapstructure:"redactBodyFields" yaml:"redactBodyFields" json:"redactBodyFields"`
+}
@@ -22,6 +22,7 @@ type Config struct {
 	MaskColumns     string             `mapstructure:"maskColumns" yaml:"maskColumns" json:"maskColumns"`
 	Schemas         map[string]string  `mapstructure:"schemas" yaml:"schemas" json:"schemas"`
 	AsyncConfig     config.AsyncConfig `mapstructure:"async" yaml:"async" json:"async"`
+	IsolateTests    bool               `mapstructure:"isolateTests" yaml:"isolateTests" json:"isolateTests"`
 }
 
 type Client struct {
@@ -29,18 +30,30 @@ type Client struct {
 	AsyncConfig config.AsyncConfig
 	maskColumns []string
 	schemas     map[string]string
+
+	isolateTests bool
 }
 
 func New(cfg Config) (*Client, error) {
+	supported := strings.Join(supportedDrivers, "', '")
 	if cfg.Driver == "" {
-		return nil, fmt.Errorf("driver is required: must be 'mysql' or 'postgres'")
+		return nil, fmt.Errorf("driver is required: must be one of '%s'", supported)
+	}
+
+	driver := normalizeDriver(cfg.Driver)
+	if !isSupportedDriver(driver) {
+		return nil, fmt.Errorf("unsupported driver '%s': must be one of '%s'", cfg.Driver, supported)
+	}
+
+	if driver == DriverClickHouse && cfg.IsolateTests {
+		return nil, fmt.Errorf("isolateTests is not supported for clickhouse: it has no transactions to roll back")
 	}
 
-	if cfg.Driver != "mysql" && cfg.Driver != "postgres" {
-		return nil, fmt.Errorf("unsupported driver '%s': must be 'mysql' or 'postgres'", cfg.Driver)
+	if err := requireRegistered(driver); err != nil {
+		return nil, err
 	}
 
-	db, err := sqlx.Open(cfg.Driver, cfg.DSN)
+	db, err := sqlx.Open(driver, cfg.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open db connection: %w", err)
 	}
@@ -53,6 +66,9 @@ func New(cfg Config) (*Client, error) {
 
 	if cfg.MaxOpenConns > 0 {
 		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	} else if driver == DriverSQLite {
+		// Every connection to ":memory:" is a separate database; one connection keeps them consistent.
+		db.SetMaxOpenConns(1)
 	}
 	if cfg.MaxIdleConns > 0 {
 		db.SetMaxIdleConns(cfg.MaxIdleConns)
@@ -74,7 +90,13 @@ func New(cfg Config) (*Client, error) {
 
 	asyncCfg := cfg.AsyncConfig.WithDefaults()
 
-	return &Client{DB: db, AsyncConfig: asyncCfg, maskColumns: maskColumns, schemas: cfg.Schemas}, nil
+	return &Client{
+		DB:           db,
+		AsyncConfig:  asyncCfg,
+		maskColumns:  maskColumns,
+		schemas:      cfg.Schemas,
+		isolateTests: cfg.IsolateTests,
+	}, nil
 }
 
 func (c *Client) ShouldMaskColumn(name string) bool {
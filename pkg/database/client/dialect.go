@@ -0,0 +1,204 @@
+package client
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Supported driver names for Config.Driver.
+const (
+	DriverMySQL      = "mysql"
+	DriverPostgres   = "postgres"
+	DriverSQLite     = "sqlite3"
+	DriverClickHouse = "clickhouse"
+)
+
+var supportedDrivers = []string{DriverMySQL, DriverPostgres, DriverSQLite, DriverClickHouse}
+
+// optionalDriverImports are drivers the package does not link in: SQLite needs cgo and
+// ClickHouse brings a large dependency tree, so tests that use them import the driver.
+var optionalDriverImports = map[string]string{
+	DriverSQLite:     "github.com/mattn/go-sqlite3",
+	DriverClickHouse: "github.com/ClickHouse/clickhouse-go/v2",
+}
+
+func init() {
+	// clickhouse-go accepts positional ? placeholders, sqlx does not know the driver.
+	sqlx.BindDriver(DriverClickHouse, sqlx.QUESTION)
+}
+
+// normalizeDriver maps accepted aliases to the registered database/sql driver name.
+func normalizeDriver(driver string) string {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "sqlite", "sqlite3":
+		return DriverSQLite
+	case "postgresql", "postgres":
+		return DriverPostgres
+	default:
+		return strings.ToLower(strings.TrimSpace(driver))
+	}
+}
+
+// requireRegistered explains how to enable an optional driver that was not imported.
+func requireRegistered(driver string) error {
+	if slices.Contains(sql.Drivers(), driver) {
+		return nil
+	}
+	if path, ok := optionalDriverImports[driver]; ok {
+		return fmt.Errorf("driver '%s' is not registered: add `import _ \"%s\"` to your tests", driver, path)
+	}
+	return fmt.Errorf("driver '%s' is not registered", driver)
+}
+
+func isSupportedDriver(driver string) bool {
+	for _, d := range supportedDrivers {
+		if d == driver {
+			return true
+		}
+	}
+	return false
+}
+
+// Driver returns the database/sql driver name of the connection.
+func (c *Client) Driver() string {
+	if c.DB == nil {
+		return ""
+	}
+	return c.DB.DriverName()
+}
+
+// Rebind converts placeholders to the dialect of the client's driver, so the same query
+// works against every supported store: ? becomes $1, $2 for PostgreSQL and $N becomes ?
+// (with arguments reordered) for MySQL, SQLite and ClickHouse.
+//
+// Queries already written in the target style, queries mixing both styles and queries whose
+// placeholder count does not match args are returned unchanged. Placeholders inside quoted
+// strings and identifiers are ignored.
+func (c *Client) Rebind(query string, args []any) (string, []any) {
+	if len(args) == 0 {
+		return query, args
+	}
+
+	questions, dollars := scanPlaceholders(query)
+
+	switch sqlx.BindType(c.Driver()) {
+	case sqlx.DOLLAR:
+		if len(dollars) == 0 && questions == len(args) {
+			return questionToDollar(query), args
+		}
+	case sqlx.QUESTION:
+		if questions == 0 && len(dollars) > 0 {
+			if rebound, reordered, ok := dollarToQuestion(query, args); ok {
+				return rebound, reordered
+			}
+		}
+	}
+	return query, args
+}
+
+// scanPlaceholders counts ? placeholders and collects $N placeholder numbers outside quotes.
+func scanPlaceholders(query string) (int, []int) {
+	questions := 0
+	var dollars []int
+	walkPlaceholders(query, func(token string) string {
+		if token == "?" {
+			questions++
+		} else {
+			n, _ := strconv.Atoi(token[1:])
+			dollars = append(dollars, n)
+		}
+		return token
+	})
+	return questions, dollars
+}
+
+func questionToDollar(query string) string {
+	n := 0
+	return walkPlaceholders(query, func(token string) string {
+		if token != "?" {
+			return token
+		}
+		n++
+		return "$" + strconv.Itoa(n)
+	})
+}
+
+func dollarToQuestion(query string, args []any) (string, []any, bool) {
+	reordered := make([]any, 0, len(args))
+	ok := true
+	rebound := walkPlaceholders(query, func(token string) string {
+		if token == "?" {
+			return token
+		}
+		n, _ := strconv.Atoi(token[1:])
+		if n < 1 || n > len(args) {
+			ok = false
+			return token
+		}
+		reordered = append(reordered, args[n-1])
+		return "?"
+	})
+	return rebound, reordered, ok
+}
+
+// walkPlaceholders calls replace for every ? and $N token outside quoted strings,
+// quoted identifiers and comments, and returns the query with the replacements applied.
+func walkPlaceholders(query string, replace func(token string) string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '\'' || ch == '"' || ch == '`':
+			end := i + 1
+			for end < len(query) {
+				if query[end] == ch {
+					if end+1 < len(query) && query[end+1] == ch {
+						end += 2
+						continue
+					}
+					break
+				}
+				if query[end] == '\\' && ch == '\'' {
+					end++
+				}
+				end++
+			}
+			if end >= len(query) {
+				end = len(query) - 1
+			}
+			b.WriteString(query[i : end+1])
+			i = end
+		case ch == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case ch == '?':
+			b.WriteString(replace("?"))
+		case ch == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			end := i + 1
+			for end < len(query) && isDigit(query[end]) {
+				end++
+			}
+			b.WriteString(replace(query[i:end]))
+			i = end - 1
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
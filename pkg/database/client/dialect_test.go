@@ -0,0 +1,107 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clientWithDriver(driver string) *Client {
+	return &Client{DB: sqlx.NewDb(nil, driver)}
+}
+
+func TestRebind_QuestionToDollarForPostgres(t *testing.T) {
+	c := clientWithDriver(DriverPostgres)
+
+	query, args := c.Rebind("SELECT * FROM users WHERE id = ? AND name = ?", []any{1, "john"})
+
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1 AND name = $2", query)
+	assert.Equal(t, []any{1, "john"}, args)
+}
+
+func TestRebind_DollarToQuestionReordersArgs(t *testing.T) {
+	for _, driver := range []string{DriverMySQL, DriverSQLite, DriverClickHouse} {
+		t.Run(driver, func(t *testing.T) {
+			c := clientWithDriver(driver)
+
+			query, args := c.Rebind("SELECT * FROM users WHERE name = $2 AND id = $1 OR parent_id = $1", []any{1, "john"})
+
+			assert.Equal(t, "SELECT * FROM users WHERE name = ? AND id = ? OR parent_id = ?", query)
+			assert.Equal(t, []any{"john", 1, 1}, args)
+		})
+	}
+}
+
+func TestRebind_LeavesNativeStyleUnchanged(t *testing.T) {
+	query, args := clientWithDriver(DriverPostgres).Rebind("SELECT * FROM users WHERE id = $1", []any{1})
+	assert.Equal(t, "SELECT * FROM users WHERE id = $1", query)
+	assert.Equal(t, []any{1}, args)
+
+	query, _ = clientWithDriver(DriverSQLite).Rebind("SELECT * FROM users WHERE id = ?", []any{1})
+	assert.Equal(t, "SELECT * FROM users WHERE id = ?", query)
+}
+
+func TestRebind_IgnoresQuotesAndComments(t *testing.T) {
+	c := clientWithDriver(DriverPostgres)
+
+	query, _ := c.Rebind("SELECT '?', \"a?b\", `c?` FROM t -- why?\nWHERE id = ?", []any{1})
+
+	assert.Equal(t, "SELECT '?', \"a?b\", `c?` FROM t -- why?\nWHERE id = $1", query)
+}
+
+func TestRebind_UnchangedOnMismatch(t *testing.T) {
+	// JSONB ? operator: placeholder count does not match args.
+	query, _ := clientWithDriver(DriverPostgres).Rebind("SELECT * FROM t WHERE data ? 'key' AND id = ?", []any{1})
+	assert.Equal(t, "SELECT * FROM t WHERE data ? 'key' AND id = ?", query)
+
+	query, args := clientWithDriver(DriverMySQL).Rebind("SELECT * FROM t WHERE id = $3", []any{1})
+	assert.Equal(t, "SELECT * FROM t WHERE id = $3", query)
+	assert.Equal(t, []any{1}, args)
+
+	query, _ = clientWithDriver("sqlmock").Rebind("SELECT * FROM t WHERE id = ?", []any{1})
+	assert.Equal(t, "SELECT * FROM t WHERE id = ?", query)
+}
+
+func TestNew_DriverValidation(t *testing.T) {
+	_, err := New(Config{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "driver is required")
+
+	_, err = New(Config{Driver: "oracle"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported driver 'oracle'")
+	assert.Contains(t, err.Error(), "clickhouse")
+
+	_, err = New(Config{Driver: "clickhouse", DSN: "clickhouse://localhost:9000", IsolateTests: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "isolateTests is not supported for clickhouse")
+}
+
+func TestNew_OptionalDriverNotImported(t *testing.T) {
+	_, err := New(Config{Driver: "clickhouse", DSN: "clickhouse://localhost:9000"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `driver 'clickhouse' is not registered: add `+"`"+`import _ "github.com/ClickHouse/clickhouse-go/v2"`)
+}
+
+func TestNew_SQLiteInMemory(t *testing.T) {
+	c, err := New(Config{Driver: "sqlite", DSN: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	assert.Equal(t, DriverSQLite, c.Driver())
+	assert.Equal(t, 1, c.DB.Stats().MaxOpenConnections)
+
+	_, err = c.DB.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+	require.NoError(t, err)
+	query, args := c.Rebind("INSERT INTO users (id, name) VALUES ($1, $2)", []any{1, "john"})
+	_, err = c.DB.Exec(query, args...)
+	require.NoError(t, err)
+
+	var name string
+	require.NoError(t, c.DB.Get(&name, "SELECT name FROM users WHERE id = ?", 1))
+	assert.Equal(t, "john", name)
+}
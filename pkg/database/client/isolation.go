@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/testscope"
+)
+
+// Executor is the subset of *sqlx.DB and *sqlx.Tx used by the DSL.
+type Executor interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+// TestTx is the transaction shared by the DSL calls of one test when isolateTests is on.
+// A transaction is bound to a single connection, so ExecutorFor hands it to one call at a time.
+type TestTx struct {
+	*sqlx.Tx
+	mu         sync.Mutex
+	savepoints atomic.Uint64
+}
+
+// NextSavepoint returns a savepoint name that is unique within the transaction.
+func (t *TestTx) NextSavepoint() string {
+	return fmt.Sprintf("gtf_exec_%d", t.savepoints.Add(1))
+}
+
+// SetIsolateTests enables or disables per-test transactions (see Config.IsolateTests).
+func (c *Client) SetIsolateTests(enabled bool) {
+	c.isolateTests = enabled
+}
+
+// IsolateTests reports whether per-test transactions are enabled.
+func (c *Client) IsolateTests() bool {
+	return c.isolateTests
+}
+
+// ExecutorFor returns the connection DSL calls of the given step must use, and a release
+// function the caller must call once it no longer uses the connection.
+//
+// With isolateTests enabled and a step running inside extension.BaseSuite, the first call
+// of a test opens a transaction that every later call of the same test reuses; BaseSuite
+// rolls it back in AfterEach. The transaction is a *TestTx held exclusively until release,
+// so concurrent AsyncSteps of a test take turns on it. It uses READ COMMITTED so polling
+// in AsyncStep still sees rows committed by the service under test.
+// Otherwise the pool (c.DB) is returned and release is a no-op.
+func (c *Client) ExecutorFor(stepCtx provider.StepCtx) (Executor, func(), error) {
+	noop := func() {}
+	if !c.isolateTests {
+		return c.DB, noop, nil
+	}
+
+	scope := testscope.FromStepCtx(stepCtx)
+	if scope == nil {
+		return c.DB, noop, nil
+	}
+
+	v, err := scope.GetOrCreate(c, func() (any, func() error, error) {
+		tx, err := c.DB.BeginTxx(context.Background(), &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to begin test transaction: %w", err)
+		}
+		testTx := &TestTx{Tx: tx}
+		rollback := func() error {
+			testTx.mu.Lock()
+			defer testTx.mu.Unlock()
+			if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+				return fmt.Errorf("failed to rollback test transaction: %w", err)
+			}
+			return nil
+		}
+		return testTx, rollback, nil
+	})
+	if err != nil {
+		return nil, noop, err
+	}
+
+	testTx := v.(*TestTx)
+	testTx.mu.Lock()
+	return testTx, testTx.mu.Unlock, nil
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/testscope"
+)
+
+type scopedStepCtx struct {
+	provider.StepCtx
+	scope *testscope.Scope
+}
+
+func (s *scopedStepCtx) TestScope() *testscope.Scope { return s.scope }
+
+func newMockClient(t *testing.T) (*Client, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return &Client{DB: sqlx.NewDb(db, "sqlmock")}, mock
+}
+
+func TestExecutorFor_IsolationDisabled(t *testing.T) {
+	c, _ := newMockClient(t)
+	stepCtx := &scopedStepCtx{scope: testscope.New()}
+
+	db, release, err := c.ExecutorFor(stepCtx)
+
+	require.NoError(t, err)
+	assert.Same(t, c.DB, db)
+	release()
+}
+
+func TestExecutorFor_NoScope(t *testing.T) {
+	c, _ := newMockClient(t)
+	c.SetIsolateTests(true)
+
+	db, release, err := c.ExecutorFor(nil)
+
+	require.NoError(t, err)
+	assert.Same(t, c.DB, db)
+	release()
+}
+
+func TestExecutorFor_SharesTransactionAndRollsBackOnClose(t *testing.T) {
+	c, mock := newMockClient(t)
+	c.SetIsolateTests(true)
+	scope := testscope.New()
+	stepCtx := &scopedStepCtx{scope: scope}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO players").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+
+	first, release, err := c.ExecutorFor(stepCtx)
+	require.NoError(t, err)
+	tx, ok := first.(*TestTx)
+	require.True(t, ok)
+	_, err = tx.Exec("INSERT INTO players (id) VALUES (1)")
+	require.NoError(t, err)
+	release()
+
+	second, release, err := c.ExecutorFor(&scopedStepCtx{scope: scope})
+	require.NoError(t, err)
+	assert.Same(t, tx, second)
+	release()
+
+	require.NoError(t, scope.Close())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecutorFor_SeparateTransactionPerTest(t *testing.T) {
+	c, mock := newMockClient(t)
+	c.SetIsolateTests(true)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	for i := 0; i < 2; i++ {
+		scope := testscope.New()
+		_, release, err := c.ExecutorFor(&scopedStepCtx{scope: scope})
+		require.NoError(t, err)
+		release()
+		require.NoError(t, scope.Close())
+	}
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecutorFor_SerializesConcurrentSteps(t *testing.T) {
+	c, mock := newMockClient(t)
+	c.SetIsolateTests(true)
+	scope := testscope.New()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	_, release, err := c.ExecutorFor(&scopedStepCtx{scope: scope})
+	require.NoError(t, err)
+
+	acquired := make(chan struct{})
+	go func() {
+		_, releaseSecond, err := c.ExecutorFor(&scopedStepCtx{scope: scope})
+		assert.NoError(t, err)
+		releaseSecond()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("the transaction was handed out while in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	<-acquired
+	require.NoError(t, scope.Close())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
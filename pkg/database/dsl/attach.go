@@ -29,3 +29,68 @@ func attachSQLReport(
 
 	sqlReporter.AttachSQLReport(stepCtx, dbClient, report)
 }
+
+func attachSQLExecReport(
+	stepCtx provider.StepCtx,
+	dbClient *client.Client,
+	query string,
+	args []any,
+	result *ExecResult,
+	transactional bool,
+) {
+	report := allure.SQLExecReportDTO{
+		Request: allure.ToSQLRequestDTO(query, args),
+		Result: allure.SQLExecResultDTO{
+			RowsAffected:  result.RowsAffected,
+			LastInsertID:  result.LastInsertID,
+			Rows:          result.Rows,
+			Duration:      result.Duration,
+			Transactional: transactional,
+			Committed:     result.Committed,
+			Error:         result.Error,
+		},
+	}
+
+	sqlReporter.AttachSQLExecReport(stepCtx, dbClient, report)
+}
+
+func attachSQLDiffReport[T any](
+	stepCtx provider.StepCtx,
+	dbClient *client.Client,
+	table string,
+	query string,
+	args []any,
+	result *DiffResult[T],
+	pollingSummary polling.PollingSummary,
+) {
+	dto := allure.SQLDiffResultDTO{
+		Table:    table,
+		Failures: result.Failures,
+		Error:    result.Error,
+	}
+	for _, row := range result.Inserted {
+		dto.Inserted = append(dto.Inserted, row)
+	}
+	for _, row := range result.Deleted {
+		dto.Deleted = append(dto.Deleted, row)
+	}
+	for _, update := range result.Updated {
+		rowDTO := allure.SQLRowUpdateDTO{Key: update.Key}
+		for _, change := range update.Changes {
+			rowDTO.Changes = append(rowDTO.Changes, allure.SQLColumnChangeDTO{
+				Column: change.Column,
+				Before: change.Before,
+				After:  change.After,
+			})
+		}
+		dto.Updated = append(dto.Updated, rowDTO)
+	}
+
+	report := allure.SQLDiffReportDTO{
+		Request: allure.ToSQLRequestDTO(query, args),
+		Result:  dto,
+		Polling: allure.ToPollingSummaryDTO(pollingSummary),
+	}
+
+	sqlReporter.AttachSQLDiffReport(stepCtx, dbClient, report)
+}
@@ -70,10 +70,10 @@ func NewQuery[T any](stepCtx provider.StepCtx, dbClient *client.Client) *Query[T
 }
 
 // SQL sets the SQL query and its arguments.
-// Use ? for MySQL placeholders or $1, $2 for PostgreSQL.
+// Placeholders are converted to the client's dialect: ? and $1, $2 both work
+// with MySQL, PostgreSQL, SQLite and ClickHouse.
 func (q *Query[T]) SQL(query string, args ...any) *Query[T] {
-	q.sql = query
-	q.args = args
+	q.sql, q.args = rebind(q.client, query, args)
 	return q
 }
 
@@ -243,3 +243,11 @@ func cleanTableName(tableName string) string {
 
 	return tableName
 }
+
+// rebind converts placeholders to the client's dialect. A nil client is reported by validate on Send.
+func rebind(dbClient *client.Client, query string, args []any) (string, []any) {
+	if dbClient == nil {
+		return query, args
+	}
+	return dbClient.Rebind(query, args)
+}
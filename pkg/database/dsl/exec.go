@@ -0,0 +1,314 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/database/client"
+)
+
+// Exec represents a data-modifying statement (INSERT/UPDATE/DELETE) with fluent interface.
+// Use it for test data setup and teardown so fixtures are reported in Allure like any other step.
+//
+// Statements are never retried, even in AsyncStep: they are not idempotent.
+//
+// Example:
+//
+//	dsl.NewExec(sCtx, dbClient).
+//	    SQL("UPDATE players SET status = ? WHERE id = ?", "blocked", playerID).
+//	    ExpectRowsAffected(1).
+//	    Send()
+type Exec struct {
+	stepCtx       provider.StepCtx
+	client        *client.Client
+	ctx           context.Context
+	sql           string
+	args          []any
+	transactional bool
+	expectations  []*expect.Expectation[*ExecResult]
+	sent          bool
+	result        *ExecResult
+}
+
+// ExecResult represents the outcome of a data-modifying statement.
+//
+// Fields:
+//   - RowsAffected: Number of rows changed (number of returned rows for RETURNING statements)
+//   - LastInsertID: Auto-increment ID reported by the driver (MySQL); 0 when not supported
+//   - Rows: Rows returned by a RETURNING clause, keyed by column name
+//   - Duration: Statement execution time
+//   - Committed: Whether the transaction (or savepoint in an isolated test) was committed (only with InTransaction)
+//   - Error: Execution or transaction error, if any
+type ExecResult struct {
+	RowsAffected int64
+	LastInsertID int64
+	Rows         []map[string]any
+	Duration     time.Duration
+	Committed    bool
+	Error        error
+}
+
+// NewExec creates a new builder for INSERT/UPDATE/DELETE statements.
+//
+// Parameters:
+//   - sCtx: Allure step context for test reporting
+//   - dbClient: Database client with connection pool
+func NewExec(stepCtx provider.StepCtx, dbClient *client.Client) *Exec {
+	return &Exec{
+		stepCtx: stepCtx,
+		client:  dbClient,
+		ctx:     context.Background(),
+	}
+}
+
+// SQL sets the statement and its arguments.
+// Placeholders are converted to the client's dialect, as in Query.SQL.
+// Statements with a RETURNING clause return the produced rows in ExecResult.Rows.
+func (e *Exec) SQL(query string, args ...any) *Exec {
+	e.sql, e.args = rebind(e.client, query, args)
+	return e
+}
+
+// InTransaction runs the statement in its own transaction that is committed only
+// when the statement succeeds and all expectations pass; otherwise it is rolled back,
+// so a fixture that touched the wrong number of rows leaves no trace.
+func (e *Exec) InTransaction() *Exec {
+	e.transactional = true
+	return e
+}
+
+// ExpectRowsAffected checks that the statement changed exactly n rows.
+func (e *Exec) ExpectRowsAffected(n int64) *Exec {
+	expect.AddExpectation(e.stepCtx, e.sent, &e.expectations, makeRowsAffectedExpectation(n), "DB")
+	return e
+}
+
+func (e *Exec) validate() {
+	v := validation.New(e.stepCtx, "DB")
+	v.RequireNotNil(e.client, "Database client")
+	v.RequireNotEmptyWithHint(e.sql, "SQL statement", "Use .SQL(\"INSERT...\", args...).")
+}
+
+// Send executes the statement once and validates all expectations.
+// Returns the ExecResult with affected rows, last insert ID and RETURNING rows.
+func (e *Exec) Send() *ExecResult {
+	e.validate()
+
+	e.stepCtx.WithNewStep(e.stepName(), func(stepCtx provider.StepCtx) {
+		e.result = e.run(e.ctx)
+		e.sent = true
+
+		attachSQLExecReport(stepCtx, e.client, e.sql, e.args, e.result, e.transactional)
+		expect.AssertExpectations(stepCtx, e.expectations, e.result.Error, e.result, e.assertNoExpectations)
+	})
+
+	return e.result
+}
+
+func (e *Exec) assertNoExpectations(stepCtx provider.StepCtx, mode polling.AssertionMode, err error) {
+	if err != nil {
+		polling.NoError(stepCtx, mode, err, "DB statement failed: %v", err)
+	}
+}
+
+func (e *Exec) stepName() string {
+	return extractExecStepName(e.sql)
+}
+
+func (e *Exec) run(ctx context.Context) *ExecResult {
+	db, release, err := e.client.ExecutorFor(e.stepCtx)
+	if err != nil {
+		return &ExecResult{Error: err}
+	}
+	defer release()
+
+	if !e.transactional {
+		return execStatement(ctx, db, e.sql, e.args)
+	}
+
+	// Inside an isolated test the statement already runs in the test transaction:
+	// a savepoint gives the same commit-or-rollback semantics without ending it.
+	if tx, ok := db.(*client.TestTx); ok {
+		return e.runInSavepoint(ctx, tx)
+	}
+
+	tx, err := e.client.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return &ExecResult{Error: fmt.Errorf("failed to begin transaction: %w", err)}
+	}
+
+	result := execStatement(ctx, tx, e.sql, e.args)
+	if result.Error != nil || !e.expectationsPass(result) {
+		if rbErr := tx.Rollback(); rbErr != nil && result.Error == nil {
+			result.Error = fmt.Errorf("failed to rollback transaction: %w", rbErr)
+		}
+		return result
+	}
+
+	if err := tx.Commit(); err != nil {
+		result.Error = fmt.Errorf("failed to commit transaction: %w", err)
+		return result
+	}
+	result.Committed = true
+	return result
+}
+
+func (e *Exec) runInSavepoint(ctx context.Context, tx *client.TestTx) *ExecResult {
+	savepoint := tx.NextSavepoint()
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+		return &ExecResult{Error: fmt.Errorf("failed to create savepoint: %w", err)}
+	}
+
+	result := execStatement(ctx, tx, e.sql, e.args)
+	if result.Error != nil || !e.expectationsPass(result) {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); rbErr != nil && result.Error == nil {
+			result.Error = fmt.Errorf("failed to rollback to savepoint: %w", rbErr)
+		}
+		return result
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+		result.Error = fmt.Errorf("failed to release savepoint: %w", err)
+		return result
+	}
+	result.Committed = true
+	return result
+}
+
+func (e *Exec) expectationsPass(result *ExecResult) bool {
+	for _, exp := range e.expectations {
+		if !exp.Check(result.Error, result).Ok {
+			return false
+		}
+	}
+	return true
+}
+
+func execStatement(ctx context.Context, db sqlx.ExtContext, query string, args []any) *ExecResult {
+	start := time.Now()
+	result := &ExecResult{}
+
+	if hasReturningClause(query) {
+		rows, err := db.QueryxContext(ctx, query, args...)
+		if err != nil {
+			result.Error = err
+			result.Duration = time.Since(start)
+			return result
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			row := make(map[string]any)
+			if err := rows.MapScan(row); err != nil {
+				result.Error = err
+				break
+			}
+			result.Rows = append(result.Rows, normalizeRow(row))
+		}
+		if result.Error == nil {
+			result.Error = rows.Err()
+		}
+		result.RowsAffected = int64(len(result.Rows))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	res, err := db.ExecContext(ctx, query, args...)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	if affected, err := res.RowsAffected(); err == nil {
+		result.RowsAffected = affected
+	}
+	if id, err := res.LastInsertId(); err == nil {
+		result.LastInsertID = id
+	}
+	return result
+}
+
+// normalizeRow converts driver []byte values (MySQL text columns) to strings.
+func normalizeRow(row map[string]any) map[string]any {
+	for k, v := range row {
+		if b, ok := v.([]byte); ok {
+			row[k] = string(b)
+		}
+	}
+	return row
+}
+
+var returningPattern = regexp.MustCompile(`(?i)\bRETURNING\b`)
+
+func hasReturningClause(query string) bool {
+	return returningPattern.MatchString(query)
+}
+
+func extractExecStepName(query string) string {
+	query = strings.TrimSpace(query)
+	upper := strings.ToUpper(query)
+
+	tokens := strings.Fields(upper)
+	if len(tokens) == 0 {
+		return "EXEC query"
+	}
+	verb := tokens[0]
+
+	var tableName string
+	switch verb {
+	case "INSERT", "REPLACE":
+		tableName = extractTableFromKeyword(query, upper, "INTO")
+	case "UPDATE":
+		tableName = extractTableFromKeyword(query, upper, "UPDATE")
+	case "DELETE":
+		tableName = extractTableFromKeyword(query, upper, "FROM")
+	case "TRUNCATE":
+		tableName = extractTableFromKeyword(query, upper, "TABLE")
+		if tableName == "" {
+			tableName = extractTableFromKeyword(query, upper, "TRUNCATE")
+		}
+	default:
+		return fmt.Sprintf("EXEC %s", verb)
+	}
+
+	if idx := strings.Index(tableName, "("); idx > 0 {
+		tableName = tableName[:idx]
+	}
+	if tableName == "" {
+		tableName = "query"
+	}
+	return fmt.Sprintf("%s %s", verb, tableName)
+}
+
+func makeRowsAffectedExpectation(expected int64) *expect.Expectation[*ExecResult] {
+	name := fmt.Sprintf("Expect: Rows affected = %d", expected)
+	return expect.New(
+		name,
+		func(err error, result *ExecResult) polling.CheckResult {
+			if err != nil {
+				return polling.CheckResult{Ok: false, Reason: fmt.Sprintf("Statement failed: %v", err)}
+			}
+			if result == nil {
+				return polling.CheckResult{Ok: false, Reason: "Statement result is nil"}
+			}
+			if result.RowsAffected != expected {
+				return polling.CheckResult{
+					Ok:     false,
+					Reason: fmt.Sprintf("Expected %d row(s) affected, got %d", expected, result.RowsAffected),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*ExecResult](name),
+	)
+}
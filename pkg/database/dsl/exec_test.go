@@ -0,0 +1,189 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/database/client"
+)
+
+func newMockClient(t *testing.T) (*client.Client, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return &client.Client{DB: sqlx.NewDb(db, "sqlmock")}, mock
+}
+
+func TestExtractExecStepName(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"INSERT INTO users (id, name) VALUES (?, ?)", "INSERT users"},
+		{"insert into public.users(id) values ($1)", "INSERT users"},
+		{"UPDATE `players` SET status = ? WHERE id = ?", "UPDATE players"},
+		{"DELETE FROM sessions WHERE user_id = $1", "DELETE sessions"},
+		{"TRUNCATE TABLE audit_log", "TRUNCATE audit_log"},
+		{"TRUNCATE audit_log", "TRUNCATE audit_log"},
+		{"CALL refresh_stats()", "EXEC CALL"},
+		{"", "EXEC query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractExecStepName(tt.query))
+		})
+	}
+}
+
+func TestHasReturningClause(t *testing.T) {
+	assert.True(t, hasReturningClause("INSERT INTO users (name) VALUES ($1) RETURNING id"))
+	assert.True(t, hasReturningClause("delete from users where id = $1 returning *"))
+	assert.False(t, hasReturningClause("INSERT INTO users (returning_customer) VALUES ($1)"))
+	assert.False(t, hasReturningClause("UPDATE users SET name = ?"))
+}
+
+func TestExecStatement_RowsAffectedAndLastInsertID(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectExec("INSERT INTO users").WithArgs("john").WillReturnResult(sqlmock.NewResult(42, 1))
+
+	result := execStatement(context.Background(), c.DB, "INSERT INTO users (name) VALUES (?)", []any{"john"})
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	assert.Equal(t, int64(42), result.LastInsertID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecStatement_Returning(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectQuery("INSERT INTO users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(7), []byte("john")))
+
+	result := execStatement(context.Background(), c.DB, "INSERT INTO users (name) VALUES ($1) RETURNING id, name", []any{"john"})
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	require.Len(t, result.Rows, 1)
+	assert.Equal(t, int64(7), result.Rows[0]["id"])
+	assert.Equal(t, "john", result.Rows[0]["name"])
+}
+
+func TestExecStatement_Error(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectExec("DELETE FROM users").WillReturnError(fmt.Errorf("permission denied"))
+
+	result := execStatement(context.Background(), c.DB, "DELETE FROM users", nil)
+
+	assert.EqualError(t, result.Error, "permission denied")
+}
+
+func TestExecRun_TransactionCommitted(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE players").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	e := &Exec{client: c, sql: "UPDATE players SET status = 'blocked'", transactional: true}
+	e.expectations = append(e.expectations, makeRowsAffectedExpectation(1))
+
+	result := e.run(context.Background())
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.Committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecRun_TransactionRolledBackOnExpectationFailure(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE players").WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectRollback()
+
+	e := &Exec{client: c, sql: "UPDATE players SET status = 'blocked'", transactional: true}
+	e.expectations = append(e.expectations, makeRowsAffectedExpectation(1))
+
+	result := e.run(context.Background())
+
+	require.NoError(t, result.Error)
+	assert.False(t, result.Committed)
+	assert.Equal(t, int64(3), result.RowsAffected)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecRun_TransactionRolledBackOnError(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO users").WillReturnError(fmt.Errorf("duplicate key"))
+	mock.ExpectRollback()
+
+	e := &Exec{client: c, sql: "INSERT INTO users (id) VALUES (1)", transactional: true}
+
+	result := e.run(context.Background())
+
+	assert.EqualError(t, result.Error, "duplicate key")
+	assert.False(t, result.Committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMakeRowsAffectedExpectation(t *testing.T) {
+	exp := makeRowsAffectedExpectation(2)
+
+	assert.True(t, exp.Check(nil, &ExecResult{RowsAffected: 2}).Ok)
+
+	res := exp.Check(nil, &ExecResult{RowsAffected: 0})
+	assert.False(t, res.Ok)
+	assert.Contains(t, res.Reason, "Expected 2 row(s) affected, got 0")
+
+	assert.False(t, exp.Check(fmt.Errorf("boom"), nil).Ok)
+	assert.False(t, exp.Check(nil, nil).Ok)
+}
+
+func TestExecRunInSavepoint_Released(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT gtf_exec_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM sessions").WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("RELEASE SAVEPOINT gtf_exec_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SAVEPOINT gtf_exec_2$").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM sessions").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT gtf_exec_2$").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := c.DB.Beginx()
+	require.NoError(t, err)
+	testTx := &client.TestTx{Tx: tx}
+
+	e := &Exec{client: c, sql: "DELETE FROM sessions", transactional: true}
+	for range 2 {
+		result := e.runInSavepoint(context.Background(), testTx)
+		require.NoError(t, result.Error)
+		assert.True(t, result.Committed)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecRunInSavepoint_RolledBack(t *testing.T) {
+	c, mock := newMockClient(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT gtf_exec_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM sessions").WillReturnResult(sqlmock.NewResult(0, 5))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT gtf_exec_1$").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	tx, err := c.DB.Beginx()
+	require.NoError(t, err)
+
+	e := &Exec{client: c, sql: "DELETE FROM sessions", transactional: true}
+	e.expectations = append(e.expectations, makeRowsAffectedExpectation(1))
+	result := e.runInSavepoint(context.Background(), &client.TestTx{Tx: tx})
+
+	require.NoError(t, result.Error)
+	assert.False(t, result.Committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,11 +2,13 @@ package dsl
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx/reflectx"
 
@@ -59,24 +61,76 @@ func getFieldValueByColumnName(target any, columnName string) (any, error) {
 		return nil, fmt.Errorf("target is not a struct, got %s", v.Kind())
 	}
 
-	if !v.CanAddr() {
-		ptr := reflect.New(v.Type())
-		ptr.Elem().Set(v)
-		v = ptr.Elem()
+	field := structMapper.TypeMap(v.Type()).GetByPath(columnName)
+	if field == nil {
+		return nil, fmt.Errorf("column '%s' not found in struct %T (check 'db' tags)", columnName, target)
 	}
 
-	fieldMap := structMapper.FieldMap(v)
-
-	fieldValue, found := fieldMap[columnName]
-	if !found {
-		return nil, fmt.Errorf("column '%s' not found in struct %T (check 'db' tags)", columnName, target)
+	// Read without allocating: a nil pointer (NULL in a ClickHouse Nullable column) must stay nil.
+	fieldValue := v
+	for _, i := range field.Index {
+		if fieldValue.Kind() == reflect.Ptr {
+			if fieldValue.IsNil() {
+				return nil, nil
+			}
+			fieldValue = fieldValue.Elem()
+		}
+		fieldValue = fieldValue.Field(i)
 	}
 
 	if !fieldValue.CanInterface() {
 		return nil, fmt.Errorf("field for column '%s' is unexported", columnName)
 	}
 
-	return fieldValue.Interface(), nil
+	return mapColumnValue(fieldValue), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// mapColumnValue converts driver-specific field types to the plain values expectations compare:
+// ClickHouse Nullable columns scan into pointers (*int64, *string), Decimal into decimal types
+// (compared as float64), UUID into driver.Valuer types, Enum columns into named string/int types.
+// database/sql Null* types and time.Time are returned as is.
+func mapColumnValue(v reflect.Value) any {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().PkgPath() == "database/sql" {
+			return v.Interface()
+		}
+		return mapColumnValue(v.Elem())
+	}
+
+	typ := v.Type()
+	if typ.PkgPath() == "" || typ.PkgPath() == "database/sql" || typ == timeType {
+		return v.Interface()
+	}
+
+	if dec, ok := v.Interface().(interface{ Float64() (float64, bool) }); ok {
+		f, _ := dec.Float64()
+		return f
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		if value, err := valuer.Value(); err == nil {
+			return value
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return v.Interface()
+	}
 }
 
 func getFieldValue[T any](result T, columnName string) (any, error) {
@@ -2,9 +2,11 @@ package dsl
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -813,3 +815,65 @@ func TestCompareStructsPartial_NonStruct(t *testing.T) {
 	assert.False(t, ok)
 	assert.Contains(t, msg, "struct types")
 }
+
+type playerStatus string
+
+type fakeDecimal struct{ value float64 }
+
+func (d fakeDecimal) Float64() (float64, bool) { return d.value, true }
+
+type fakeUUID [2]byte
+
+func (u fakeUUID) Value() (driver.Value, error) { return "0102", nil }
+
+type ClickHouseModel struct {
+	ID      uint64       `db:"id"`
+	Status  playerStatus `db:"status"`
+	Email   *string      `db:"email"`
+	Score   *int64       `db:"score"`
+	Amount  fakeDecimal  `db:"amount"`
+	TraceID fakeUUID     `db:"trace_id"`
+	Created time.Time    `db:"created_at"`
+}
+
+func TestGetFieldValueByColumnName_DriverTypes(t *testing.T) {
+	email := "a@b.c"
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	model := ClickHouseModel{
+		ID:      7,
+		Status:  "active",
+		Email:   &email,
+		Amount:  fakeDecimal{value: 12.5},
+		TraceID: fakeUUID{1, 2},
+		Created: created,
+	}
+
+	tests := []struct {
+		column   string
+		expected any
+	}{
+		{"id", uint64(7)},
+		{"status", "active"},
+		{"email", "a@b.c"},
+		{"score", nil},
+		{"amount", 12.5},
+		{"trace_id", "0102"},
+		{"created_at", created},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			value, err := getFieldValueByColumnName(model, tt.column)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestMakeColumnEqualsExpectation_ClickHouseTypes(t *testing.T) {
+	model := ClickHouseModel{Status: "active", Amount: fakeDecimal{value: 12.5}}
+
+	assert.True(t, makeColumnEqualsExpectation[ClickHouseModel]("status", "active").Check(nil, model).Ok)
+	assert.True(t, makeColumnEqualsExpectation[ClickHouseModel]("amount", 12.5).Check(nil, model).Ok)
+	assert.True(t, makeColumnIsNullExpectation[ClickHouseModel]("score").Check(nil, model).Ok)
+}
@@ -44,9 +44,14 @@ func (q *Query[T]) executeAll(stepCtx provider.StepCtx) ([]T, time.Duration, err
 
 func (q *Query[T]) timedQuery(durationPtr *time.Duration) func(context.Context) (T, error) {
 	return func(ctx context.Context) (T, error) {
-		start := time.Now()
 		var result T
-		err := q.client.DB.GetContext(ctx, &result, q.sql, q.args...)
+		db, release, err := q.client.ExecutorFor(q.stepCtx)
+		if err != nil {
+			return result, err
+		}
+		defer release()
+		start := time.Now()
+		err = db.GetContext(ctx, &result, q.sql, q.args...)
 		*durationPtr = time.Since(start)
 		return result, err
 	}
@@ -54,9 +59,14 @@ func (q *Query[T]) timedQuery(durationPtr *time.Duration) func(context.Context)
 
 func (q *Query[T]) timedQueryAll(durationPtr *time.Duration) func(context.Context) ([]T, error) {
 	return func(ctx context.Context) ([]T, error) {
-		start := time.Now()
 		var results []T
-		err := q.client.DB.SelectContext(ctx, &results, q.sql, q.args...)
+		db, release, err := q.client.ExecutorFor(q.stepCtx)
+		if err != nil {
+			return results, err
+		}
+		defer release()
+		start := time.Now()
+		err = db.SelectContext(ctx, &results, q.sql, q.args...)
 		*durationPtr = time.Since(start)
 		return results, err
 	}
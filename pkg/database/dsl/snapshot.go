@@ -0,0 +1,594 @@
+package dsl
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx/reflectx"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/allure"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/database/client"
+)
+
+// TableSnapshot holds the rows of a table captured before an action.
+// Call ExpectDiff after the action to assert exactly which rows were inserted, updated and deleted.
+//
+// Example:
+//
+//	snap := dsl.Snapshot[PlayerRow](sCtx, dbClient, "players", "region = ?", "EU")
+//
+//	// ... action under test ...
+//
+//	snap.ExpectDiff().
+//	    Inserted(PlayerRow{Username: "new_player"}).
+//	    Updated(playerID, map[string]any{"status": "blocked"}).
+//	    Deleted(removedID).
+//	    IgnoreColumns("updated_at").
+//	    Send()
+type TableSnapshot[T any] struct {
+	stepCtx provider.StepCtx
+	client  *client.Client
+	ctx     context.Context
+	table   string
+	where   string
+	args    []any
+	rows    []T
+}
+
+// Snapshot captures the rows of table matching where (empty for the whole table) as T structs.
+// The query runs immediately in its own step, so call it before the action under test.
+//
+// Parameters:
+//   - sCtx: Allure step context for test reporting
+//   - dbClient: Database client with connection pool
+//   - table: Table name, optionally schema-qualified
+//   - where: SQL condition without the WHERE keyword; placeholders are converted as in Query.SQL
+//   - args: Condition arguments
+func Snapshot[T any](stepCtx provider.StepCtx, dbClient *client.Client, table string, where string, args ...any) *TableSnapshot[T] {
+	s := &TableSnapshot[T]{
+		stepCtx: stepCtx,
+		client:  dbClient,
+		ctx:     context.Background(),
+		table:   table,
+	}
+	s.where, s.args = rebind(dbClient, where, args)
+
+	v := validation.New(stepCtx, "DB")
+	v.RequireNotNil(dbClient, "Database client")
+	v.RequireNotEmptyWithHint(table, "Table name", "Use dsl.Snapshot[T](sCtx, dbClient, \"table\", where, args...).")
+
+	stepCtx.WithNewStep(fmt.Sprintf("SNAPSHOT %s", table), func(stepCtx provider.StepCtx) {
+		start := time.Now()
+		rows, err := s.capture(s.ctx)
+		s.rows = rows
+
+		attachSQLReport(stepCtx, s.client, allure.SQLAttachParams{
+			Query:    s.selectSQL(),
+			Args:     s.args,
+			Result:   rows,
+			RowCount: len(rows),
+			Duration: time.Since(start),
+			Error:    err,
+		}, polling.PollingSummary{})
+
+		if err != nil {
+			mode := polling.GetAssertionModeFromStepMode(polling.GetStepMode(stepCtx))
+			polling.NoError(stepCtx, mode, err, "DB snapshot of '%s' failed: %v", table, err)
+		}
+	})
+
+	return s
+}
+
+// Rows returns the captured rows.
+func (s *TableSnapshot[T]) Rows() []T {
+	return s.rows
+}
+
+func (s *TableSnapshot[T]) selectSQL() string {
+	query := "SELECT " + selectColumns[T]() + " FROM " + s.table
+	if strings.TrimSpace(s.where) != "" {
+		query += " WHERE " + s.where
+	}
+	return query
+}
+
+func (s *TableSnapshot[T]) capture(ctx context.Context) ([]T, error) {
+	var rows []T
+	db, release, err := s.client.ExecutorFor(s.stepCtx)
+	if err != nil {
+		return rows, err
+	}
+	defer release()
+	err = db.SelectContext(ctx, &rows, s.selectSQL(), s.args...)
+	return rows, err
+}
+
+// ExpectDiff starts the assertion on the changes made since the snapshot.
+// Every change must be declared: rows that were inserted, updated or deleted without a
+// matching Inserted/Updated/Deleted call fail the step. A diff without declarations asserts no changes.
+func (s *TableSnapshot[T]) ExpectDiff() *Diff[T] {
+	return &Diff[T]{
+		snapshot:   s,
+		keyColumns: []string{"id"},
+		ignored:    make(map[string]bool),
+	}
+}
+
+// Diff asserts the changes of a table between a snapshot and now.
+type Diff[T any] struct {
+	snapshot   *TableSnapshot[T]
+	keyColumns []string
+	ignored    map[string]bool
+
+	inserted []T
+	updated  []expectedUpdate
+	deleted  []any
+
+	result *DiffResult[T]
+}
+
+type expectedUpdate struct {
+	key     any
+	changes map[string]any
+}
+
+// DiffResult represents the changes of a table since the snapshot.
+//
+// Fields:
+//   - Inserted: Rows present now but not in the snapshot
+//   - Updated: Rows present in both with changed columns
+//   - Deleted: Rows present in the snapshot but not now
+//   - Failures: Declared changes that did not happen and changes that were not declared
+//   - Error: Query or key error, if any
+type DiffResult[T any] struct {
+	Inserted []T
+	Updated  []RowUpdate[T]
+	Deleted  []T
+	Failures []string
+	Error    error
+}
+
+// RowUpdate describes one updated row with its column-level changes.
+type RowUpdate[T any] struct {
+	Key     string
+	Before  T
+	After   T
+	Changes []ColumnChange
+}
+
+// ColumnChange is a single column value before and after the action.
+type ColumnChange struct {
+	Column string
+	Before any
+	After  any
+}
+
+// Key sets the primary key column(s) used to match rows between the snapshot and now.
+// Defaults to "id". For composite keys pass []any{...} values to Updated and Deleted.
+func (d *Diff[T]) Key(columns ...string) *Diff[T] {
+	d.keyColumns = columns
+	return d
+}
+
+// IgnoreColumns excludes columns from change detection, e.g. "updated_at" or "version".
+// A row whose only changes are in ignored columns is not reported as updated.
+func (d *Diff[T]) IgnoreColumns(columns ...string) *Diff[T] {
+	for _, column := range columns {
+		d.ignored[column] = true
+	}
+	return d
+}
+
+// Inserted declares rows expected to be inserted. Each expected row is matched partially
+// (zero-value fields are ignored) against a distinct inserted row.
+func (d *Diff[T]) Inserted(rows ...T) *Diff[T] {
+	d.inserted = append(d.inserted, rows...)
+	return d
+}
+
+// Updated declares that the row with key changed exactly the given columns to the given values.
+func (d *Diff[T]) Updated(key any, changes map[string]any) *Diff[T] {
+	d.updated = append(d.updated, expectedUpdate{key: key, changes: changes})
+	return d
+}
+
+// Deleted declares rows expected to be deleted, by key.
+func (d *Diff[T]) Deleted(keys ...any) *Diff[T] {
+	d.deleted = append(d.deleted, keys...)
+	return d
+}
+
+func (d *Diff[T]) validate() {
+	v := validation.New(d.snapshot.stepCtx, "DB")
+	v.Require(len(d.keyColumns) > 0, "Diff key is empty. Use Key(\"id\") or omit it to use the default.")
+	for _, update := range d.updated {
+		v.Require(len(update.changes) > 0,
+			fmt.Sprintf("Updated(%v) has no column changes. Declare the changed columns and their new values.", update.key))
+	}
+}
+
+// Send re-reads the table and asserts the declared diff.
+// In async mode (AsyncStep), retries with backoff until the table reaches the expected state.
+// Returns the DiffResult with inserted, updated and deleted rows.
+func (d *Diff[T]) Send() *DiffResult[T] {
+	d.validate()
+
+	s := d.snapshot
+	s.stepCtx.WithNewStep(fmt.Sprintf("DIFF %s", s.table), func(stepCtx provider.StepCtx) {
+		result, err, summary := retry.ExecuteDSL(retry.DSLConfig[*DiffResult[T], *DiffResult[T]]{
+			Ctx:         s.ctx,
+			StepCtx:     stepCtx,
+			AsyncConfig: s.client.AsyncConfig,
+			Executor:    d.execute,
+			Checker:     d.buildChecker(),
+		})
+		if result == nil {
+			result = &DiffResult[T]{Error: err}
+		}
+		d.result = result
+
+		attachSQLDiffReport(stepCtx, s.client, s.table, s.selectSQL(), s.args, result, summary)
+
+		if err != nil {
+			mode := polling.GetStepMode(stepCtx)
+			assertionMode := polling.GetAssertionModeFromStepMode(mode)
+
+			msg := fmt.Sprintf("DB diff of '%s' does not match: %v", s.table, err)
+			if mode == polling.AsyncMode {
+				msg = polling.FinalFailureMessage(summary)
+			}
+			polling.NoError(stepCtx, assertionMode, err, msg)
+		}
+	})
+
+	return d.result
+}
+
+func (d *Diff[T]) execute(ctx context.Context) (*DiffResult[T], error) {
+	after, err := d.snapshot.capture(ctx)
+	if err != nil {
+		return &DiffResult[T]{Error: err}, err
+	}
+
+	result, err := computeDiff(d.snapshot.rows, after, d.keyColumns, d.ignored)
+	if err != nil {
+		return &DiffResult[T]{Error: err}, err
+	}
+
+	result.Failures = d.verify(result)
+	if len(result.Failures) > 0 {
+		return result, fmt.Errorf("%s", strings.Join(result.Failures, "; "))
+	}
+	return result, nil
+}
+
+func (d *Diff[T]) buildChecker() retry.Checker[*DiffResult[T]] {
+	return func(_ *DiffResult[T], err error) []polling.CheckResult {
+		if err != nil {
+			return []polling.CheckResult{{
+				Ok:        false,
+				Retryable: true,
+				Reason:    err.Error(),
+			}}
+		}
+		return []polling.CheckResult{{Ok: true}}
+	}
+}
+
+// verify compares the actual diff with the declared one and describes every mismatch.
+func (d *Diff[T]) verify(result *DiffResult[T]) []string {
+	var failures []string
+
+	unmatched := append([]T(nil), result.Inserted...)
+	for i, expected := range d.inserted {
+		idx := -1
+		for j, actual := range unmatched {
+			if ok, _ := compareStructsPartial(expected, actual); ok {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			failures = append(failures, fmt.Sprintf("expected inserted row #%d %s was not inserted", i+1, describeRow(expected)))
+			continue
+		}
+		unmatched = append(unmatched[:idx], unmatched[idx+1:]...)
+	}
+	for _, row := range unmatched {
+		failures = append(failures, fmt.Sprintf("unexpected inserted row %s", describeRow(row)))
+	}
+
+	updates := make(map[string]RowUpdate[T], len(result.Updated))
+	for _, update := range result.Updated {
+		updates[update.Key] = update
+	}
+	for _, expected := range d.updated {
+		key := formatKey(expected.key)
+		update, ok := updates[key]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("row %s was not updated", key))
+			continue
+		}
+		delete(updates, key)
+		failures = append(failures, verifyUpdate(update, expected.changes)...)
+	}
+	for _, update := range result.Updated {
+		if _, ok := updates[update.Key]; ok {
+			failures = append(failures, fmt.Sprintf("unexpected update of row %s: %s", update.Key, describeChanges(update.Changes)))
+		}
+	}
+
+	deleted := make(map[string]bool, len(result.Deleted))
+	for _, row := range result.Deleted {
+		key, _ := rowKey(row, d.keyColumns)
+		deleted[key] = true
+	}
+	for _, expected := range d.deleted {
+		key := formatKey(expected)
+		if !deleted[key] {
+			failures = append(failures, fmt.Sprintf("row %s was not deleted", key))
+			continue
+		}
+		delete(deleted, key)
+	}
+	for _, row := range result.Deleted {
+		if key, _ := rowKey(row, d.keyColumns); deleted[key] {
+			failures = append(failures, fmt.Sprintf("unexpected deleted row %s", key))
+		}
+	}
+
+	return failures
+}
+
+func verifyUpdate[T any](update RowUpdate[T], expected map[string]any) []string {
+	var failures []string
+
+	changed := make(map[string]ColumnChange, len(update.Changes))
+	for _, change := range update.Changes {
+		changed[change.Column] = change
+	}
+
+	for column, value := range expected {
+		change, ok := changed[column]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("row %s: column '%s' expected to change to %v, but was not changed", update.Key, column, value))
+			continue
+		}
+		if equal, _, reason := equalsLoose(value, change.After); !equal {
+			failures = append(failures, fmt.Sprintf("row %s: column '%s' %s", update.Key, column, reason))
+		}
+	}
+
+	for _, change := range update.Changes {
+		if _, ok := expected[change.Column]; !ok {
+			failures = append(failures, fmt.Sprintf("row %s: unexpected change of column '%s': %v → %v",
+				update.Key, change.Column, change.Before, change.After))
+		}
+	}
+
+	return failures
+}
+
+// computeDiff matches rows by key and compares them column by column.
+func computeDiff[T any](before, after []T, keyColumns []string, ignored map[string]bool) (*DiffResult[T], error) {
+	result := &DiffResult[T]{}
+
+	beforeByKey := make(map[string]T, len(before))
+	for _, row := range before {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := beforeByKey[key]; dup {
+			return nil, fmt.Errorf("duplicate key %s in snapshot: use Key(...) with the table's primary key", key)
+		}
+		beforeByKey[key] = row
+	}
+
+	seen := make(map[string]bool, len(after))
+	for _, row := range after {
+		key, err := rowKey(row, keyColumns)
+		if err != nil {
+			return nil, err
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate key %s in table: use Key(...) with the table's primary key", key)
+		}
+		seen[key] = true
+
+		old, existed := beforeByKey[key]
+		if !existed {
+			result.Inserted = append(result.Inserted, row)
+			continue
+		}
+		if changes := diffColumns(old, row, ignored); len(changes) > 0 {
+			result.Updated = append(result.Updated, RowUpdate[T]{Key: key, Before: old, After: row, Changes: changes})
+		}
+	}
+
+	for _, row := range before {
+		key, _ := rowKey(row, keyColumns)
+		if !seen[key] {
+			result.Deleted = append(result.Deleted, row)
+		}
+	}
+
+	return result, nil
+}
+
+func diffColumns[T any](before, after T, ignored map[string]bool) []ColumnChange {
+	beforeCols := rowColumns(before)
+	afterCols := rowColumns(after)
+
+	var changes []ColumnChange
+	for i, col := range beforeCols {
+		if ignored[col.name] || valuesEqual(col.value, afterCols[i].value) {
+			continue
+		}
+		changes = append(changes, ColumnChange{Column: col.name, Before: col.value, After: afterCols[i].value})
+	}
+	return changes
+}
+
+type rowColumn struct {
+	name  string
+	value any
+}
+
+// snapshotMapper names columns the way sqlx scans them: the db tag, or the lower-cased field name.
+var snapshotMapper = reflectx.NewMapperFunc("db", strings.ToLower)
+
+type columnField struct {
+	name  string
+	index []int
+}
+
+// columnFields lists the columns a struct type maps, in field order. Embedded structs
+// without a db tag are flattened (unexported ones too, as sqlx does); fields tagged "-"
+// and other unexported fields are skipped.
+func columnFields(typ reflect.Type) []columnField {
+	return appendColumnFields(nil, snapshotMapper.TypeMap(typ).Tree.Children)
+}
+
+func appendColumnFields(fields []columnField, children []*reflectx.FieldInfo) []columnField {
+	for _, fi := range children {
+		if fi == nil {
+			continue
+		}
+		if fi.Embedded && fi.Field.Tag.Get("db") == "" && fi.Field.Type.Kind() == reflect.Struct {
+			fields = appendColumnFields(fields, fi.Children)
+			continue
+		}
+		fields = append(fields, columnField{name: fi.Name, index: fi.Index})
+	}
+	return fields
+}
+
+// rowColumns flattens a struct row into its db columns in field order, named like
+// selectColumns names them. sql.Null* and other driver.Valuer fields are unwrapped.
+func rowColumns(row any) []rowColumn {
+	v := reflect.ValueOf(row)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var columns []rowColumn
+	for _, field := range columnFields(v.Type()) {
+		columns = append(columns, rowColumn{name: field.name, value: columnValue(v.FieldByIndex(field.index))})
+	}
+	return columns
+}
+
+// selectColumns lists the columns T maps, so that columns the struct does not declare
+// are not selected: sqlx fails on result columns without a destination field.
+// A non-struct T selects *.
+func selectColumns[T any]() string {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return "*"
+	}
+	var names []string
+	for _, field := range columnFields(typ) {
+		names = append(names, field.name)
+	}
+	if len(names) == 0 {
+		return "*"
+	}
+	return strings.Join(names, ", ")
+}
+
+func columnValue(v reflect.Value) any {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if valuer, ok := v.Interface().(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err == nil {
+			return value
+		}
+	}
+	if b, ok := v.Interface().([]byte); ok {
+		return string(b)
+	}
+	return v.Interface()
+}
+
+func valuesEqual(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Equal(tb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func rowKey(row any, keyColumns []string) (string, error) {
+	columns := rowColumns(row)
+	parts := make([]string, 0, len(keyColumns))
+	for _, name := range keyColumns {
+		found := false
+		for _, col := range columns {
+			if col.name == name {
+				parts = append(parts, fmt.Sprint(col.value))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("key column '%s' not found in struct %T (check 'db' tags or use Key(...))", name, row)
+		}
+	}
+	return strings.Join(parts, "/"), nil
+}
+
+// formatKey renders an expected key the same way rowKey does; composite keys are passed as []any.
+func formatKey(key any) string {
+	if parts, ok := key.([]any); ok {
+		s := make([]string, len(parts))
+		for i, p := range parts {
+			s[i] = fmt.Sprint(p)
+		}
+		return strings.Join(s, "/")
+	}
+	return fmt.Sprint(key)
+}
+
+func describeRow(row any) string {
+	var parts []string
+	for _, col := range rowColumns(row) {
+		if col.value == nil || reflect.ValueOf(col.value).IsZero() {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", col.name, col.value))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func describeChanges(changes []ColumnChange) string {
+	parts := make([]string, len(changes))
+	for i, change := range changes {
+		parts[i] = fmt.Sprintf("%s: %v → %v", change.Column, change.Before, change.After)
+	}
+	return strings.Join(parts, ", ")
+}
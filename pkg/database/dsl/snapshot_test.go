@@ -0,0 +1,232 @@
+package dsl
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotRow struct {
+	ID       int64          `db:"id"`
+	Username string         `db:"username"`
+	Status   string         `db:"status"`
+	Email    sql.NullString `db:"email"`
+	Updated  int64          `db:"updated_at"`
+}
+
+func TestComputeDiff(t *testing.T) {
+	before := []snapshotRow{
+		{ID: 1, Username: "alice", Status: "active"},
+		{ID: 2, Username: "bob", Status: "active"},
+		{ID: 3, Username: "carol", Status: "active"},
+	}
+	after := []snapshotRow{
+		{ID: 1, Username: "alice", Status: "active"},
+		{ID: 2, Username: "bob", Status: "blocked", Email: sql.NullString{String: "bob@example.com", Valid: true}},
+		{ID: 4, Username: "dave", Status: "active"},
+	}
+
+	result, err := computeDiff(before, after, []string{"id"}, map[string]bool{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Inserted, 1)
+	assert.Equal(t, int64(4), result.Inserted[0].ID)
+
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, "2", result.Updated[0].Key)
+	assert.Equal(t, []ColumnChange{
+		{Column: "status", Before: "active", After: "blocked"},
+		{Column: "email", Before: nil, After: "bob@example.com"},
+	}, result.Updated[0].Changes)
+
+	require.Len(t, result.Deleted, 1)
+	assert.Equal(t, int64(3), result.Deleted[0].ID)
+}
+
+func TestComputeDiff_IgnoredColumns(t *testing.T) {
+	before := []snapshotRow{{ID: 1, Status: "active", Updated: 100}}
+	after := []snapshotRow{{ID: 1, Status: "active", Updated: 200}}
+
+	result, err := computeDiff(before, after, []string{"id"}, map[string]bool{"updated_at": true})
+	require.NoError(t, err)
+	assert.Empty(t, result.Updated)
+}
+
+func TestComputeDiff_CompositeKey(t *testing.T) {
+	before := []snapshotRow{{ID: 1, Username: "alice", Status: "active"}}
+	after := []snapshotRow{{ID: 1, Username: "alice", Status: "blocked"}}
+
+	result, err := computeDiff(before, after, []string{"id", "username"}, map[string]bool{})
+	require.NoError(t, err)
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, "1/alice", result.Updated[0].Key)
+	assert.Equal(t, formatKey([]any{1, "alice"}), result.Updated[0].Key)
+}
+
+func TestComputeDiff_KeyErrors(t *testing.T) {
+	_, err := computeDiff([]snapshotRow{{ID: 1}}, nil, []string{"uuid"}, map[string]bool{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key column 'uuid' not found")
+
+	_, err = computeDiff([]snapshotRow{{ID: 1}, {ID: 1}}, nil, []string{"id"}, map[string]bool{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate key 1")
+}
+
+func TestDiffVerify_DeclaredChangesPass(t *testing.T) {
+	d := (&TableSnapshot[snapshotRow]{}).ExpectDiff().
+		Inserted(snapshotRow{Username: "dave"}).
+		Updated(2, map[string]any{"status": "blocked"}).
+		Deleted(3)
+
+	result := &DiffResult[snapshotRow]{
+		Inserted: []snapshotRow{{ID: 4, Username: "dave", Status: "active"}},
+		Updated: []RowUpdate[snapshotRow]{{
+			Key:     "2",
+			Changes: []ColumnChange{{Column: "status", Before: "active", After: "blocked"}},
+		}},
+		Deleted: []snapshotRow{{ID: 3, Username: "carol"}},
+	}
+
+	assert.Empty(t, d.verify(result))
+}
+
+func TestDiffVerify_UndeclaredChangesFail(t *testing.T) {
+	d := (&TableSnapshot[snapshotRow]{}).ExpectDiff()
+
+	result := &DiffResult[snapshotRow]{
+		Inserted: []snapshotRow{{ID: 4, Username: "dave"}},
+		Updated: []RowUpdate[snapshotRow]{{
+			Key:     "2",
+			Changes: []ColumnChange{{Column: "status", Before: "active", After: "blocked"}},
+		}},
+		Deleted: []snapshotRow{{ID: 3}},
+	}
+
+	assert.Equal(t, []string{
+		"unexpected inserted row {id=4, username=dave}",
+		"unexpected update of row 2: status: active → blocked",
+		"unexpected deleted row 3",
+	}, d.verify(result))
+}
+
+func TestDiffVerify_MissingAndWrongChanges(t *testing.T) {
+	d := (&TableSnapshot[snapshotRow]{}).ExpectDiff().
+		Inserted(snapshotRow{Username: "erin"}).
+		Updated(2, map[string]any{"status": "blocked", "username": "robert"}).
+		Updated(5, map[string]any{"status": "blocked"}).
+		Deleted(3)
+
+	result := &DiffResult[snapshotRow]{
+		Updated: []RowUpdate[snapshotRow]{{
+			Key: "2",
+			Changes: []ColumnChange{
+				{Column: "status", Before: "active", After: "suspended"},
+				{Column: "email", Before: nil, After: "bob@example.com"},
+			},
+		}},
+	}
+
+	failures := d.verify(result)
+	assert.Contains(t, failures, "expected inserted row #1 {username=erin} was not inserted")
+	assert.Contains(t, failures, "row 2: column 'status' expected blocked, got suspended")
+	assert.Contains(t, failures, "row 2: column 'username' expected to change to robert, but was not changed")
+	assert.Contains(t, failures, "row 2: unexpected change of column 'email': <nil> → bob@example.com")
+	assert.Contains(t, failures, "row 5 was not updated")
+	assert.Contains(t, failures, "row 3 was not deleted")
+	assert.Len(t, failures, 6)
+}
+
+func TestDiffExecute_RequeriesTable(t *testing.T) {
+	c, mock := newMockClient(t)
+	columns := []string{"id", "username", "status", "email", "updated_at"}
+	mock.ExpectQuery("SELECT id, username, status, email, updated_at FROM players WHERE status = \\?").
+		WithArgs("active").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(1), "alice", "active", nil, int64(1)).
+			AddRow(int64(2), "bob", "active", nil, int64(1)))
+
+	snap := &TableSnapshot[snapshotRow]{
+		client: c,
+		ctx:    context.Background(),
+		table:  "players",
+		where:  "status = ?",
+		args:   []any{"active"},
+	}
+
+	rows, err := snap.capture(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	snap.rows = rows
+
+	mock.ExpectQuery("SELECT id, username, status, email, updated_at FROM players WHERE status = \\?").
+		WithArgs("active").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(1), "alice", "active", nil, int64(2)).
+			AddRow(int64(3), "carol", "active", "carol@example.com", int64(2)))
+
+	result, err := snap.ExpectDiff().
+		IgnoreColumns("updated_at").
+		Inserted(snapshotRow{Username: "carol"}).
+		Deleted(2).
+		execute(context.Background())
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Failures)
+	assert.Empty(t, result.Updated)
+	require.Len(t, result.Inserted, 1)
+	assert.Equal(t, "carol@example.com", result.Inserted[0].Email.String)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSnapshotSelectSQL(t *testing.T) {
+	assert.Equal(t, "SELECT id, username, status, email, updated_at FROM players", (&TableSnapshot[snapshotRow]{table: "players"}).selectSQL())
+	assert.Equal(t, "SELECT id, username, status, email, updated_at FROM players WHERE region = $1",
+		(&TableSnapshot[snapshotRow]{table: "players", where: "region = $1"}).selectSQL())
+}
+
+type embeddedRow struct {
+	snapshotRow
+	Region  string
+	Skipped string `db:"-"`
+	private string
+}
+
+func TestSelectColumns(t *testing.T) {
+	assert.Equal(t, "id, username, status, email, updated_at, region", selectColumns[embeddedRow]())
+	assert.Equal(t, "id, username, status, email, updated_at", selectColumns[*snapshotRow]())
+	assert.Equal(t, "*", selectColumns[map[string]any]())
+}
+
+type untaggedRow struct {
+	ID   int64
+	Name string
+}
+
+func TestComputeDiff_UsesSelectedColumnNames(t *testing.T) {
+	var names []string
+	for _, col := range rowColumns(embeddedRow{}) {
+		names = append(names, col.name)
+	}
+	assert.Equal(t, selectColumns[embeddedRow](), strings.Join(names, ", "))
+
+	result, err := computeDiff([]untaggedRow{{ID: 1, Name: "a"}}, []untaggedRow{{ID: 1, Name: "b"}}, []string{"id"}, map[string]bool{})
+	require.NoError(t, err, "untagged fields are keyed by their sqlx column name")
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, []ColumnChange{{Column: "name", Before: "a", After: "b"}}, result.Updated[0].Changes)
+
+	before := []embeddedRow{{snapshotRow: snapshotRow{ID: 1, Status: "active"}, Region: "EU"}}
+	after := []embeddedRow{{snapshotRow: snapshotRow{ID: 1, Status: "blocked"}, Region: "US"}}
+	embedded, err := computeDiff(before, after, []string{"id"}, map[string]bool{"region": true})
+	require.NoError(t, err)
+	require.Len(t, embedded.Updated, 1)
+	assert.Equal(t, []ColumnChange{{Column: "status", Before: "active", After: "blocked"}}, embedded.Updated[0].Changes)
+
+	d := (&TableSnapshot[embeddedRow]{}).ExpectDiff().Key("id").Updated(1, map[string]any{"status": "blocked"})
+	assert.Empty(t, d.verify(embedded))
+}
@@ -0,0 +1,123 @@
+package dsl
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/database/client"
+)
+
+func newSQLiteClient(t *testing.T) *client.Client {
+	t.Helper()
+	c, err := client.New(client.Config{Driver: "sqlite", DSN: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	_, err = c.DB.Exec(`CREATE TABLE players (
+		id INTEGER PRIMARY KEY,
+		username TEXT NOT NULL,
+		status TEXT NOT NULL,
+		email TEXT,
+		updated_at INTEGER NOT NULL DEFAULT 0
+	)`)
+	require.NoError(t, err)
+	return c
+}
+
+func TestSQLite_ExecAndQuery(t *testing.T) {
+	c := newSQLiteClient(t)
+	ctx := context.Background()
+
+	e := NewExec(nil, c).SQL("INSERT INTO players (username, status) VALUES ($1, $2)", "alice", "active")
+	result := execStatement(ctx, c.DB, e.sql, e.args)
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(1), result.RowsAffected)
+	assert.Equal(t, int64(1), result.LastInsertID)
+
+	returning := execStatement(ctx, c.DB, "INSERT INTO players (username, status) VALUES (?, ?) RETURNING id, username", []any{"bob", "active"})
+	require.NoError(t, returning.Error)
+	require.Len(t, returning.Rows, 1)
+	assert.Equal(t, "bob", returning.Rows[0]["username"])
+
+	q := NewQuery[snapshotRow](nil, c).SQL("SELECT * FROM players WHERE username = $1", "bob")
+	var duration time.Duration
+	row, err := q.timedQuery(&duration)(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), row.ID)
+
+	email, err := getFieldValueByColumnName(row, "email")
+	require.NoError(t, err)
+	assert.Equal(t, sql.NullString{}, email)
+}
+
+func TestSQLite_SnapshotDiff(t *testing.T) {
+	c := newSQLiteClient(t)
+	ctx := context.Background()
+
+	_, err := c.DB.Exec("INSERT INTO players (id, username, status) VALUES (1, 'alice', 'active'), (2, 'bob', 'active'), (3, 'carol', 'active')")
+	require.NoError(t, err)
+
+	snap := &TableSnapshot[snapshotRow]{client: c, ctx: ctx, table: "players"}
+	snap.where, snap.args = rebind(c, "status = $1", []any{"active"})
+	snap.rows, err = snap.capture(ctx)
+	require.NoError(t, err)
+	require.Len(t, snap.rows, 3)
+
+	_, err = c.DB.Exec("UPDATE players SET status = 'blocked', updated_at = 1 WHERE id = 2")
+	require.NoError(t, err)
+	_, err = c.DB.Exec("DELETE FROM players WHERE id = 3")
+	require.NoError(t, err)
+	_, err = c.DB.Exec("INSERT INTO players (id, username, status) VALUES (4, 'dave', 'active')")
+	require.NoError(t, err)
+
+	// Row 2 leaves the snapshot filter (status = active), so it shows up as deleted.
+	result, err := snap.ExpectDiff().
+		Inserted(snapshotRow{Username: "dave"}).
+		Deleted(2, 3).
+		execute(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Failures)
+
+	full := &TableSnapshot[snapshotRow]{client: c, ctx: ctx, table: "players"}
+	full.rows, err = full.capture(ctx)
+	require.NoError(t, err)
+
+	_, err = c.DB.Exec("UPDATE players SET email = 'bob@example.com', updated_at = 2 WHERE id = 2")
+	require.NoError(t, err)
+
+	result, err = full.ExpectDiff().
+		IgnoreColumns("updated_at").
+		Updated(2, map[string]any{"email": "bob@example.com"}).
+		execute(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, []ColumnChange{{Column: "email", Before: nil, After: "bob@example.com"}}, result.Updated[0].Changes)
+}
+
+func TestSQLite_SnapshotIgnoresUnmappedColumns(t *testing.T) {
+	c := newSQLiteClient(t)
+	ctx := context.Background()
+
+	_, err := c.DB.Exec("ALTER TABLE players ADD COLUMN region TEXT NOT NULL DEFAULT 'EU'")
+	require.NoError(t, err)
+	_, err = c.DB.Exec("INSERT INTO players (id, username, status) VALUES (1, 'alice', 'active')")
+	require.NoError(t, err)
+
+	snap := &TableSnapshot[snapshotRow]{client: c, ctx: ctx, table: "players"}
+	snap.rows, err = snap.capture(ctx)
+	require.NoError(t, err, "a column the row struct does not declare must not break the snapshot")
+	require.Len(t, snap.rows, 1)
+
+	_, err = c.DB.Exec("INSERT INTO players (id, username, status, region) VALUES (2, 'bob', 'active', 'US')")
+	require.NoError(t, err)
+
+	result, err := snap.ExpectDiff().Inserted(snapshotRow{Username: "bob"}).execute(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, result.Failures)
+}
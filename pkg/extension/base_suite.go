@@ -6,6 +6,8 @@ import (
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 	"github.com/ozontech/allure-go/pkg/framework/suite"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/testscope"
 )
 
 type BaseSuite struct {
@@ -13,16 +15,19 @@ type BaseSuite struct {
 	tExt     *TExtension
 	asyncWg  sync.WaitGroup
 	currentT provider.T
+	scope    *testscope.Scope
 }
 
 func (s *BaseSuite) BeforeEach(t provider.T) {
 	s.tExt = nil
 	s.currentT = t
+	s.scope = testscope.New()
 }
 
 func (s *BaseSuite) T(t provider.T) *TExtension {
 	if s.tExt == nil {
 		s.tExt = NewTExtension(t)
+		s.tExt.scope = s.scope
 	}
 	return s.tExt
 }
@@ -40,6 +45,16 @@ func (s *BaseSuite) AsyncStep(t provider.T, name string, fn func(sCtx provider.S
 	}, params...)
 }
 
+// AfterEach waits for async steps, releases per-test resources, e.g. rolls back
+// database transactions opened with isolateTests, and updates the contract coverage files.
 func (s *BaseSuite) AfterEach(t provider.T) {
 	s.asyncWg.Wait()
+	defer flushContractCoverage(t)
+
+	if s.scope != nil {
+		if err := s.scope.Close(); err != nil {
+			t.Errorf("failed to release test resources: %v", err)
+		}
+		s.scope = nil
+	}
 }
@@ -0,0 +1,62 @@
+package extension
+
+import (
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/allure"
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
+)
+
+var coverageReporter = allure.NewDefaultReporter()
+
+var (
+	coverageMu      sync.Mutex
+	coverageWritten uint64
+)
+
+// AfterAll attaches the OpenAPI contract coverage to the suite (see WriteContractCoverage).
+// The coverage files are kept up to date by AfterEach, so a suite with its own AfterAll
+// only misses the attachment; call WriteContractCoverage(t) there to keep it.
+func (s *BaseSuite) AfterAll(t provider.T) {
+	WriteContractCoverage(t)
+}
+
+// WriteContractCoverage writes the OpenAPI contract coverage of HTTP clients with a
+// contractSpec to contract-coverage.json and environment.properties in the Allure results
+// directory and attaches a "Contract Coverage" report to t. The coverage is collected per
+// test binary, so the files hold the totals of all suites run so far.
+func WriteContractCoverage(t provider.T) {
+	reports := writeContractCoverage(t.Logf, true)
+	if len(reports) > 0 {
+		coverageReporter.AttachContractCoverageReport(t, reports)
+	}
+}
+
+// flushContractCoverage rewrites the coverage files when calls were recorded since
+// the last write. AfterEach calls it, so the files do not depend on AfterAll.
+func flushContractCoverage(t provider.T) {
+	writeContractCoverage(t.Logf, false)
+}
+
+func writeContractCoverage(logf func(format string, args ...any), force bool) []contract.CoverageReport {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	recorded := contract.RecordedCalls()
+	if !force && recorded == coverageWritten {
+		return nil
+	}
+	reports := contract.CoverageReports()
+	if len(reports) == 0 {
+		return nil
+	}
+
+	if err := allure.WriteContractCoverage(allure.ResultsPath(), reports); err != nil {
+		logf("failed to write contract coverage: %v", err)
+		return reports
+	}
+	coverageWritten = recorded
+	return reports
+}
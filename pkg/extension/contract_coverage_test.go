@@ -0,0 +1,50 @@
+package extension
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/allure"
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
+)
+
+func TestFlushContractCoverage_WritesOnlyNewCalls(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("ALLURE_OUTPUT_PATH", dir)
+	t.Setenv("ALLURE_OUTPUT_FOLDER", "results")
+	file := filepath.Join(dir, "results", allure.ContractCoverageFile)
+
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info: {title: Flush, version: "1"}
+paths:
+  /ping:
+    get:
+      responses:
+        "200": {description: ok}
+`))
+	require.NoError(t, err)
+	v := contract.NewValidatorFromSpec(spec)
+
+	v.RecordCall(http.MethodGet, "/ping", http.StatusOK)
+	writeContractCoverage(t.Logf, false)
+	require.FileExists(t, file, "the first flush after a call writes the files")
+
+	require.NoError(t, os.Remove(file))
+	writeContractCoverage(t.Logf, false)
+	assert.NoFileExists(t, file, "nothing new was recorded")
+
+	v.RecordCall(http.MethodGet, "/ping", http.StatusOK)
+	writeContractCoverage(t.Logf, false)
+	assert.FileExists(t, file)
+
+	require.NoError(t, os.Remove(file))
+	assert.NotEmpty(t, writeContractCoverage(t.Logf, true))
+	assert.FileExists(t, file, "WriteContractCoverage always writes")
+}
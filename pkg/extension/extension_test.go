@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/testscope"
 )
 
 type mockStepCtx struct {
@@ -591,3 +592,38 @@ func TestBaseSuite_CurrentTField(t *testing.T) {
 
 	assert.Nil(t, s.currentT, "currentT should be nil initially")
 }
+
+// =============================================================================
+// Test scope propagation
+// =============================================================================
+
+func TestStepCtxWrapper_WithNewStep_PreservesScope(t *testing.T) {
+	scope := testscope.New()
+	wrapper := &stepCtxWrapper{StepCtx: &mockStepCtx{}, mode: SyncMode, scope: scope}
+
+	var captured *testscope.Scope
+	wrapper.WithNewStep("outer", func(sCtx provider.StepCtx) {
+		sCtx.WithNewStep("inner", func(sCtx provider.StepCtx) {
+			captured = testscope.FromStepCtx(sCtx)
+		})
+	})
+
+	assert.Same(t, scope, captured)
+}
+
+func TestWithModeSwitch_PreservesScope(t *testing.T) {
+	scope := testscope.New()
+	wrapper := &stepCtxWrapper{StepCtx: &mockStepCtx{}, mode: SyncMode, scope: scope}
+
+	assert.Same(t, scope, testscope.FromStepCtx(WithAsyncMode(wrapper)))
+	assert.Same(t, scope, testscope.FromStepCtx(WithSyncMode(WithAsyncMode(wrapper))))
+}
+
+func TestWithScope_PlainCtx(t *testing.T) {
+	scope := testscope.New()
+
+	ctx := withScope(&mockStepCtx{}, scope)
+
+	assert.Same(t, scope, testscope.FromStepCtx(ctx))
+	assert.Equal(t, SyncMode, GetStepMode(ctx))
+}
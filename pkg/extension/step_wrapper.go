@@ -3,22 +3,31 @@ package extension
 import (
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/testscope"
 )
 
 type stepCtxWrapper struct {
 	provider.StepCtx
-	mode StepMode
+	mode  StepMode
+	scope *testscope.Scope
 }
 
 func (w *stepCtxWrapper) StepMode() StepMode {
 	return w.mode
 }
 
+// TestScope returns the per-test scope (nil outside BaseSuite).
+func (w *stepCtxWrapper) TestScope() *testscope.Scope {
+	return w.scope
+}
+
 func (w *stepCtxWrapper) WithNewStep(stepName string, step func(sCtx provider.StepCtx), params ...*allure.Parameter) {
 	w.StepCtx.WithNewStep(stepName, func(sCtx provider.StepCtx) {
 		wrappedCtx := &stepCtxWrapper{
 			StepCtx: sCtx,
 			mode:    w.mode,
+			scope:   w.scope,
 		}
 		step(wrappedCtx)
 	}, params...)
@@ -29,34 +38,41 @@ func (w *stepCtxWrapper) WithNewAsyncStep(stepName string, step func(sCtx provid
 		wrappedCtx := &stepCtxWrapper{
 			StepCtx: sCtx,
 			mode:    w.mode,
+			scope:   w.scope,
 		}
 		step(wrappedCtx)
 	}, params...)
 }
 
 func WithAsyncMode(sCtx provider.StepCtx) provider.StepCtx {
+	return withMode(sCtx, AsyncMode)
+}
+
+func WithSyncMode(sCtx provider.StepCtx) provider.StepCtx {
+	return withMode(sCtx, SyncMode)
+}
+
+func withMode(sCtx provider.StepCtx, mode StepMode) provider.StepCtx {
 	if wrapped, ok := sCtx.(*stepCtxWrapper); ok {
 		return &stepCtxWrapper{
 			StepCtx: wrapped.StepCtx,
-			mode:    AsyncMode,
+			mode:    mode,
+			scope:   wrapped.scope,
 		}
 	}
 	return &stepCtxWrapper{
 		StepCtx: sCtx,
-		mode:    AsyncMode,
+		mode:    mode,
 	}
 }
 
-func WithSyncMode(sCtx provider.StepCtx) provider.StepCtx {
+func withScope(sCtx provider.StepCtx, scope *testscope.Scope) provider.StepCtx {
 	if wrapped, ok := sCtx.(*stepCtxWrapper); ok {
-		return &stepCtxWrapper{
-			StepCtx: wrapped.StepCtx,
-			mode:    SyncMode,
-		}
+		wrapped.scope = scope
+		return wrapped
 	}
 	return &stepCtxWrapper{
 		StepCtx: sCtx,
-		mode:    SyncMode,
+		scope:   scope,
 	}
 }
-
@@ -3,10 +3,13 @@ package extension
 import (
 	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/testscope"
 )
 
 type TExtension struct {
 	provider.T
+	scope *testscope.Scope
 }
 
 func NewTExtension(t provider.T) *TExtension {
@@ -15,15 +18,14 @@ func NewTExtension(t provider.T) *TExtension {
 
 func (t *TExtension) WithNewStep(stepName string, step func(sCtx provider.StepCtx), params ...*allure.Parameter) {
 	t.T.WithNewStep(stepName, func(sCtx provider.StepCtx) {
-		syncCtx := WithSyncMode(sCtx)
+		syncCtx := withScope(WithSyncMode(sCtx), t.scope)
 		step(syncCtx)
 	}, params...)
 }
 
 func (t *TExtension) WithNewAsyncStep(stepName string, step func(sCtx provider.StepCtx), params ...*allure.Parameter) {
 	t.T.WithNewAsyncStep(stepName, func(sCtx provider.StepCtx) {
-		asyncCtx := WithAsyncMode(sCtx)
+		asyncCtx := withScope(WithAsyncMode(sCtx), t.scope)
 		step(asyncCtx)
 	}, params...)
 }
-
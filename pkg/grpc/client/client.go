@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 
@@ -15,16 +14,30 @@ import (
 )
 
 type Client struct {
-	conn        *grpc.ClientConn
-	target      string
-	AsyncConfig config.AsyncConfig
+	conn          *grpc.ClientConn
+	target        string
+	resolver      *descriptorResolver
+	healthService string
+	AsyncConfig   config.AsyncConfig
 }
 
 type Config struct {
-	Target      string             `mapstructure:"target" yaml:"target" json:"target"`
-	Timeout     time.Duration      `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
-	Insecure    bool               `mapstructure:"insecure" yaml:"insecure" json:"insecure"`
-	AsyncConfig config.AsyncConfig `mapstructure:"asyncConfig" yaml:"asyncConfig" json:"asyncConfig"`
+	Target   string           `mapstructure:"target" yaml:"target" json:"target"`
+	Timeout  time.Duration    `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+	Insecure bool             `mapstructure:"insecure" yaml:"insecure" json:"insecure"`
+	TLS      config.TLSConfig `mapstructure:"tls" yaml:"tls" json:"tls"`
+	// Token is sent as "authorization: Bearer <token>" metadata on every RPC.
+	Token          string          `mapstructure:"token" yaml:"token" json:"token"`
+	Keepalive      KeepaliveConfig `mapstructure:"keepalive" yaml:"keepalive" json:"keepalive"`
+	MaxRecvMsgSize int             `mapstructure:"maxRecvMsgSize" yaml:"maxRecvMsgSize" json:"maxRecvMsgSize"`
+	MaxSendMsgSize int             `mapstructure:"maxSendMsgSize" yaml:"maxSendMsgSize" json:"maxSendMsgSize"`
+	// DescriptorSets are protoc --descriptor_set_out files used by dynamic calls
+	// instead of server reflection.
+	DescriptorSets []string `mapstructure:"descriptorSets" yaml:"descriptorSets" json:"descriptorSets"`
+	// WaitReady makes BuildEnv block until HealthService reports SERVING (0 disables the check).
+	WaitReady     time.Duration      `mapstructure:"waitReady" yaml:"waitReady" json:"waitReady"`
+	HealthService string             `mapstructure:"healthService" yaml:"healthService" json:"healthService"`
+	AsyncConfig   config.AsyncConfig `mapstructure:"asyncConfig" yaml:"asyncConfig" json:"asyncConfig"`
 }
 
 func New(cfg Config) (*Client, error) {
@@ -32,10 +45,14 @@ func New(cfg Config) (*Client, error) {
 		return nil, fmt.Errorf("gRPC target address is required")
 	}
 
-	opts := []grpc.DialOption{}
+	opts, err := dialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	if cfg.Insecure {
-		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	resolver, err := newDescriptorResolver(cfg.DescriptorSets)
+	if err != nil {
+		return nil, err
 	}
 
 	conn, err := grpc.Dial(cfg.Target, opts...)
@@ -46,9 +63,11 @@ func New(cfg Config) (*Client, error) {
 	asyncCfg := cfg.AsyncConfig.WithDefaults()
 
 	return &Client{
-		conn:        conn,
-		target:      cfg.Target,
-		AsyncConfig: asyncCfg,
+		conn:          conn,
+		target:        cfg.Target,
+		resolver:      resolver,
+		healthService: cfg.HealthService,
+		AsyncConfig:   asyncCfg,
 	}, nil
 }
 
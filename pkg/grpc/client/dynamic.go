@@ -0,0 +1,288 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Server reflection services, newest first. v1alpha has the same wire format,
+// so both are called with the v1 message types.
+var reflectionMethods = []string{
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+}
+
+// dynamicJSON renders dynamic responses in the canonical proto JSON mapping with
+// proto field names, keeping zero values so they can be asserted. Unlike the
+// encoding/json output of generated Go types, 64-bit integers are strings,
+// enums are value names and well-known types use their JSON form (Timestamp as
+// RFC 3339, Duration as "1.5s").
+var dynamicJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// descriptorResolver finds method descriptors in the configured descriptor sets
+// or, without them, through server reflection. Resolved methods are cached.
+type descriptorResolver struct {
+	mu      sync.Mutex
+	files   *protoregistry.Files
+	methods map[string]protoreflect.MethodDescriptor
+}
+
+func newDescriptorResolver(descriptorSets []string) (*descriptorResolver, error) {
+	r := &descriptorResolver{methods: make(map[string]protoreflect.MethodDescriptor)}
+	if len(descriptorSets) == 0 {
+		return r, nil
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	seen := make(map[string]bool)
+	for _, path := range descriptorSets {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gRPC descriptor set '%s': %w", path, err)
+		}
+		fileSet := &descriptorpb.FileDescriptorSet{}
+		if err := proto.Unmarshal(raw, fileSet); err != nil {
+			return nil, fmt.Errorf("failed to parse gRPC descriptor set '%s': %w", path, err)
+		}
+		for _, file := range fileSet.GetFile() {
+			if !seen[file.GetName()] {
+				seen[file.GetName()] = true
+				set.File = append(set.File, file)
+			}
+		}
+	}
+
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gRPC descriptor sets (build them with protoc --include_imports): %w", err)
+	}
+	r.files = files
+	return r, nil
+}
+
+// ResolveMethod returns the descriptor of fullMethod ("/package.Service/Method").
+func (c *Client) ResolveMethod(ctx context.Context, fullMethod string) (protoreflect.MethodDescriptor, error) {
+	service, method, err := splitMethod(fullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	r := c.resolver
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := service + "/" + method
+	if md, ok := r.methods[key]; ok {
+		return md, nil
+	}
+
+	files := r.files
+	if files == nil {
+		if files, err = reflectFiles(ctx, c.conn, service); err != nil {
+			return nil, err
+		}
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("gRPC service '%s' not found: %w", service, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not a gRPC service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("gRPC method '%s' not found in service '%s'", method, service)
+	}
+
+	r.methods[key] = md
+	return md, nil
+}
+
+// splitMethod accepts "/package.Service/Method" with or without the leading slash.
+func splitMethod(fullMethod string) (string, string, error) {
+	trimmed := strings.TrimPrefix(fullMethod, "/")
+	idx := strings.LastIndex(trimmed, "/")
+	if idx <= 0 || idx == len(trimmed)-1 {
+		return "", "", fmt.Errorf("invalid gRPC method '%s', expected \"package.Service/Method\"", fullMethod)
+	}
+	return trimmed[:idx], trimmed[idx+1:], nil
+}
+
+// InvokeDynamic calls a unary method without generated types: the JSON body is encoded
+// with the method's input descriptor and the response is returned as JSON.
+func InvokeDynamic(
+	ctx context.Context,
+	c *Client,
+	fullMethod string,
+	body json.RawMessage,
+	md metadata.MD,
+) (*Response[json.RawMessage], error) {
+	start := time.Now()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	fail := func(err error) (*Response[json.RawMessage], error) {
+		return &Response[json.RawMessage]{Duration: time.Since(start), Error: err}, err
+	}
+
+	method, err := c.ResolveMethod(ctx, fullMethod)
+	if err != nil {
+		return fail(fmt.Errorf("failed to resolve gRPC method: %w", err))
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return fail(fmt.Errorf("gRPC method '%s' is streaming; dynamic calls support unary methods only", method.FullName()))
+	}
+
+	req := dynamicpb.NewMessage(method.Input())
+	if len(body) > 0 {
+		if err := protojson.Unmarshal(body, req); err != nil {
+			return fail(fmt.Errorf("failed to encode request as %s: %w", method.Input().FullName(), err))
+		}
+	}
+
+	if md != nil {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	var headerMD, trailerMD metadata.MD
+	resp := dynamicpb.NewMessage(method.Output())
+	path := fmt.Sprintf("/%s/%s", method.Parent().FullName(), method.Name())
+
+	err = c.conn.Invoke(ctx, path, req, resp, grpc.Header(&headerMD), grpc.Trailer(&trailerMD))
+
+	response := &Response[json.RawMessage]{
+		Duration: time.Since(start),
+		Error:    err,
+		Metadata: metadata.Join(headerMD, trailerMD),
+	}
+
+	if err == nil {
+		raw, marshalErr := dynamicJSON.Marshal(resp)
+		if marshalErr != nil {
+			response.Error = fmt.Errorf("failed to decode response %s: %w", method.Output().FullName(), marshalErr)
+			return response, response.Error
+		}
+		body := json.RawMessage(raw)
+		response.Body = &body
+		response.RawBody = raw
+	}
+
+	return response, err
+}
+
+// reflectFiles fetches the file defining symbol and all its dependencies via server reflection.
+// Dependencies the server does not return (e.g. well-known types) are taken from the
+// descriptors linked into this binary.
+func reflectFiles(ctx context.Context, conn *grpc.ClientConn, symbol string) (*protoregistry.Files, error) {
+	var lastErr error
+	for _, path := range reflectionMethods {
+		files, err := reflectFilesVia(ctx, conn, path, symbol)
+		if status.Code(err) == codes.Unimplemented {
+			lastErr = err
+			continue
+		}
+		return files, err
+	}
+	return nil, fmt.Errorf("server reflection is not available: %w", lastErr)
+}
+
+func reflectFilesVia(ctx context.Context, conn *grpc.ClientConn, path, symbol string) (*protoregistry.Files, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+	var order []string
+	add := func(resp *reflectionpb.ServerReflectionResponse) error {
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, file); err != nil {
+				return fmt.Errorf("invalid descriptor from server reflection: %w", err)
+			}
+			if _, ok := files[file.GetName()]; !ok {
+				files[file.GetName()] = file
+				order = append(order, file.GetName())
+			}
+		}
+		return nil
+	}
+
+	resp, err := reflectionRequest(stream, &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := add(resp); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(order); i++ {
+		for _, dep := range files[order[i]].GetDependency() {
+			if _, ok := files[dep]; ok {
+				continue
+			}
+			resp, err := reflectionRequest(stream, &reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+			})
+			if err == nil {
+				err = add(resp)
+			}
+			if _, ok := files[dep]; ok {
+				continue
+			}
+			linked, findErr := protoregistry.GlobalFiles.FindFileByPath(dep)
+			if findErr != nil {
+				return nil, fmt.Errorf("dependency '%s' of '%s' not available via server reflection: %v", dep, order[i], err)
+			}
+			files[dep] = protodesc.ToFileDescriptorProto(linked)
+			order = append(order, dep)
+		}
+	}
+
+	set := &descriptorpb.FileDescriptorSet{}
+	for _, name := range order {
+		set.File = append(set.File, files[name])
+	}
+	return protodesc.NewFiles(set)
+}
+
+func reflectionRequest(stream grpc.ClientStream, req *reflectionpb.ServerReflectionRequest) (*reflectionpb.ServerReflectionResponse, error) {
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	resp := &reflectionpb.ServerReflectionResponse{}
+	if err := stream.RecvMsg(resp); err != nil {
+		return nil, err
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, status.Error(codes.Code(errResp.GetErrorCode()), errResp.GetErrorMessage())
+	}
+	return resp, nil
+}
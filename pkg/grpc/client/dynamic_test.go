@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newHealthServer starts a gRPC health service, optionally with server reflection.
+func newHealthServer(t *testing.T, withReflection bool) (string, *health.Server) {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	hs := health.NewServer()
+	hs.SetServingStatus("players", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(srv, hs)
+	if withReflection {
+		reflection.Register(srv)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String(), hs
+}
+
+func invokeDynamic(t *testing.T, c *Client, method, body string) (*Response[json.RawMessage], error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return InvokeDynamic(ctx, c, method, json.RawMessage(body), nil)
+}
+
+func TestInvokeDynamic_ServerReflection(t *testing.T) {
+	addr, _ := newHealthServer(t, true)
+	c, err := New(Config{Target: addr, Insecure: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	resp, err := invokeDynamic(t, c, "grpc.health.v1.Health/Check", `{"service": ""}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status": "SERVING"}`, string(*resp.Body))
+	assert.Equal(t, resp.RawBody, []byte(*resp.Body))
+
+	resp, err = invokeDynamic(t, c, "/grpc.health.v1.Health/Check", `{"service": "players"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status": "NOT_SERVING"}`, string(*resp.Body))
+
+	_, err = invokeDynamic(t, c, "grpc.health.v1.Health/Check", `{"service": "unknown"}`)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = invokeDynamic(t, c, "grpc.health.v1.Health/Check", `{"unknown_field": 1}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to encode request as grpc.health.v1.HealthCheckRequest")
+
+	_, err = invokeDynamic(t, c, "grpc.health.v1.Health/Watch", `{}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dynamic calls support unary methods only")
+
+	_, err = invokeDynamic(t, c, "grpc.health.v1.Health/Missing", `{}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gRPC method 'Missing' not found")
+
+	_, err = invokeDynamic(t, c, "missing.Service/Call", `{}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to resolve gRPC method")
+}
+
+func TestInvokeDynamic_WithoutReflection(t *testing.T) {
+	addr, _ := newHealthServer(t, false)
+	c, err := New(Config{Target: addr, Insecure: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	_, err = invokeDynamic(t, c, "grpc.health.v1.Health/Check", `{}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server reflection is not available")
+}
+
+func TestInvokeDynamic_DescriptorSet(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto),
+	}}
+	raw, err := proto.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "health.pb")
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	addr, _ := newHealthServer(t, false)
+	c, err := New(Config{Target: addr, Insecure: true, DescriptorSets: []string{path}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	resp, err := invokeDynamic(t, c, "grpc.health.v1.Health/Check", ``)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status": "SERVING"}`, string(*resp.Body))
+}
+
+func TestNew_DescriptorSetErrors(t *testing.T) {
+	_, err := New(Config{Target: "localhost:1", Insecure: true, DescriptorSets: []string{"missing.pb"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read gRPC descriptor set")
+
+	path := filepath.Join(t.TempDir(), "broken.pb")
+	require.NoError(t, os.WriteFile(path, []byte("not a descriptor set"), 0o600))
+	_, err = New(Config{Target: "localhost:1", Insecure: true, DescriptorSets: []string{path}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "descriptor set")
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method, err := splitMethod("/pkg.Service/Method")
+	require.NoError(t, err)
+	assert.Equal(t, "pkg.Service", service)
+	assert.Equal(t, "Method", method)
+
+	for _, invalid := range []string{"", "Method", "pkg.Service/", "/Method"} {
+		_, _, err := splitMethod(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+// orderFile describes test.OrderService with int64, enum and Timestamp fields.
+func orderFile(t *testing.T) protoreflect.FileDescriptor {
+	t.Helper()
+	fdp := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("order.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Status"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("STATUS_UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("ACTIVE"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("id"), JsonName: proto.String("id"), Number: proto.Int32(1),
+					Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum()},
+				{Name: proto.String("status"), JsonName: proto.String("status"), Number: proto.Int32(2),
+					Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(), TypeName: proto.String(".test.Status")},
+				{Name: proto.String("created_at"), JsonName: proto.String("createdAt"), Number: proto.Int32(3),
+					Label: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".google.protobuf.Timestamp")},
+			},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("OrderService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("GetOrder"),
+				InputType:  proto.String(".test.Order"),
+				OutputType: proto.String(".test.Order"),
+			}},
+		}},
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	return fd
+}
+
+// newOrderServer echoes the request Order back and returns its address.
+func newOrderServer(t *testing.T, fd protoreflect.FileDescriptor) string {
+	t.Helper()
+
+	order := fd.Messages().ByName("Order")
+	desc := grpc.ServiceDesc{
+		ServiceName: "test.OrderService",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "GetOrder",
+			Handler: func(_ any, _ context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := dynamicpb.NewMessage(order)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				return req, nil
+			},
+		}},
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	srv.RegisterService(&desc, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestInvokeDynamic_ProtoJSONMapping(t *testing.T) {
+	fd := orderFile(t)
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(timestamppb.File_google_protobuf_timestamp_proto),
+		protodesc.ToFileDescriptorProto(fd),
+	}}
+	raw, err := proto.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "order.pb")
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	c, err := New(Config{Target: newOrderServer(t, fd), Insecure: true, DescriptorSets: []string{path}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	// Requests accept int64 as a number or a string; responses always use strings.
+	resp, err := invokeDynamic(t, c, "test.OrderService/GetOrder",
+		`{"id": 9007199254740993, "status": "ACTIVE", "created_at": "2024-01-02T03:04:05Z"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "9007199254740993", "status": "ACTIVE", "created_at": "2024-01-02T03:04:05Z"}`, string(*resp.Body))
+
+	resp, err = invokeDynamic(t, c, "test.OrderService/GetOrder", `{"status": 1}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "0", "status": "ACTIVE", "created_at": null}`, string(*resp.Body))
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+// testCert is a self-signed certificate for "localhost" written to PEM files.
+type testCert struct {
+	certFile string
+	keyFile  string
+	pair     tls.Certificate
+	pool     *x509.CertPool
+}
+
+func newTestCert(t *testing.T, name string) testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	dir := t.TempDir()
+	cert := testCert{certFile: filepath.Join(dir, name+".pem"), keyFile: filepath.Join(dir, name+"-key.pem")}
+	require.NoError(t, os.WriteFile(cert.certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(cert.keyFile, keyPEM, 0o600))
+
+	cert.pair, err = tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	cert.pool = x509.NewCertPool()
+	cert.pool.AppendCertsFromPEM(certPEM)
+	return cert
+}
+
+// newEchoServer starts a unary "/test.Echo/Echo" server that returns the request
+// value followed by the authorization metadata it received.
+func newEchoServer(t *testing.T, opts ...grpc.ServerOption) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opts...)
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Echo",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Echo",
+			Handler: func(_ any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				req := new(wrapperspb.StringValue)
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				md, _ := metadata.FromIncomingContext(ctx)
+				return wrapperspb.String(req.GetValue() + "|" + strings.Join(md.Get("authorization"), ",")), nil
+			},
+		}},
+	}, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func echo(t *testing.T, cfg Config, value string) (*Response[wrapperspb.StringValue], error) {
+	t.Helper()
+	c, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return Invoke[wrapperspb.StringValue, wrapperspb.StringValue](ctx, c, "/test.Echo/Echo", wrapperspb.String(value), nil)
+}
+
+func TestNew_MutualTLSWithServerNameOverride(t *testing.T) {
+	serverCert := newTestCert(t, "server")
+	clientCert := newTestCert(t, "client")
+
+	addr := newEchoServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert.pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCert.pool,
+		MinVersion:   tls.VersionTLS12,
+	})))
+
+	resp, err := echo(t, Config{
+		Target: addr,
+		TLS: config.TLSConfig{
+			Enabled:    true,
+			CAFile:     serverCert.certFile,
+			CertFile:   clientCert.certFile,
+			KeyFile:    clientCert.keyFile,
+			ServerName: "localhost",
+		},
+		Token: "secret-token",
+	}, "hello")
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello|Bearer secret-token", resp.Body.GetValue())
+
+	// Without the client certificate the server rejects the handshake.
+	_, err = echo(t, Config{
+		Target: addr,
+		TLS:    config.TLSConfig{Enabled: true, CAFile: serverCert.certFile, ServerName: "localhost"},
+	}, "hello")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestNew_DefaultsToTLSWhenNotInsecure(t *testing.T) {
+	serverCert := newTestCert(t, "server")
+	addr := newEchoServer(t, grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert.pair}})))
+
+	// The self-signed server certificate is not trusted by the system roots.
+	_, err := echo(t, Config{Target: addr}, "hello")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestNew_InsecureTokenAndMessageSize(t *testing.T) {
+	addr := newEchoServer(t)
+
+	resp, err := echo(t, Config{Target: addr, Insecure: true, Token: "local"}, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "hi|Bearer local", resp.Body.GetValue())
+
+	_, err = echo(t, Config{Target: addr, Insecure: true, MaxSendMsgSize: 8}, strings.Repeat("x", 64))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = echo(t, Config{Target: addr, Insecure: true, MaxRecvMsgSize: 8}, strings.Repeat("x", 64))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestDialOptions_Validation(t *testing.T) {
+	_, err := New(Config{Target: "localhost:1", Insecure: true, TLS: config.TLSConfig{Enabled: true}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mutually exclusive")
+
+	_, err = New(Config{Target: "localhost:1", TLS: config.TLSConfig{Enabled: true, CAFile: "missing.pem"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid gRPC TLS config")
+
+	_, err = New(Config{Target: "localhost:1", Insecure: true, MaxRecvMsgSize: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must not be negative")
+
+	opts, err := dialOptions(Config{Insecure: true, Keepalive: KeepaliveConfig{Time: time.Minute}, MaxRecvMsgSize: 1 << 20})
+	require.NoError(t, err)
+	assert.Len(t, opts, 3)
+}
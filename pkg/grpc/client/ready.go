@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+)
+
+// WaitReady polls the grpc.health.v1 Check of HealthService ("" checks the whole server)
+// until it reports SERVING, or returns an error with the last observed state after timeout.
+func (c *Client) WaitReady(timeout time.Duration) error {
+	health := healthpb.NewHealthClient(c.conn)
+	err := polling.WaitReady(timeout, func(ctx context.Context) error {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: c.healthService})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("health status %s", resp.GetStatus())
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("gRPC %s not ready after %v: %w", c.target, timeout, err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestWaitReady_WaitsForServing(t *testing.T) {
+	addr, hs := newHealthServer(t, false)
+	hs.SetServingStatus("players", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	c, err := New(Config{Target: addr, Insecure: true, HealthService: "players"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	time.AfterFunc(300*time.Millisecond, func() {
+		hs.SetServingStatus("players", healthpb.HealthCheckResponse_SERVING)
+	})
+
+	start := time.Now()
+	require.NoError(t, c.WaitReady(5*time.Second))
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestWaitReady_Timeout(t *testing.T) {
+	addr, _ := newHealthServer(t, false)
+
+	c, err := New(Config{Target: addr, Insecure: true, HealthService: "players"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	err = c.WaitReady(300 * time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not ready after 300ms")
+	assert.Contains(t, err.Error(), "NOT_SERVING")
+}
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// StreamKind selects which side of a gRPC stream sends a sequence of messages.
+type StreamKind string
+
+const (
+	ServerStreaming StreamKind = "server-streaming"
+	ClientStreaming StreamKind = "client-streaming"
+	BidiStreaming   StreamKind = "bidi-streaming"
+)
+
+func (k StreamKind) desc() *grpc.StreamDesc {
+	return &grpc.StreamDesc{
+		ServerStreams: k != ClientStreaming,
+		ClientStreams: k != ServerStreaming,
+	}
+}
+
+// FrameDirection tells whether a stream frame was sent by the client or received from the server.
+type FrameDirection string
+
+const (
+	FrameSent     FrameDirection = "sent"
+	FrameReceived FrameDirection = "received"
+)
+
+// StreamFrame is a single message sent or received on a stream.
+// Offset is the time since the stream was opened.
+type StreamFrame struct {
+	Direction FrameDirection
+	Offset    time.Duration
+	Body      any
+	JSON      []byte
+}
+
+// StreamOptions controls how long a stream is read.
+// With neither MaxMessages nor CollectFor set, messages are received until the server closes the stream.
+type StreamOptions struct {
+	Kind     StreamKind
+	Metadata metadata.MD
+	// MaxMessages stops receiving once this many messages arrived.
+	MaxMessages int
+	// CollectFor stops receiving after this window, for streams the server never closes.
+	CollectFor time.Duration
+	// Timeout bounds the whole stream; exceeding it fails with DeadlineExceeded.
+	Timeout time.Duration
+}
+
+// StreamResponse holds everything received on a stream.
+// Error is the status the server closed the stream with; it is nil when the stream
+// closed with OK or was stopped by the client after MaxMessages or CollectFor.
+type StreamResponse[TResp any] struct {
+	Messages        []*TResp
+	Frames          []StreamFrame
+	Metadata        metadata.MD
+	Duration        time.Duration
+	Error           error
+	StoppedByClient bool
+}
+
+func (r *StreamResponse[TResp]) GetError() error {
+	if r == nil {
+		return nil
+	}
+	return r.Error
+}
+
+// Received returns the JSON of every received message in arrival order.
+func (r *StreamResponse[TResp]) Received() [][]byte {
+	if r == nil {
+		return nil
+	}
+	received := make([][]byte, 0, len(r.Messages))
+	for _, frame := range r.Frames {
+		if frame.Direction == FrameReceived {
+			received = append(received, frame.JSON)
+		}
+	}
+	return received
+}
+
+func (r *StreamResponse[TResp]) ToAny() *StreamResponse[any] {
+	if r == nil {
+		return nil
+	}
+	respAny := &StreamResponse[any]{
+		Frames:          r.Frames,
+		Metadata:        r.Metadata,
+		Duration:        r.Duration,
+		Error:           r.Error,
+		StoppedByClient: r.StoppedByClient,
+	}
+	if r.Messages != nil {
+		respAny.Messages = make([]*any, len(r.Messages))
+		for i, msg := range r.Messages {
+			var msgAny any = msg
+			respAny.Messages[i] = &msgAny
+		}
+	}
+	return respAny
+}
+
+// Stream opens a streaming RPC, sends requests in order and collects the server messages.
+// Sending runs concurrently with receiving so bidi servers can answer each message.
+func Stream[TReq any, TResp any](
+	ctx context.Context,
+	c *Client,
+	fullMethod string,
+	requests []*TReq,
+	opts StreamOptions,
+) (*StreamResponse[TResp], error) {
+	start := time.Now()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts.Kind == "" {
+		opts.Kind = BidiStreaming
+	}
+	if opts.Metadata != nil {
+		ctx = metadata.NewOutgoingContext(ctx, opts.Metadata)
+	}
+	if opts.Timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, opts.Timeout)
+		defer cancelTimeout()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	recorder := &frameRecorder{start: start}
+	response := &StreamResponse[TResp]{}
+
+	stream, err := c.conn.NewStream(ctx, opts.Kind.desc(), fullMethod)
+	if err != nil {
+		response.Error = err
+		response.Duration = time.Since(start)
+		return response, err
+	}
+
+	sendDone := make(chan error, 1)
+	go func() {
+		sendDone <- sendAll(stream, requests, recorder)
+	}()
+
+	var stopped atomic.Bool
+	var window *time.Timer
+	if opts.CollectFor > 0 {
+		window = time.AfterFunc(opts.CollectFor, func() {
+			stopped.Store(true)
+			cancel()
+		})
+	}
+
+	var recvErr error
+	for {
+		if opts.MaxMessages > 0 && len(response.Messages) >= opts.MaxMessages {
+			stopped.Store(true)
+			cancel()
+			break
+		}
+
+		msg := new(TResp)
+		if err := stream.RecvMsg(msg); err != nil {
+			if !errors.Is(err, io.EOF) && !stopped.Load() {
+				recvErr = err
+			}
+			break
+		}
+		recorder.add(FrameReceived, msg)
+		response.Messages = append(response.Messages, msg)
+
+		if opts.Kind == ClientStreaming {
+			break
+		}
+	}
+
+	if window != nil {
+		window.Stop()
+	}
+	if stopped.Load() {
+		cancel()
+	}
+	if sendErr := <-sendDone; recvErr == nil && !stopped.Load() && sendErr != nil && !errors.Is(sendErr, io.EOF) {
+		recvErr = sendErr
+	}
+
+	header, _ := stream.Header()
+	response.Metadata = metadata.Join(header, stream.Trailer())
+	response.Frames = recorder.frames
+	response.Error = recvErr
+	response.StoppedByClient = stopped.Load()
+	response.Duration = time.Since(start)
+
+	return response, recvErr
+}
+
+func sendAll[TReq any](stream grpc.ClientStream, requests []*TReq, recorder *frameRecorder) error {
+	for _, req := range requests {
+		// Record before sending so a fast reply is never listed ahead of its request.
+		recorder.add(FrameSent, req)
+		if err := stream.SendMsg(req); err != nil {
+			return err
+		}
+	}
+	return stream.CloseSend()
+}
+
+// frameRecorder collects frames from the sending and receiving goroutines.
+type frameRecorder struct {
+	mu     sync.Mutex
+	start  time.Time
+	frames []StreamFrame
+}
+
+func (r *frameRecorder) add(direction FrameDirection, body any) {
+	raw, _ := json.Marshal(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.frames = append(r.frames, StreamFrame{
+		Direction: direction,
+		Offset:    time.Since(r.start),
+		Body:      body,
+		JSON:      raw,
+	})
+}
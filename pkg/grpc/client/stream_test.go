@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newStreamServer starts a gRPC server with streaming test methods on a local port.
+func newStreamServer(t *testing.T) *Client {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Stream",
+		HandlerType: (*any)(nil),
+		Streams: []grpc.StreamDesc{
+			{StreamName: "Count", ServerStreams: true, Handler: countHandler},
+			{StreamName: "Ticks", ServerStreams: true, Handler: ticksHandler},
+			{StreamName: "Join", ClientStreams: true, Handler: joinHandler},
+			{StreamName: "Echo", ServerStreams: true, ClientStreams: true, Handler: echoHandler},
+		},
+	}, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	c, err := New(Config{Target: lis.Addr().String(), Insecure: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// countHandler replies with "<value>-1", "<value>-2", "<value>-3".
+func countHandler(_ any, stream grpc.ServerStream) error {
+	req := new(wrapperspb.StringValue)
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+	_ = stream.SetHeader(metadata.Pairs("x-stream", "count"))
+	for _, n := range []string{"1", "2", "3"} {
+		if err := stream.SendMsg(wrapperspb.String(req.GetValue() + "-" + n)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ticksHandler sends a message every 10ms until the client goes away.
+func ticksHandler(_ any, stream grpc.ServerStream) error {
+	if err := stream.RecvMsg(new(wrapperspb.StringValue)); err != nil {
+		return err
+	}
+	for {
+		if err := stream.SendMsg(wrapperspb.String("tick")); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// joinHandler replies once with all received values joined by commas.
+func joinHandler(_ any, stream grpc.ServerStream) error {
+	var values []string
+	for {
+		req := new(wrapperspb.StringValue)
+		err := stream.RecvMsg(req)
+		if errors.Is(err, io.EOF) {
+			return stream.SendMsg(wrapperspb.String(strings.Join(values, ",")))
+		}
+		if err != nil {
+			return err
+		}
+		values = append(values, req.GetValue())
+	}
+}
+
+// echoHandler answers each message and fails the stream on "fail".
+func echoHandler(_ any, stream grpc.ServerStream) error {
+	for {
+		req := new(wrapperspb.StringValue)
+		err := stream.RecvMsg(req)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetValue() == "fail" {
+			return status.Error(codes.InvalidArgument, "fail requested")
+		}
+		if err := stream.SendMsg(wrapperspb.String("echo: " + req.GetValue())); err != nil {
+			return err
+		}
+	}
+}
+
+func values(messages []*wrapperspb.StringValue) []string {
+	result := make([]string, len(messages))
+	for i, msg := range messages {
+		result[i] = msg.GetValue()
+	}
+	return result
+}
+
+func TestStream_ServerStreaming(t *testing.T) {
+	c := newStreamServer(t)
+
+	resp, err := Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Stream/Count",
+		[]*wrapperspb.StringValue{wrapperspb.String("n")}, StreamOptions{Kind: ServerStreaming, Timeout: 5 * time.Second})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"n-1", "n-2", "n-3"}, values(resp.Messages))
+	assert.False(t, resp.StoppedByClient)
+	assert.Equal(t, []string{"count"}, resp.Metadata.Get("x-stream"))
+
+	require.Len(t, resp.Frames, 4)
+	assert.Equal(t, FrameSent, resp.Frames[0].Direction)
+	assert.JSONEq(t, `{"value":"n"}`, string(resp.Frames[0].JSON))
+	assert.Equal(t, FrameReceived, resp.Frames[3].Direction)
+	assert.JSONEq(t, `{"value":"n-3"}`, string(resp.Frames[3].JSON))
+	assert.Len(t, resp.Received(), 3)
+}
+
+func TestStream_ClientStreaming(t *testing.T) {
+	c := newStreamServer(t)
+
+	requests := []*wrapperspb.StringValue{wrapperspb.String("a"), wrapperspb.String("b"), wrapperspb.String("c")}
+	resp, err := Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Stream/Join",
+		requests, StreamOptions{Kind: ClientStreaming, Timeout: 5 * time.Second})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a,b,c"}, values(resp.Messages))
+	assert.Len(t, resp.Frames, 4)
+}
+
+func TestStream_BidiStatusError(t *testing.T) {
+	c := newStreamServer(t)
+
+	requests := []*wrapperspb.StringValue{wrapperspb.String("hi"), wrapperspb.String("fail")}
+	resp, err := Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Stream/Echo",
+		requests, StreamOptions{Timeout: 5 * time.Second})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(resp.Error))
+	assert.Equal(t, []string{"echo: hi"}, values(resp.Messages))
+}
+
+func TestStream_StopsAfterMaxMessages(t *testing.T) {
+	c := newStreamServer(t)
+
+	resp, err := Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Stream/Ticks",
+		[]*wrapperspb.StringValue{wrapperspb.String("go")}, StreamOptions{Kind: ServerStreaming, MaxMessages: 2, Timeout: 5 * time.Second})
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Messages, 2)
+	assert.True(t, resp.StoppedByClient)
+}
+
+func TestStream_CollectForWindow(t *testing.T) {
+	c := newStreamServer(t)
+
+	resp, err := Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Stream/Ticks",
+		[]*wrapperspb.StringValue{wrapperspb.String("go")}, StreamOptions{Kind: ServerStreaming, CollectFor: 100 * time.Millisecond, Timeout: 5 * time.Second})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Messages)
+	assert.True(t, resp.StoppedByClient)
+}
+
+func TestStream_TimeoutFailsWithDeadlineExceeded(t *testing.T) {
+	c := newStreamServer(t)
+
+	resp, err := Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Stream/Ticks",
+		[]*wrapperspb.StringValue{wrapperspb.String("go")}, StreamOptions{Kind: ServerStreaming, Timeout: 100 * time.Millisecond})
+
+	require.Error(t, err)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(resp.Error))
+	assert.False(t, resp.StoppedByClient)
+	assert.NotEmpty(t, resp.Messages)
+}
+
+func TestStreamResponse_ToAny(t *testing.T) {
+	var nilResp *StreamResponse[wrapperspb.StringValue]
+	assert.Nil(t, nilResp.ToAny())
+	assert.Nil(t, nilResp.GetError())
+
+	resp := &StreamResponse[wrapperspb.StringValue]{
+		Messages: []*wrapperspb.StringValue{wrapperspb.String("a")},
+		Frames:   []StreamFrame{{Direction: FrameReceived, JSON: []byte(`{"value":"a"}`)}},
+		Error:    status.Error(codes.Aborted, "aborted"),
+	}
+	anyResp := resp.ToAny()
+	require.Len(t, anyResp.Messages, 1)
+	assert.Equal(t, resp.Error, anyResp.GetError())
+	assert.Equal(t, [][]byte{[]byte(`{"value":"a"}`)}, anyResp.Received())
+}
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"encoding/json"
+
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 
 	"github.com/gorelov-m-v/go-test-framework/internal/allure"
@@ -24,3 +26,41 @@ func attachGRPCReport[TReq, TResp any](
 
 	grpcReporter.AttachGRPCReport(stepCtx, report)
 }
+
+func attachGRPCStreamReport[TReq, TResp any](
+	stepCtx provider.StepCtx,
+	s *Stream[TReq, TResp],
+	resp *client.StreamResponse[TResp],
+	pollingSummary polling.PollingSummary,
+) {
+	report := allure.GRPCStreamReportDTO{
+		Request: allure.GRPCStreamRequestDTO{
+			Target:     s.client.Target(),
+			Method:     s.fullMethod,
+			Kind:       s.kind,
+			Metadata:   s.metadata,
+			Take:       s.take,
+			CollectFor: s.collectFor,
+			Timeout:    s.timeout,
+		},
+		Result:  allure.ToGRPCStreamResultDTO(resp),
+		Polling: allure.ToPollingSummaryDTO(pollingSummary),
+	}
+
+	grpcReporter.AttachGRPCStreamReport(stepCtx, report)
+}
+
+func attachDynamicGRPCReport(
+	stepCtx provider.StepCtx,
+	c *DynamicCall,
+	resp *client.Response[json.RawMessage],
+	pollingSummary polling.PollingSummary,
+) {
+	report := allure.GRPCReportDTO{
+		Request:  allure.ToGRPCRequestDTO(c.client.Target(), c.fullMethod, &c.body, c.metadata),
+		Response: allure.ToGRPCResponseDTO(resp),
+		Polling:  allure.ToPollingSummaryDTO(pollingSummary),
+	}
+
+	grpcReporter.AttachGRPCReport(stepCtx, report)
+}
@@ -0,0 +1,198 @@
+package dsl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/jsonutil"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+// DynamicCall represents a unary gRPC call without generated Go types.
+// The method's message descriptors come from server reflection or the client's
+// descriptorSets; the request is given as JSON and the response is returned as JSON
+// (proto field names, zero values included), so the usual field expectations apply.
+//
+// The response follows the proto JSON mapping (protojson), not the encoding/json
+// output that Call compares against: int64/uint64 fields are strings, enums are
+// value names and Timestamp/Duration are strings. Assert them accordingly, e.g.
+// ExpectFieldEquals("id", "42") and ExpectFieldEquals("status", "ACTIVE").
+//
+// Example:
+//
+//	dsl.NewDynamicCall(sCtx, grpcClient).
+//	    Method("user.UserService/GetUser").
+//	    JSONBody(`{"id": "123"}`).
+//	    ExpectNoError().
+//	    ExpectFieldEquals("name", "John").
+//	    Send()
+type DynamicCall struct {
+	stepCtx provider.StepCtx
+	client  *client.Client
+	ctx     context.Context
+
+	fullMethod string
+	body       json.RawMessage
+	bodyErr    error
+	metadata   metadata.MD
+
+	resp *client.Response[json.RawMessage]
+	sent bool
+
+	expectations []*expect.Expectation[*client.Response[any]]
+}
+
+// NewDynamicCall creates a new dynamic gRPC request builder.
+func NewDynamicCall(stepCtx provider.StepCtx, grpcClient *client.Client) *DynamicCall {
+	return &DynamicCall{
+		stepCtx:  stepCtx,
+		client:   grpcClient,
+		ctx:      context.Background(),
+		metadata: metadata.MD{},
+	}
+}
+
+// Method sets the gRPC method as "package.Service/Method" (a leading slash is optional).
+func (c *DynamicCall) Method(fullMethod string) *DynamicCall {
+	c.fullMethod = fullMethod
+	return c
+}
+
+// JSONBody sets the request message as JSON: a string, []byte, json.RawMessage,
+// or any value that is marshaled with encoding/json.
+func (c *DynamicCall) JSONBody(body any) *DynamicCall {
+	switch b := body.(type) {
+	case string:
+		c.body = json.RawMessage(b)
+	case []byte:
+		c.body = json.RawMessage(b)
+	case json.RawMessage:
+		c.body = b
+	default:
+		c.body, c.bodyErr = json.Marshal(body)
+	}
+	return c
+}
+
+// Metadata adds a metadata key-value pair to the gRPC call.
+func (c *DynamicCall) Metadata(key, value string) *DynamicCall {
+	c.metadata.Append(key, value)
+	return c
+}
+
+func (c *DynamicCall) ExpectNoError() *DynamicCall {
+	c.addExpectation(makeNoErrorExpectation())
+	return c
+}
+
+func (c *DynamicCall) ExpectError() *DynamicCall {
+	c.addExpectation(makeErrorExpectation())
+	return c
+}
+
+func (c *DynamicCall) ExpectStatusCode(code codes.Code) *DynamicCall {
+	c.addExpectation(makeStatusCodeExpectation(code))
+	return c
+}
+
+func (c *DynamicCall) ExpectStatusMessage(message string) *DynamicCall {
+	c.addExpectation(makeStatusMessageExpectation(message))
+	return c
+}
+
+func (c *DynamicCall) ExpectErrorInfoReason(reason string) *DynamicCall {
+	c.addExpectation(makeErrorInfoReasonExpectation(reason))
+	return c
+}
+
+func (c *DynamicCall) ExpectFieldViolation(field, description string) *DynamicCall {
+	c.addExpectation(makeFieldViolationExpectation(field, description))
+	return c
+}
+
+func (c *DynamicCall) ExpectFieldEquals(path string, expected any) *DynamicCall {
+	c.addExpectation(jsonSource.FieldEquals(path, expected))
+	return c
+}
+
+func (c *DynamicCall) ExpectFieldNotEmpty(path string) *DynamicCall {
+	c.addExpectation(jsonSource.FieldNotEmpty(path))
+	return c
+}
+
+func (c *DynamicCall) ExpectFieldExists(path string) *DynamicCall {
+	c.addExpectation(jsonSource.FieldExists(path))
+	return c
+}
+
+func (c *DynamicCall) ExpectMetadata(key, value string) *DynamicCall {
+	c.addExpectation(makeMetadataExpectation(key, value))
+	return c
+}
+
+func (c *DynamicCall) addExpectation(exp *expect.Expectation[*client.Response[any]]) {
+	expect.AddExpectation(c.stepCtx, c.sent, &c.expectations, exp, "gRPC")
+}
+
+// Send resolves the method, executes the call and validates all expectations.
+// In async mode (AsyncStep), automatically retries with backoff until expectations pass.
+// Returns the response with the JSON body, metadata, and any error.
+func (c *DynamicCall) Send() *client.Response[json.RawMessage] {
+	c.validate()
+
+	c.stepCtx.WithNewStep(c.stepName(), func(stepCtx provider.StepCtx) {
+		resp, err, summary := c.execute(stepCtx)
+		c.resp = resp
+		c.sent = true
+
+		attachDynamicGRPCReport(stepCtx, c, c.resp, summary)
+		expect.AssertExpectations(stepCtx, c.expectations, err, c.resp.ToAny(), c.assertNoExpectations)
+	})
+
+	return c.resp
+}
+
+func (c *DynamicCall) stepName() string {
+	return fmt.Sprintf("gRPC %s", c.fullMethod)
+}
+
+func (c *DynamicCall) execute(stepCtx provider.StepCtx) (*client.Response[json.RawMessage], error, polling.PollingSummary) {
+	return retry.ExecuteDSL(retry.DSLConfig[*client.Response[json.RawMessage], *client.Response[any]]{
+		Ctx:          c.ctx,
+		StepCtx:      stepCtx,
+		AsyncConfig:  c.client.AsyncConfig,
+		Expectations: c.expectations,
+		Executor: func(ctx context.Context) (*client.Response[json.RawMessage], error) {
+			return client.InvokeDynamic(ctx, c.client, c.fullMethod, c.body, c.metadata)
+		},
+		Convert:          func(resp *client.Response[json.RawMessage]) *client.Response[any] { return resp.ToAny() },
+		PostProcess:      postProcessGRPC[json.RawMessage],
+		NilResultFactory: newGRPCErrorResponse[json.RawMessage],
+	})
+}
+
+func (c *DynamicCall) assertNoExpectations(stepCtx provider.StepCtx, mode polling.AssertionMode, err error) {
+	if err != nil {
+		polling.NoError(stepCtx, mode, err, "gRPC call failed: %v", err)
+	}
+}
+
+func (c *DynamicCall) validate() {
+	v := validation.New(c.stepCtx, "gRPC")
+	v.RequireNotNil(c.client, "gRPC client")
+	v.RequireNotEmptyWithHint(c.fullMethod, "gRPC method", "Use .Method(\"package.Service/Method\").")
+	if c.bodyErr != nil {
+		v.Require(false, fmt.Sprintf("JSONBody could not be marshaled: %v", c.bodyErr))
+	} else if len(c.body) > 0 {
+		v.Require(jsonutil.ValidateBytes(c.body) == nil, "JSONBody is not valid JSON")
+	}
+}
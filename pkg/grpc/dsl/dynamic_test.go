@@ -0,0 +1,44 @@
+package dsl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+func TestDynamicCall_JSONBody(t *testing.T) {
+	assert.Equal(t, json.RawMessage(`{"id":"1"}`), NewDynamicCall(nil, nil).JSONBody(`{"id":"1"}`).body)
+	assert.Equal(t, json.RawMessage(`{"id":"2"}`), NewDynamicCall(nil, nil).JSONBody([]byte(`{"id":"2"}`)).body)
+	assert.Equal(t, json.RawMessage(`{"id":"3"}`), NewDynamicCall(nil, nil).JSONBody(map[string]string{"id": "3"}).body)
+
+	call := NewDynamicCall(nil, nil).JSONBody(func() {})
+	assert.Error(t, call.bodyErr)
+}
+
+func TestDynamicResponse_FieldExpectations(t *testing.T) {
+	body := json.RawMessage(`{"status":"SERVING","count":0}`)
+	resp := (&client.Response[json.RawMessage]{Body: &body, RawBody: body}).ToAny()
+
+	raw, err := getResponseJSON(resp)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(body), string(raw))
+
+	assert.True(t, jsonSource.FieldEquals("status", "SERVING").Check(nil, resp).Ok)
+	assert.True(t, jsonSource.FieldEquals("count", 0).Check(nil, resp).Ok)
+	assert.False(t, jsonSource.FieldEquals("status", "NOT_SERVING").Check(nil, resp).Ok)
+}
+
+func TestDynamicResponse_ProtoJSONFields(t *testing.T) {
+	// int64 and enum fields as InvokeDynamic renders them (see client.TestInvokeDynamic_ProtoJSONMapping).
+	body := json.RawMessage(`{"id":"9007199254740993","status":"ACTIVE","created_at":"2024-01-02T03:04:05Z"}`)
+	resp := (&client.Response[json.RawMessage]{Body: &body, RawBody: body}).ToAny()
+
+	assert.True(t, jsonSource.FieldEquals("id", "9007199254740993").Check(nil, resp).Ok)
+	assert.True(t, jsonSource.FieldEquals("status", "ACTIVE").Check(nil, resp).Ok)
+	assert.True(t, jsonSource.FieldEquals("created_at", "2024-01-02T03:04:05Z").Check(nil, resp).Ok)
+	assert.False(t, jsonSource.FieldEquals("status", 1).Check(nil, resp).Ok)
+}
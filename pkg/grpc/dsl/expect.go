@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -37,6 +38,25 @@ func (c *Call[TReq, TResp]) ExpectStatusCode(code codes.Code) *Call[TReq, TResp]
 	return c
 }
 
+// ExpectStatusMessage expects the call to fail with exactly this status message.
+func (c *Call[TReq, TResp]) ExpectStatusMessage(message string) *Call[TReq, TResp] {
+	c.addExpectation(makeStatusMessageExpectation(message))
+	return c
+}
+
+// ExpectErrorInfoReason expects an ErrorInfo status detail with the given reason.
+func (c *Call[TReq, TResp]) ExpectErrorInfoReason(reason string) *Call[TReq, TResp] {
+	c.addExpectation(makeErrorInfoReasonExpectation(reason))
+	return c
+}
+
+// ExpectFieldViolation expects a BadRequest status detail with a violation for field.
+// An empty description matches any description.
+func (c *Call[TReq, TResp]) ExpectFieldViolation(field, description string) *Call[TReq, TResp] {
+	c.addExpectation(makeFieldViolationExpectation(field, description))
+	return c
+}
+
 func (c *Call[TReq, TResp]) ExpectFieldEquals(path string, expected any) *Call[TReq, TResp] {
 	c.addExpectation(jsonSource.FieldEquals(path, expected))
 	return c
@@ -106,23 +126,11 @@ func makeStatusCodeExpectation(code codes.Code) *expect.Expectation[*client.Resp
 	return expect.New(
 		name,
 		func(err error, resp *client.Response[any]) polling.CheckResult {
-			var actualCode codes.Code
+			actualCode := codes.OK
 			if err != nil {
-				st, ok := status.FromError(err)
-				if ok {
-					actualCode = st.Code()
-				} else {
-					actualCode = codes.Unknown
-				}
+				actualCode = statusCode(err)
 			} else if resp != nil && resp.Error != nil {
-				st, ok := status.FromError(resp.Error)
-				if ok {
-					actualCode = st.Code()
-				} else {
-					actualCode = codes.Unknown
-				}
-			} else {
-				actualCode = codes.OK
+				actualCode = statusCode(resp.Error)
 			}
 
 			if actualCode != code {
@@ -138,6 +146,140 @@ func makeStatusCodeExpectation(code codes.Code) *expect.Expectation[*client.Resp
 	)
 }
 
+// callStatus returns the gRPC status of a failed call, or false if the call succeeded.
+func callStatus(err error, resp *client.Response[any]) (*status.Status, bool) {
+	if err == nil && resp != nil {
+		err = resp.Error
+	}
+	if err == nil {
+		return nil, false
+	}
+	st, _ := status.FromError(err)
+	return st, true
+}
+
+func makeStatusMessageExpectation(message string) *expect.Expectation[*client.Response[any]] {
+	name := fmt.Sprintf("Expect: Status message '%s'", message)
+	return expect.New(
+		name,
+		func(err error, resp *client.Response[any]) polling.CheckResult {
+			st, failed := callStatus(err, resp)
+			if !failed {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected status message '%s', but call succeeded", message),
+				}
+			}
+			if st.Message() != message {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Status message = '%s', expected '%s'", st.Message(), message),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Response[any]](name),
+	)
+}
+
+func makeErrorInfoReasonExpectation(reason string) *expect.Expectation[*client.Response[any]] {
+	name := fmt.Sprintf("Expect: ErrorInfo reason '%s'", reason)
+	return expect.New(
+		name,
+		func(err error, resp *client.Response[any]) polling.CheckResult {
+			st, failed := callStatus(err, resp)
+			if !failed {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected ErrorInfo reason '%s', but call succeeded", reason),
+				}
+			}
+			var reasons []string
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					if info.GetReason() == reason {
+						return polling.CheckResult{Ok: true}
+					}
+					reasons = append(reasons, info.GetReason())
+				}
+			}
+			if len(reasons) == 0 {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Status %s has no ErrorInfo detail", st.Code()),
+				}
+			}
+			return polling.CheckResult{
+				Ok:        false,
+				Retryable: true,
+				Reason:    fmt.Sprintf("ErrorInfo reason = %v, expected '%s'", reasons, reason),
+			}
+		},
+		expect.StandardReport[*client.Response[any]](name),
+	)
+}
+
+func makeFieldViolationExpectation(field, description string) *expect.Expectation[*client.Response[any]] {
+	name := fmt.Sprintf("Expect: Field violation '%s'", field)
+	if description != "" {
+		name = fmt.Sprintf("Expect: Field violation '%s': '%s'", field, description)
+	}
+	return expect.New(
+		name,
+		func(err error, resp *client.Response[any]) polling.CheckResult {
+			st, failed := callStatus(err, resp)
+			if !failed {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected field violation '%s', but call succeeded", field),
+				}
+			}
+			var violations []string
+			for _, detail := range st.Details() {
+				badRequest, ok := detail.(*errdetails.BadRequest)
+				if !ok {
+					continue
+				}
+				for _, v := range badRequest.GetFieldViolations() {
+					if v.GetField() == field && (description == "" || v.GetDescription() == description) {
+						return polling.CheckResult{Ok: true}
+					}
+					violations = append(violations, fmt.Sprintf("%s: %s", v.GetField(), v.GetDescription()))
+				}
+			}
+			if violations == nil {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Status %s has no BadRequest field violations", st.Code()),
+				}
+			}
+			return polling.CheckResult{
+				Ok:        false,
+				Retryable: true,
+				Reason:    fmt.Sprintf("No matching field violation, got %v", violations),
+			}
+		},
+		expect.StandardReport[*client.Response[any]](name),
+	)
+}
+
+// statusCode extracts the gRPC status code from err, Unknown for non-status errors.
+func statusCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code()
+	}
+	return codes.Unknown
+}
+
 func getResponseJSON(resp *client.Response[any]) ([]byte, error) {
 	if resp == nil || resp.Body == nil {
 		return nil, fmt.Errorf("response body is nil")
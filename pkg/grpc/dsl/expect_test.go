@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -436,3 +437,63 @@ func TestFieldEquals_FloatValue(t *testing.T) {
 
 	assert.True(t, result.Ok, "Reason: %s", result.Reason)
 }
+
+func richStatusError(t *testing.T) error {
+	t.Helper()
+	st, err := status.New(codes.InvalidArgument, "validation failed").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "email", Description: "must be a valid email"},
+			{Field: "age", Description: "must be positive"},
+		}},
+		&errdetails.ErrorInfo{Reason: "INVALID_PLAYER", Domain: "players.example.com"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st.Err()
+}
+
+func TestStatusMessageExpectation(t *testing.T) {
+	err := richStatusError(t)
+	resp := &client.Response[any]{Error: err}
+
+	assert.True(t, makeStatusMessageExpectation("validation failed").Check(err, resp).Ok)
+
+	result := makeStatusMessageExpectation("other").Check(err, resp)
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "Status message = 'validation failed'")
+
+	result = makeStatusMessageExpectation("validation failed").Check(nil, &client.Response[any]{})
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "call succeeded")
+}
+
+func TestErrorInfoReasonExpectation(t *testing.T) {
+	err := richStatusError(t)
+	resp := &client.Response[any]{Error: err}
+
+	assert.True(t, makeErrorInfoReasonExpectation("INVALID_PLAYER").Check(nil, resp).Ok)
+
+	result := makeErrorInfoReasonExpectation("BLOCKED").Check(nil, resp)
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "[INVALID_PLAYER]")
+
+	result = makeErrorInfoReasonExpectation("BLOCKED").Check(status.Error(codes.NotFound, "no player"), nil)
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "has no ErrorInfo detail")
+}
+
+func TestFieldViolationExpectation(t *testing.T) {
+	err := richStatusError(t)
+
+	assert.True(t, makeFieldViolationExpectation("email", "must be a valid email").Check(err, nil).Ok)
+	assert.True(t, makeFieldViolationExpectation("age", "").Check(err, nil).Ok)
+
+	result := makeFieldViolationExpectation("email", "is required").Check(err, nil)
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "email: must be a valid email")
+
+	result = makeFieldViolationExpectation("email", "").Check(status.Error(codes.InvalidArgument, "bad"), nil)
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "no BadRequest field violations")
+}
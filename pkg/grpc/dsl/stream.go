@@ -0,0 +1,220 @@
+package dsl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/constants"
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+// DefaultStreamTimeout bounds a stream when no Timeout is set.
+const DefaultStreamTimeout = 30 * time.Second
+
+// Stream represents a streaming gRPC call builder with fluent interface.
+// It sends the configured messages in order, collects server messages until the
+// stream closes (or Take / CollectFor stop it) and checks expectations on the result.
+// Every sent and received frame is recorded in the Allure report.
+//
+// Type parameters:
+//   - TReq: Request protobuf message type
+//   - TResp: Response protobuf message type
+//
+// Example:
+//
+//	dsl.NewStream[pb.SubscribeRequest, pb.Event](sCtx, grpcClient).
+//	    Method("/events.EventService/Subscribe").
+//	    ServerStreaming().
+//	    Message(pb.SubscribeRequest{Topic: "players"}).
+//	    Take(3).
+//	    ExpectMessageCount(3).
+//	    ExpectAnyMessage("type", "PLAYER_CREATED").
+//	    Send()
+type Stream[TReq any, TResp any] struct {
+	stepCtx provider.StepCtx
+	client  *client.Client
+	ctx     context.Context
+
+	fullMethod string
+	kind       client.StreamKind
+	messages   []*TReq
+	metadata   metadata.MD
+	take       int
+	collectFor time.Duration
+	timeout    time.Duration
+
+	resp *client.StreamResponse[TResp]
+	sent bool
+
+	expectations []*expect.Expectation[*client.StreamResponse[any]]
+}
+
+// NewStream creates a new streaming gRPC call builder. The stream is bidirectional
+// unless ServerStreaming or ClientStreaming is set.
+func NewStream[TReq any, TResp any](stepCtx provider.StepCtx, grpcClient *client.Client) *Stream[TReq, TResp] {
+	return &Stream[TReq, TResp]{
+		stepCtx:  stepCtx,
+		client:   grpcClient,
+		ctx:      context.Background(),
+		kind:     client.BidiStreaming,
+		metadata: metadata.MD{},
+		timeout:  DefaultStreamTimeout,
+	}
+}
+
+// Method sets the full gRPC method path in format "/package.Service/Method".
+func (s *Stream[TReq, TResp]) Method(fullMethod string) *Stream[TReq, TResp] {
+	s.fullMethod = fullMethod
+	return s
+}
+
+// ServerStreaming marks the method as server-streaming: one request, many responses.
+func (s *Stream[TReq, TResp]) ServerStreaming() *Stream[TReq, TResp] {
+	s.kind = client.ServerStreaming
+	return s
+}
+
+// ClientStreaming marks the method as client-streaming: many requests, one response.
+func (s *Stream[TReq, TResp]) ClientStreaming() *Stream[TReq, TResp] {
+	s.kind = client.ClientStreaming
+	return s
+}
+
+// Bidi marks the method as bidirectional streaming (the default).
+func (s *Stream[TReq, TResp]) Bidi() *Stream[TReq, TResp] {
+	s.kind = client.BidiStreaming
+	return s
+}
+
+// Message appends a request message to the sequence sent on the stream.
+func (s *Stream[TReq, TResp]) Message(msg TReq) *Stream[TReq, TResp] {
+	s.messages = append(s.messages, &msg)
+	return s
+}
+
+// Messages appends several request messages to the sequence sent on the stream.
+func (s *Stream[TReq, TResp]) Messages(msgs ...TReq) *Stream[TReq, TResp] {
+	for i := range msgs {
+		s.messages = append(s.messages, &msgs[i])
+	}
+	return s
+}
+
+// Metadata adds a metadata key-value pair to the stream.
+func (s *Stream[TReq, TResp]) Metadata(key, value string) *Stream[TReq, TResp] {
+	s.metadata.Append(key, value)
+	return s
+}
+
+// Take stops receiving after n messages and closes the stream from the client side.
+func (s *Stream[TReq, TResp]) Take(n int) *Stream[TReq, TResp] {
+	s.take = n
+	return s
+}
+
+// CollectFor receives messages for the given window, then closes the stream from the
+// client side. Use it for subscriptions the server never closes.
+func (s *Stream[TReq, TResp]) CollectFor(d time.Duration) *Stream[TReq, TResp] {
+	s.collectFor = d
+	return s
+}
+
+// Timeout bounds the whole stream (default DefaultStreamTimeout).
+// A stream still open at the deadline fails with DeadlineExceeded.
+func (s *Stream[TReq, TResp]) Timeout(d time.Duration) *Stream[TReq, TResp] {
+	s.timeout = d
+	return s
+}
+
+func (s *Stream[TReq, TResp]) addExpectation(exp *expect.Expectation[*client.StreamResponse[any]]) {
+	expect.AddExpectation(s.stepCtx, s.sent, &s.expectations, exp, "gRPC stream")
+}
+
+// Send opens the stream, exchanges messages and validates all expectations.
+// In async mode (AsyncStep), the whole stream is repeated with backoff until expectations pass.
+// Returns the received messages, every frame, metadata and the final status.
+func (s *Stream[TReq, TResp]) Send() *client.StreamResponse[TResp] {
+	s.validate()
+
+	s.stepCtx.WithNewStep(s.stepName(), func(stepCtx provider.StepCtx) {
+		resp, err, summary := s.execute(stepCtx, s.expectations)
+		s.resp = resp
+		s.sent = true
+
+		attachGRPCStreamReport(stepCtx, s, s.resp, summary)
+		s.assertResults(stepCtx, err)
+	})
+
+	return s.resp
+}
+
+func (s *Stream[TReq, TResp]) stepName() string {
+	return fmt.Sprintf("gRPC stream %s", s.fullMethod)
+}
+
+func (s *Stream[TReq, TResp]) execute(
+	stepCtx provider.StepCtx,
+	expectations []*expect.Expectation[*client.StreamResponse[any]],
+) (*client.StreamResponse[TResp], error, polling.PollingSummary) {
+	return retry.ExecuteDSL(retry.DSLConfig[*client.StreamResponse[TResp], *client.StreamResponse[any]]{
+		Ctx:          s.ctx,
+		StepCtx:      stepCtx,
+		AsyncConfig:  s.client.AsyncConfig,
+		Expectations: expectations,
+		Executor:     s.doStream,
+		Convert:      func(resp *client.StreamResponse[TResp]) *client.StreamResponse[any] { return resp.ToAny() },
+		PostProcess: func(resp *client.StreamResponse[TResp], err error, summary *polling.PollingSummary) {
+			retry.PostProcessSummary(resp, err, summary)
+		},
+		NilResultFactory: newGRPCStreamErrorResponse[TResp],
+	})
+}
+
+func (s *Stream[TReq, TResp]) doStream(ctx context.Context) (*client.StreamResponse[TResp], error) {
+	return client.Stream[TReq, TResp](ctx, s.client, s.fullMethod, s.messages, client.StreamOptions{
+		Kind:        s.kind,
+		Metadata:    s.metadata,
+		MaxMessages: s.take,
+		CollectFor:  s.collectFor,
+		Timeout:     s.timeout,
+	})
+}
+
+func newGRPCStreamErrorResponse[TResp any](err error) *client.StreamResponse[TResp] {
+	errMsg := errors.New(constants.ErrNilResponse)
+	if err != nil {
+		errMsg = err
+	}
+	return &client.StreamResponse[TResp]{Error: errMsg}
+}
+
+func (s *Stream[TReq, TResp]) assertResults(stepCtx provider.StepCtx, err error) {
+	expect.AssertExpectations(stepCtx, s.expectations, err, s.resp.ToAny(), s.assertNoExpectations)
+}
+
+func (s *Stream[TReq, TResp]) assertNoExpectations(stepCtx provider.StepCtx, mode polling.AssertionMode, err error) {
+	if err != nil {
+		polling.NoError(stepCtx, mode, err, "gRPC stream failed: %v", err)
+	}
+}
+
+func (s *Stream[TReq, TResp]) validate() {
+	v := validation.New(s.stepCtx, "gRPC stream")
+	v.RequireNotNil(s.client, "gRPC client")
+	v.RequireNotEmptyWithHint(s.fullMethod, "gRPC method", "Use .Method(\"/package.Service/Method\").")
+	if s.kind == client.ServerStreaming {
+		v.Require(len(s.messages) == 1, "Server-streaming call requires exactly one request message. Use .Message(req).")
+	}
+	v.Require(s.take >= 0, "Take(n) must not be negative")
+	v.Require(s.collectFor >= 0, "CollectFor(d) must not be negative")
+	v.Require(s.timeout > 0, "Timeout(d) must be positive")
+}
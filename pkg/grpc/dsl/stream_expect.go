@@ -0,0 +1,168 @@
+package dsl
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/jsonutil"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+// ExpectNoError expects the stream to close with status OK (or to be stopped by Take / CollectFor).
+func (s *Stream[TReq, TResp]) ExpectNoError() *Stream[TReq, TResp] {
+	s.addExpectation(makeStreamStatusExpectation(codes.OK, "Expect: No error"))
+	return s
+}
+
+// ExpectStatusCode expects the stream to close with the given status code.
+func (s *Stream[TReq, TResp]) ExpectStatusCode(code codes.Code) *Stream[TReq, TResp] {
+	s.addExpectation(makeStreamStatusExpectation(code, fmt.Sprintf("Expect: Status code %s (%d)", code.String(), code)))
+	return s
+}
+
+// ExpectMessageCount expects exactly count messages received from the server.
+func (s *Stream[TReq, TResp]) ExpectMessageCount(count int) *Stream[TReq, TResp] {
+	s.addExpectation(makeStreamMessageCountExpectation(count))
+	return s
+}
+
+// ExpectAnyMessage expects at least one received message with the field at path equal to expected.
+func (s *Stream[TReq, TResp]) ExpectAnyMessage(path string, expected any) *Stream[TReq, TResp] {
+	s.addExpectation(makeStreamAnyMessageExpectation(path, expected))
+	return s
+}
+
+// ExpectEveryMessage expects every received message to have the field at path equal to expected.
+func (s *Stream[TReq, TResp]) ExpectEveryMessage(path string, expected any) *Stream[TReq, TResp] {
+	s.addExpectation(makeStreamEveryMessageExpectation(path, expected))
+	return s
+}
+
+func streamPreCheck(resp *client.StreamResponse[any]) (polling.CheckResult, bool) {
+	if resp == nil {
+		return polling.CheckResult{Ok: false, Retryable: true, Reason: "Stream response is nil"}, false
+	}
+	return polling.CheckResult{}, true
+}
+
+// streamClosedReason appends the stream error to a failure reason, if the stream failed.
+func streamClosedReason(reason string, resp *client.StreamResponse[any]) string {
+	if resp.Error != nil {
+		return fmt.Sprintf("%s (stream closed with error: %v)", reason, resp.Error)
+	}
+	return reason
+}
+
+func makeStreamStatusExpectation(code codes.Code, name string) *expect.Expectation[*client.StreamResponse[any]] {
+	return expect.New(
+		name,
+		func(err error, resp *client.StreamResponse[any]) polling.CheckResult {
+			actualCode := codes.OK
+			if err != nil {
+				actualCode = statusCode(err)
+			} else if resp != nil && resp.Error != nil {
+				actualCode = statusCode(resp.Error)
+			}
+
+			if actualCode != code {
+				reason := fmt.Sprintf("Expected status %s (%d), got %s (%d)", code.String(), code, actualCode.String(), actualCode)
+				if resp != nil && resp.Error != nil {
+					reason = fmt.Sprintf("%s: %v", reason, resp.Error)
+				}
+				return polling.CheckResult{Ok: false, Retryable: true, Reason: reason}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.StreamResponse[any]](name),
+	)
+}
+
+func makeStreamMessageCountExpectation(count int) *expect.Expectation[*client.StreamResponse[any]] {
+	name := fmt.Sprintf("Expect: Message count = %d", count)
+	return expect.New(
+		name,
+		func(err error, resp *client.StreamResponse[any]) polling.CheckResult {
+			if res, ok := streamPreCheck(resp); !ok {
+				return res
+			}
+			if actual := len(resp.Messages); actual != count {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    streamClosedReason(fmt.Sprintf("Expected %d messages, received %d", count, actual), resp),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.StreamResponse[any]](name),
+	)
+}
+
+func makeStreamAnyMessageExpectation(path string, expected any) *expect.Expectation[*client.StreamResponse[any]] {
+	name := fmt.Sprintf("Expect: Any message '%s' == %v", path, expected)
+	return expect.New(
+		name,
+		func(err error, resp *client.StreamResponse[any]) polling.CheckResult {
+			if res, ok := streamPreCheck(resp); !ok {
+				return res
+			}
+			received := resp.Received()
+			for _, raw := range received {
+				if ok, _ := messageFieldEquals(raw, path, expected); ok {
+					return polling.CheckResult{Ok: true}
+				}
+			}
+			return polling.CheckResult{
+				Ok:        false,
+				Retryable: true,
+				Reason:    streamClosedReason(fmt.Sprintf("None of %d received messages has '%s' == %v", len(received), path, expected), resp),
+			}
+		},
+		expect.StandardReport[*client.StreamResponse[any]](name),
+	)
+}
+
+func makeStreamEveryMessageExpectation(path string, expected any) *expect.Expectation[*client.StreamResponse[any]] {
+	name := fmt.Sprintf("Expect: Every message '%s' == %v", path, expected)
+	return expect.New(
+		name,
+		func(err error, resp *client.StreamResponse[any]) polling.CheckResult {
+			if res, ok := streamPreCheck(resp); !ok {
+				return res
+			}
+			received := resp.Received()
+			if len(received) == 0 {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    streamClosedReason("No messages received", resp),
+				}
+			}
+			for i, raw := range received {
+				if ok, msg := messageFieldEquals(raw, path, expected); !ok {
+					return polling.CheckResult{
+						Ok:        false,
+						Retryable: true,
+						Reason:    fmt.Sprintf("Message #%d: %s", i+1, msg),
+					}
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.StreamResponse[any]](name),
+	)
+}
+
+func messageFieldEquals(raw []byte, path string, expected any) (bool, string) {
+	field, err := jsonutil.GetField(raw, path)
+	if err != nil {
+		return false, fmt.Sprintf("invalid JSON: %v", err)
+	}
+	if !field.Exists() {
+		return false, fmt.Sprintf("field '%s' not found", path)
+	}
+	return jsonutil.Compare(field, expected)
+}
@@ -0,0 +1,77 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+func streamResponse(err error, messages ...string) *client.StreamResponse[any] {
+	resp := &client.StreamResponse[any]{Error: err}
+	for _, msg := range messages {
+		var body any = msg
+		resp.Messages = append(resp.Messages, &body)
+		resp.Frames = append(resp.Frames,
+			client.StreamFrame{Direction: client.FrameSent, JSON: []byte(`{"value":"ping"}`)},
+			client.StreamFrame{Direction: client.FrameReceived, JSON: []byte(msg)},
+		)
+	}
+	return resp
+}
+
+func TestStreamStatusExpectation(t *testing.T) {
+	ok := streamResponse(nil, `{"value":"a"}`)
+	failed := streamResponse(status.Error(codes.NotFound, "no topic"))
+
+	exp := makeStreamStatusExpectation(codes.OK, "Expect: No error")
+	assert.True(t, exp.Check(nil, ok).Ok)
+	result := exp.Check(failed.Error, failed)
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "got NotFound (5)")
+	assert.Contains(t, result.Reason, "no topic")
+
+	assert.True(t, makeStreamStatusExpectation(codes.NotFound, "Expect: Status code NotFound (5)").Check(failed.Error, failed).Ok)
+}
+
+func TestStreamMessageCountExpectation(t *testing.T) {
+	exp := makeStreamMessageCountExpectation(2)
+
+	assert.True(t, exp.Check(nil, streamResponse(nil, `{"a":1}`, `{"a":2}`)).Ok)
+
+	result := exp.Check(nil, streamResponse(status.Error(codes.Unavailable, "gone"), `{"a":1}`))
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "Expected 2 messages, received 1")
+	assert.Contains(t, result.Reason, "stream closed with error")
+
+	assert.False(t, exp.Check(nil, nil).Ok)
+}
+
+func TestStreamAnyMessageExpectation(t *testing.T) {
+	resp := streamResponse(nil, `{"type":"CREATED","id":1}`, `{"type":"UPDATED","id":1}`)
+
+	assert.True(t, makeStreamAnyMessageExpectation("type", "UPDATED").Check(nil, resp).Ok)
+	assert.True(t, makeStreamAnyMessageExpectation("id", 1).Check(nil, resp).Ok)
+
+	result := makeStreamAnyMessageExpectation("type", "DELETED").Check(nil, resp)
+	assert.False(t, result.Ok)
+	assert.True(t, result.Retryable)
+	assert.Contains(t, result.Reason, "None of 2 received messages")
+}
+
+func TestStreamEveryMessageExpectation(t *testing.T) {
+	exp := makeStreamEveryMessageExpectation("id", 1)
+
+	assert.True(t, exp.Check(nil, streamResponse(nil, `{"id":1}`, `{"id":1}`)).Ok)
+
+	result := exp.Check(nil, streamResponse(nil, `{"id":1}`, `{"id":2}`))
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "Message #2")
+
+	result = exp.Check(nil, streamResponse(nil))
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "No messages received")
+}
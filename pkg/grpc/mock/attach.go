@@ -0,0 +1,40 @@
+package mock
+
+import (
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/allure"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+)
+
+var mockReporter = allure.NewDefaultReporter()
+
+func attachMockReport[TReq, TResp any](stepCtx provider.StepCtx, v *Verification[TReq, TResp], result *Received[TReq], summary polling.PollingSummary) {
+	report := allure.GRPCMockReportDTO{
+		Verification: v.String(),
+		Polling:      allure.ToPollingSummaryDTO(summary),
+	}
+
+	if result != nil {
+		report.Total = result.Total
+		for _, call := range result.Matched {
+			report.Matched = append(report.Matched, toMockCallDTO(call))
+		}
+	}
+
+	for _, call := range v.method.Calls() {
+		if !v.matches(call) {
+			report.Others = append(report.Others, toMockCallDTO(call))
+		}
+	}
+
+	mockReporter.AttachGRPCMockReport(stepCtx, report)
+}
+
+func toMockCallDTO[TReq any](call Call[TReq]) allure.GRPCMockCallDTO {
+	dto := allure.GRPCMockCallDTO{ReceivedAt: call.ReceivedAt, Metadata: call.Metadata, Error: call.Error}
+	for _, req := range call.Requests {
+		dto.Requests = append(dto.Requests, req)
+	}
+	return dto
+}
@@ -0,0 +1,244 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+// Method is the programmable handler of a single RPC. Until it is programmed it answers
+// unary and client-streaming calls with an empty response message and closes
+// server-streaming and bidi calls without messages.
+//
+// Behavior precedence: a Handle function wins over canned responses; a status set with
+// ReturnsError answers unary calls instead of the response, and closes streams after the
+// canned messages were sent.
+type Method[TReq, TResp any] struct {
+	server     *Server
+	fullMethod string
+	kind       client.StreamKind
+
+	// Guarded by server.mu.
+	responses []*TResp
+	handler   func(ctx context.Context, req *TReq) (*TResp, error)
+	err       error
+	delay     time.Duration
+}
+
+// Call is a journaled call of a method with its typed request messages.
+type Call[TReq any] struct {
+	Metadata   metadata.MD
+	Requests   []*TReq
+	ReceivedAt time.Time
+	Error      error
+}
+
+// Request returns the first request message, the only one of unary and server-streaming calls.
+func (c Call[TReq]) Request() *TReq {
+	if len(c.Requests) == 0 {
+		return nil
+	}
+	return c.Requests[0]
+}
+
+// Register adds a unary method to the server, replacing an earlier registration.
+// fullMethod is "/package.Service/Method".
+func Register[TReq, TResp any](s *Server, fullMethod string) *Method[TReq, TResp] {
+	return RegisterStream[TReq, TResp](s, fullMethod, "")
+}
+
+// RegisterStream adds a streaming method of the given kind to the server.
+func RegisterStream[TReq, TResp any](s *Server, fullMethod string, kind client.StreamKind) *Method[TReq, TResp] {
+	m := &Method[TReq, TResp]{server: s, fullMethod: fullMethod, kind: kind}
+	s.register(fullMethod, m)
+	return m
+}
+
+// FullMethod returns the method name as "/package.Service/Method".
+func (m *Method[TReq, TResp]) FullMethod() string {
+	return m.fullMethod
+}
+
+// Returns answers with canned responses: the first one for unary and client-streaming
+// calls, all of them in order for server-streaming and bidi calls.
+func (m *Method[TReq, TResp]) Returns(responses ...*TResp) *Method[TReq, TResp] {
+	m.server.mu.Lock()
+	defer m.server.mu.Unlock()
+	m.responses = responses
+	return m
+}
+
+// ReturnsError answers with a status error.
+func (m *Method[TReq, TResp]) ReturnsError(code codes.Code, message string) *Method[TReq, TResp] {
+	return m.ReturnsStatus(status.New(code, message))
+}
+
+// ReturnsStatus answers with st, e.g. one carrying error details built with st.WithDetails.
+// A status with code OK clears a previously set error.
+func (m *Method[TReq, TResp]) ReturnsStatus(st *status.Status) *Method[TReq, TResp] {
+	m.server.mu.Lock()
+	defer m.server.mu.Unlock()
+	m.err = st.Err()
+	return m
+}
+
+// Handle answers every request with fn. For bidi methods fn is called per received
+// message and a nil response sends nothing; for client-streaming methods it gets the
+// last message after the client closed its side.
+func (m *Method[TReq, TResp]) Handle(fn func(ctx context.Context, req *TReq) (*TResp, error)) *Method[TReq, TResp] {
+	m.server.mu.Lock()
+	defer m.server.mu.Unlock()
+	m.handler = fn
+	return m
+}
+
+// Delay makes every answer wait for d, e.g. to test client deadlines.
+func (m *Method[TReq, TResp]) Delay(d time.Duration) *Method[TReq, TResp] {
+	m.server.mu.Lock()
+	defer m.server.mu.Unlock()
+	m.delay = d
+	return m
+}
+
+// Reset clears the programmed behavior. Journaled calls are kept; see Server.Reset.
+func (m *Method[TReq, TResp]) Reset() *Method[TReq, TResp] {
+	m.server.mu.Lock()
+	defer m.server.mu.Unlock()
+	m.responses, m.handler, m.err, m.delay = nil, nil, nil, 0
+	return m
+}
+
+// Calls returns the journaled calls of this method in arrival order.
+func (m *Method[TReq, TResp]) Calls() []Call[TReq] {
+	var calls []Call[TReq]
+	for _, received := range m.server.Calls() {
+		if received.FullMethod == m.fullMethod {
+			calls = append(calls, toCall[TReq](received))
+		}
+	}
+	return calls
+}
+
+// Requests returns every request message received by this method in arrival order.
+func (m *Method[TReq, TResp]) Requests() []*TReq {
+	var requests []*TReq
+	for _, call := range m.Calls() {
+		requests = append(requests, call.Requests...)
+	}
+	return requests
+}
+
+func toCall[TReq any](received ReceivedCall) Call[TReq] {
+	call := Call[TReq]{Metadata: received.Metadata, ReceivedAt: received.ReceivedAt, Error: received.Error}
+	for _, req := range received.Requests {
+		if typed, ok := req.(*TReq); ok {
+			call.Requests = append(call.Requests, typed)
+		}
+	}
+	return call
+}
+
+// behavior is a snapshot of the programmed behavior taken when a call starts.
+type behavior[TReq, TResp any] struct {
+	responses []*TResp
+	handler   func(ctx context.Context, req *TReq) (*TResp, error)
+	err       error
+	delay     time.Duration
+}
+
+func (m *Method[TReq, TResp]) behavior() behavior[TReq, TResp] {
+	m.server.mu.Lock()
+	defer m.server.mu.Unlock()
+	return behavior[TReq, TResp]{responses: m.responses, handler: m.handler, err: m.err, delay: m.delay}
+}
+
+func (m *Method[TReq, TResp]) clientStreams() bool {
+	return m.kind == client.ClientStreaming || m.kind == client.BidiStreaming
+}
+
+func (m *Method[TReq, TResp]) serverStreams() bool {
+	return m.kind == client.ServerStreaming || m.kind == client.BidiStreaming
+}
+
+func (m *Method[TReq, TResp]) serve(stream grpc.ServerStream, call *ReceivedCall) error {
+	b := m.behavior()
+	perMessage := m.kind == client.BidiStreaming && b.handler != nil
+
+	var last *TReq
+	for {
+		req := new(TReq)
+		if err := stream.RecvMsg(req); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		m.server.addRequest(call, req)
+		last = req
+
+		if perMessage {
+			if err := m.reply(stream, b, req); err != nil {
+				return err
+			}
+			continue
+		}
+		if !m.clientStreams() {
+			break
+		}
+	}
+
+	if perMessage {
+		return nil
+	}
+	return m.reply(stream, b, last)
+}
+
+func (m *Method[TReq, TResp]) reply(stream grpc.ServerStream, b behavior[TReq, TResp], req *TReq) error {
+	if b.delay > 0 {
+		select {
+		case <-time.After(b.delay):
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+
+	if b.handler != nil {
+		resp, err := b.handler(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if resp == nil && !m.serverStreams() {
+			resp = new(TResp)
+		}
+		if resp != nil {
+			return stream.SendMsg(resp)
+		}
+		return nil
+	}
+
+	if !m.serverStreams() {
+		if b.err != nil {
+			return b.err
+		}
+		resp := new(TResp)
+		if len(b.responses) > 0 && b.responses[0] != nil {
+			resp = b.responses[0]
+		}
+		return stream.SendMsg(resp)
+	}
+
+	for _, resp := range b.responses {
+		if err := stream.SendMsg(resp); err != nil {
+			return err
+		}
+	}
+	return b.err
+}
@@ -0,0 +1,157 @@
+package mock
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+// Server is an in-process gRPC server that answers registered methods with programmed
+// behavior and journals every call it receives. Point the service under test at Target().
+//
+// Methods are usually registered by the mock generated with grpc-gen -mock, but any
+// method can be registered by hand:
+//
+//	server, err := mock.New(mock.Config{})
+//	defer server.Close()
+//
+//	getUser := mock.Register[pb.GetUserRequest, pb.GetUserResponse](server, "/user.UserService/GetUser")
+//	getUser.Returns(&pb.GetUserResponse{Name: "John"})
+//
+//	// ... exercise the service under test ...
+//
+//	getUser.ExpectCalled(sCtx).WithField("id", "123").Once()
+type Server struct {
+	AsyncConfig config.AsyncConfig
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+
+	mu      sync.Mutex
+	methods map[string]handler
+	journal []*ReceivedCall
+}
+
+// Config is the mock server configuration.
+type Config = config.MockConfig
+
+// ReceivedCall is a journaled call: the request messages the client sent and the
+// status the mock closed the call with.
+type ReceivedCall struct {
+	FullMethod string
+	Metadata   metadata.MD
+	// Requests holds *TReq values of the registered method, in arrival order.
+	Requests   []any
+	ReceivedAt time.Time
+	// Error is the status the call was answered with; nil means OK.
+	Error error
+}
+
+// handler serves calls of a single registered method.
+type handler interface {
+	serve(stream grpc.ServerStream, call *ReceivedCall) error
+}
+
+// New starts a mock server on cfg.Addr.
+func New(cfg Config) (*Server, error) {
+	addr := cfg.Addr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start gRPC mock on '%s': %w", addr, err)
+	}
+
+	s := &Server{
+		AsyncConfig: cfg.AsyncConfig.WithDefaults(),
+		listener:    listener,
+		methods:     make(map[string]handler),
+	}
+	// Every call goes through the unknown-service handler, so methods can be
+	// registered at any time without generated service descriptors.
+	s.grpcServer = grpc.NewServer(grpc.UnknownServiceHandler(s.handle))
+	go func() { _ = s.grpcServer.Serve(listener) }()
+
+	return s, nil
+}
+
+// Target returns the address clients dial, e.g. "127.0.0.1:54321".
+func (s *Server) Target() string {
+	return s.listener.Addr().String()
+}
+
+// Close stops the server, cancelling in-flight calls.
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+}
+
+// Reset clears the call journal. Programmed behavior is kept; reset it per method.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.journal = nil
+}
+
+// Calls returns a copy of the call journal in arrival order.
+func (s *Server) Calls() []ReceivedCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := make([]ReceivedCall, 0, len(s.journal))
+	for _, call := range s.journal {
+		calls = append(calls, call.copy())
+	}
+	return calls
+}
+
+func (s *Server) register(fullMethod string, h handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.methods[fullMethod] = h
+}
+
+func (s *Server) handle(_ any, stream grpc.ServerStream) error {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	md, _ := metadata.FromIncomingContext(stream.Context())
+
+	call := &ReceivedCall{FullMethod: fullMethod, Metadata: md.Copy(), ReceivedAt: time.Now()}
+
+	s.mu.Lock()
+	h := s.methods[fullMethod]
+	s.journal = append(s.journal, call)
+	s.mu.Unlock()
+
+	var err error
+	if h == nil {
+		err = status.Errorf(codes.Unimplemented, "no mock registered for %s", fullMethod)
+	} else {
+		err = h.serve(stream, call)
+	}
+
+	s.mu.Lock()
+	call.Error = err
+	s.mu.Unlock()
+	return err
+}
+
+func (s *Server) addRequest(call *ReceivedCall, req any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	call.Requests = append(call.Requests, req)
+}
+
+// copy must be called with the server lock held.
+func (c *ReceivedCall) copy() ReceivedCall {
+	copied := *c
+	copied.Requests = append([]any(nil), c.Requests...)
+	return copied
+}
@@ -0,0 +1,175 @@
+package mock
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/grpc/client"
+)
+
+func newTestServer(t *testing.T) (*Server, *client.Client) {
+	t.Helper()
+	s, err := New(Config{})
+	require.NoError(t, err)
+	t.Cleanup(s.Close)
+
+	c, err := client.New(client.Config{Target: s.Target(), Insecure: true})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return s, c
+}
+
+func invoke(t *testing.T, c *client.Client, fullMethod, value string, md metadata.MD) *client.Response[wrapperspb.StringValue] {
+	t.Helper()
+	// Status errors are returned both as err and in resp.Error.
+	resp, _ := client.Invoke[wrapperspb.StringValue, wrapperspb.StringValue](
+		context.Background(), c, fullMethod, wrapperspb.String(value), md)
+	require.NotNil(t, resp)
+	return resp
+}
+
+func TestServer_UnaryCannedResponseAndJournal(t *testing.T) {
+	s, c := newTestServer(t)
+	m := Register[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Echo/Get")
+
+	resp := invoke(t, c, "/test.Echo/Get", "default", nil)
+	require.NoError(t, resp.Error)
+	assert.Equal(t, "", resp.Body.GetValue(), "unprogrammed methods answer with an empty message")
+
+	m.Returns(wrapperspb.String("pong"))
+	resp = invoke(t, c, "/test.Echo/Get", "ping", metadata.Pairs("x-request-id", "r1"))
+	require.NoError(t, resp.Error)
+	assert.Equal(t, "pong", resp.Body.GetValue())
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "ping", calls[1].Request().GetValue())
+	assert.Equal(t, []string{"r1"}, calls[1].Metadata.Get("x-request-id"))
+	assert.NoError(t, calls[1].Error)
+	assert.Len(t, m.Requests(), 2)
+
+	s.Reset()
+	assert.Empty(t, m.Calls())
+	assert.Equal(t, "pong", invoke(t, c, "/test.Echo/Get", "again", nil).Body.GetValue(),
+		"Server.Reset keeps programmed behavior")
+}
+
+func TestServer_StatusErrors(t *testing.T) {
+	s, c := newTestServer(t)
+	m := Register[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Echo/Get").
+		Returns(wrapperspb.String("ignored"))
+
+	m.ReturnsError(codes.NotFound, "user not found")
+	resp := invoke(t, c, "/test.Echo/Get", "42", nil)
+	assert.Equal(t, codes.NotFound, status.Code(resp.Error))
+	assert.Equal(t, "user not found", status.Convert(resp.Error).Message())
+
+	st, err := status.New(codes.InvalidArgument, "bad request").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "id", Description: "must be numeric"}},
+	})
+	require.NoError(t, err)
+	m.ReturnsStatus(st)
+	resp = invoke(t, c, "/test.Echo/Get", "abc", nil)
+	require.Len(t, status.Convert(resp.Error).Details(), 1)
+
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, codes.InvalidArgument, status.Code(calls[1].Error))
+
+	m.Reset()
+	assert.NoError(t, invoke(t, c, "/test.Echo/Get", "ok", nil).Error)
+}
+
+func TestServer_HandleAndDelay(t *testing.T) {
+	s, c := newTestServer(t)
+	m := Register[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Echo/Get").
+		Handle(func(_ context.Context, req *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			if req.GetValue() == "" {
+				return nil, status.Error(codes.InvalidArgument, "value is required")
+			}
+			return wrapperspb.String(strings.ToUpper(req.GetValue())), nil
+		})
+
+	assert.Equal(t, "HELLO", invoke(t, c, "/test.Echo/Get", "hello", nil).Body.GetValue())
+	assert.Equal(t, codes.InvalidArgument, status.Code(invoke(t, c, "/test.Echo/Get", "", nil).Error))
+
+	m.Delay(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Invoke[wrapperspb.StringValue, wrapperspb.StringValue](ctx, c, "/test.Echo/Get", wrapperspb.String("slow"), nil)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestServer_UnregisteredMethod(t *testing.T) {
+	s, c := newTestServer(t)
+
+	resp := invoke(t, c, "/test.Echo/Missing", "x", nil)
+	assert.Equal(t, codes.Unimplemented, status.Code(resp.Error))
+	assert.Contains(t, status.Convert(resp.Error).Message(), "no mock registered for /test.Echo/Missing")
+
+	calls := s.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, "/test.Echo/Missing", calls[0].FullMethod)
+}
+
+func TestServer_ServerStreamingSendsMessagesThenStatus(t *testing.T) {
+	s, c := newTestServer(t)
+	m := RegisterStream[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Feed/Watch", client.ServerStreaming).
+		Returns(wrapperspb.String("a"), wrapperspb.String("b")).
+		ReturnsError(codes.Unavailable, "feed closed")
+
+	resp, err := client.Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Feed/Watch",
+		[]*wrapperspb.StringValue{wrapperspb.String("topic")}, client.StreamOptions{Kind: client.ServerStreaming, Timeout: 5 * time.Second})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	require.Len(t, resp.Messages, 2)
+	assert.Equal(t, "b", resp.Messages[1].GetValue())
+	assert.Equal(t, codes.Unavailable, status.Code(resp.Error))
+	assert.Equal(t, "topic", m.Calls()[0].Request().GetValue())
+}
+
+func TestServer_ClientStreamingCapturesAllMessages(t *testing.T) {
+	s, c := newTestServer(t)
+	m := RegisterStream[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Upload/Send", client.ClientStreaming).
+		Returns(wrapperspb.String("stored"))
+
+	requests := []*wrapperspb.StringValue{wrapperspb.String("1"), wrapperspb.String("2"), wrapperspb.String("3")}
+	resp, err := client.Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Upload/Send",
+		requests, client.StreamOptions{Kind: client.ClientStreaming, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Messages, 1)
+	assert.Equal(t, "stored", resp.Messages[0].GetValue())
+	require.Len(t, m.Calls(), 1)
+	assert.Len(t, m.Calls()[0].Requests, 3)
+}
+
+func TestServer_BidiHandleRepliesPerMessage(t *testing.T) {
+	s, c := newTestServer(t)
+	RegisterStream[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Chat/Talk", client.BidiStreaming).
+		Handle(func(_ context.Context, req *wrapperspb.StringValue) (*wrapperspb.StringValue, error) {
+			if req.GetValue() == "skip" {
+				return nil, nil
+			}
+			return wrapperspb.String("echo: " + req.GetValue()), nil
+		})
+
+	requests := []*wrapperspb.StringValue{wrapperspb.String("hi"), wrapperspb.String("skip"), wrapperspb.String("bye")}
+	resp, err := client.Stream[wrapperspb.StringValue, wrapperspb.StringValue](context.Background(), c, "/test.Chat/Talk",
+		requests, client.StreamOptions{Kind: client.BidiStreaming, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+
+	require.NoError(t, resp.Error)
+	require.Len(t, resp.Messages, 2)
+	assert.Equal(t, "echo: bye", resp.Messages[1].GetValue())
+}
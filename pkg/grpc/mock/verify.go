@@ -0,0 +1,172 @@
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/journal"
+	"github.com/gorelov-m-v/go-test-framework/internal/jsonutil"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+)
+
+// Verification checks how many journaled calls of a method match its filters.
+// In async mode (AsyncStep) the journal is polled until the count matches.
+type Verification[TReq, TResp any] struct {
+	stepCtx provider.StepCtx
+	method  *Method[TReq, TResp]
+	ctx     context.Context
+
+	fields   []fieldFilter
+	metadata map[string]string
+}
+
+type fieldFilter struct {
+	path     string
+	expected any
+}
+
+// Received is the journal state observed by a verification.
+type Received[TReq any] = journal.Received[Call[TReq]]
+
+// ExpectCalled starts a verification of calls to the method.
+//
+// Example:
+//
+//	userMock.GetUser.ExpectCalled(sCtx).WithField("id", "123").Once()
+func (m *Method[TReq, TResp]) ExpectCalled(stepCtx provider.StepCtx) *Verification[TReq, TResp] {
+	return &Verification[TReq, TResp]{
+		stepCtx: stepCtx,
+		method:  m,
+		ctx:     context.Background(),
+	}
+}
+
+// WithField matches calls with a request message whose JSON field at path (GJSON syntax)
+// equals expected.
+func (v *Verification[TReq, TResp]) WithField(path string, expected any) *Verification[TReq, TResp] {
+	v.fields = append(v.fields, fieldFilter{path: path, expected: expected})
+	return v
+}
+
+// WithMetadata matches calls carrying the metadata key with this value.
+func (v *Verification[TReq, TResp]) WithMetadata(key, value string) *Verification[TReq, TResp] {
+	if v.metadata == nil {
+		v.metadata = make(map[string]string)
+	}
+	v.metadata[strings.ToLower(key)] = value
+	return v
+}
+
+// Times expects exactly n matching calls and returns them.
+func (v *Verification[TReq, TResp]) Times(n int) []Call[TReq] {
+	return v.verify(journal.Exactly(n))
+}
+
+// Once expects exactly one matching call.
+func (v *Verification[TReq, TResp]) Once() []Call[TReq] {
+	return v.Times(1)
+}
+
+// AtLeast expects n or more matching calls and returns them.
+func (v *Verification[TReq, TResp]) AtLeast(n int) []Call[TReq] {
+	return v.verify(journal.AtLeast(n))
+}
+
+// Never expects no matching call at the time of the check.
+func (v *Verification[TReq, TResp]) Never() {
+	v.verify(journal.None())
+}
+
+// String describes the verified method and filters, e.g. "/user.UserService/GetUser id=123".
+func (v *Verification[TReq, TResp]) String() string {
+	parts := []string{v.method.fullMethod}
+	for key, value := range v.metadata {
+		parts = append(parts, key+": "+value)
+	}
+	sort.Strings(parts[1:])
+	for _, f := range v.fields {
+		parts = append(parts, fmt.Sprintf("%s=%v", f.path, f.expected))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (v *Verification[TReq, TResp]) verify(count journal.Count) []Call[TReq] {
+	v.validate()
+
+	return journal.Verifier[Call[TReq]]{
+		Step:        fmt.Sprintf("gRPC mock received %s", v),
+		Noun:        "call",
+		AsyncConfig: v.method.server.AsyncConfig,
+		Read:        v.received,
+		Attach: func(stepCtx provider.StepCtx, result *Received[TReq], summary polling.PollingSummary) {
+			attachMockReport(stepCtx, v, result, summary)
+		},
+	}.Verify(v.ctx, v.stepCtx, count)
+}
+
+func (v *Verification[TReq, TResp]) received() *Received[TReq] {
+	calls := v.method.Calls()
+	result := &Received[TReq]{Total: len(calls)}
+	for _, call := range calls {
+		if v.matches(call) {
+			result.Matched = append(result.Matched, call)
+		}
+	}
+	return result
+}
+
+// matches reports whether the call carries the metadata and has a request message
+// with all the fields.
+func (v *Verification[TReq, TResp]) matches(call Call[TReq]) bool {
+	for key, value := range v.metadata {
+		if !containsValue(call.Metadata.Get(key), value) {
+			return false
+		}
+	}
+	if len(v.fields) == 0 {
+		return true
+	}
+	for _, req := range call.Requests {
+		if v.fieldsMatch(req) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Verification[TReq, TResp]) fieldsMatch(req *TReq) bool {
+	raw, err := json.Marshal(req)
+	if err != nil {
+		return false
+	}
+	for _, f := range v.fields {
+		res, err := jsonutil.GetField(raw, f.path)
+		if err != nil || !res.Exists() {
+			return false
+		}
+		if ok, _ := jsonutil.Compare(res, f.expected); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Verification[TReq, TResp]) validate() {
+	val := validation.New(v.stepCtx, "gRPC mock")
+	val.RequireNotNil(v.method, "gRPC mock method")
+}
@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestVerification_Filters(t *testing.T) {
+	s, c := newTestServer(t)
+	m := Register[wrapperspb.StringValue, wrapperspb.StringValue](s, "/test.Echo/Get")
+
+	invoke(t, c, "/test.Echo/Get", "a", metadata.Pairs("x-tenant", "t1"))
+	invoke(t, c, "/test.Echo/Get", "b", metadata.Pairs("x-tenant", "t2"))
+	invoke(t, c, "/test.Echo/Get", "a", nil)
+
+	v := m.ExpectCalled(nil).WithField("value", "a")
+	assert.Len(t, v.received().Matched, 2)
+	assert.Equal(t, 3, v.received().Total)
+
+	v = m.ExpectCalled(nil).WithField("value", "a").WithMetadata("X-Tenant", "t1")
+	assert.Len(t, v.received().Matched, 1)
+	assert.Equal(t, "/test.Echo/Get x-tenant: t1 value=a", v.String())
+
+	assert.Empty(t, m.ExpectCalled(nil).WithField("missing", "a").received().Matched)
+}
+
+func TestToMockCallDTO(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	dto := toMockCallDTO(Call[wrapperspb.StringValue]{
+		Metadata:   metadata.Pairs("authorization", "Bearer secret"),
+		Requests:   []*wrapperspb.StringValue{wrapperspb.String("a"), wrapperspb.String("b")},
+		ReceivedAt: at,
+		Error:      status.Error(codes.NotFound, "missing"),
+	})
+
+	assert.Equal(t, at, dto.ReceivedAt)
+	require.Len(t, dto.Requests, 2)
+	assert.Equal(t, codes.NotFound, status.Code(dto.Error))
+}
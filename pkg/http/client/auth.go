@@ -0,0 +1,334 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+const (
+	AuthBasic  = "basic"
+	AuthBearer = "bearer"
+	AuthOAuth2 = "oauth2"
+
+	GrantClientCredentials = "client_credentials"
+	GrantPassword          = "password"
+)
+
+// maxTokenExpiryDelta caps how long before expiry a cached OAuth2 token is renewed.
+const maxTokenExpiryDelta = 30 * time.Second
+
+// Credentials identify the user a single request is sent as (see Call.AsUser).
+// Token is sent as a bearer token. Username and Password are sent with basic auth,
+// or exchanged for a token with the OAuth2 password grant when auth.type is oauth2.
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// authProvider produces the Authorization header value for a request.
+// creds is nil for requests sent with the configured identity.
+type authProvider interface {
+	authorization(ctx context.Context, creds *Credentials) (string, error)
+	describe(creds *Credentials) string
+}
+
+func newAuthProvider(cfg config.AuthConfig, httpClient *http.Client) (authProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Type)) {
+	case "":
+		return nil, nil
+	case AuthBasic:
+		if cfg.Username == "" {
+			return nil, fmt.Errorf("HTTP auth: basic requires username")
+		}
+		return basicAuth{username: cfg.Username, password: cfg.Password}, nil
+	case AuthBearer:
+		if cfg.Token == "" {
+			return nil, fmt.Errorf("HTTP auth: bearer requires token")
+		}
+		return bearerAuth{token: cfg.Token}, nil
+	case AuthOAuth2:
+		return newOAuth2Auth(cfg, httpClient)
+	default:
+		return nil, fmt.Errorf("HTTP auth: unknown type '%s' (expected basic, bearer or oauth2)", cfg.Type)
+	}
+}
+
+// authorization returns the Authorization header for a request sent as creds
+// (nil means the configured identity), or "" when no authentication applies.
+func (c *Client) authorization(ctx context.Context, creds *Credentials) (string, error) {
+	if creds != nil && creds.Token != "" {
+		return bearerAuth{token: creds.Token}.authorization(ctx, nil)
+	}
+	if c.auth == nil {
+		if creds == nil {
+			return "", nil
+		}
+		return basicAuth{username: creds.Username, password: creds.Password}.authorization(ctx, nil)
+	}
+	return c.auth.authorization(ctx, creds)
+}
+
+// DescribeAuth returns a secret-free description of how a request sent as creds
+// is authenticated, for reports. It is empty when no authentication applies.
+func (c *Client) DescribeAuth(creds *Credentials) string {
+	if c == nil {
+		return ""
+	}
+	if creds != nil && creds.Token != "" {
+		return "bearer (per request)"
+	}
+	if c.auth == nil {
+		if creds == nil {
+			return ""
+		}
+		return fmt.Sprintf("basic (user %s)", creds.Username)
+	}
+	return c.auth.describe(creds)
+}
+
+type basicAuth struct {
+	username string
+	password string
+}
+
+func (a basicAuth) authorization(_ context.Context, creds *Credentials) (string, error) {
+	username, password := a.username, a.password
+	if creds != nil {
+		username, password = creds.Username, creds.Password
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+}
+
+func (a basicAuth) describe(creds *Credentials) string {
+	if creds != nil {
+		return fmt.Sprintf("basic (user %s)", creds.Username)
+	}
+	return fmt.Sprintf("basic (user %s)", a.username)
+}
+
+type bearerAuth struct {
+	token string
+}
+
+func (a bearerAuth) authorization(ctx context.Context, creds *Credentials) (string, error) {
+	if creds != nil {
+		return basicAuth{}.authorization(ctx, creds)
+	}
+	return "Bearer " + a.token, nil
+}
+
+func (a bearerAuth) describe(creds *Credentials) string {
+	if creds != nil {
+		return basicAuth{}.describe(creds)
+	}
+	return "bearer"
+}
+
+// oauth2Auth fetches tokens from the token endpoint and caches them per grant and user
+// until shortly before expiry. Expired tokens are renewed with the refresh token when
+// the endpoint issued one, falling back to the original grant.
+type oauth2Auth struct {
+	cfg        config.OAuth2Config
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	tokens map[string]*oauth2Entry
+}
+
+// oauth2Entry is the cached token of one grant and user. Its lock is held while the
+// token is renewed, so concurrent requests as that user wait for a single fetch and
+// requests as other users are not blocked.
+type oauth2Entry struct {
+	mu    sync.Mutex
+	token *oauth2Token
+}
+
+type oauth2Token struct {
+	accessToken  string
+	tokenType    string
+	refreshToken string
+	expiry       time.Time
+}
+
+func (t *oauth2Token) valid(now time.Time) bool {
+	return t != nil && (t.expiry.IsZero() || now.Before(t.expiry))
+}
+
+func newOAuth2Auth(cfg config.AuthConfig, httpClient *http.Client) (*oauth2Auth, error) {
+	oauth := cfg.OAuth2
+	if oauth.TokenURL == "" {
+		return nil, fmt.Errorf("HTTP auth: oauth2 requires oauth2.tokenURL")
+	}
+	if oauth.ClientID == "" {
+		return nil, fmt.Errorf("HTTP auth: oauth2 requires oauth2.clientID")
+	}
+	switch oauth.GrantType {
+	case "":
+		oauth.GrantType = GrantClientCredentials
+	case GrantClientCredentials:
+	case GrantPassword:
+		if cfg.Username == "" {
+			return nil, fmt.Errorf("HTTP auth: oauth2 password grant requires username")
+		}
+	default:
+		return nil, fmt.Errorf("HTTP auth: unsupported oauth2 grantType '%s' (expected %s or %s)",
+			oauth.GrantType, GrantClientCredentials, GrantPassword)
+	}
+
+	return &oauth2Auth{
+		cfg:        oauth,
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: httpClient,
+		tokens:     make(map[string]*oauth2Entry),
+	}, nil
+}
+
+func (a *oauth2Auth) authorization(ctx context.Context, creds *Credentials) (string, error) {
+	form := a.grantForm(creds)
+	key := form.Get("grant_type") + ":" + form.Get("username")
+
+	entry := a.entry(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if !entry.token.valid(time.Now()) {
+		token, err := a.renew(ctx, entry.token, form)
+		if err != nil {
+			return "", err
+		}
+		entry.token = token
+	}
+
+	return entry.token.tokenType + " " + entry.token.accessToken, nil
+}
+
+func (a *oauth2Auth) entry(key string) *oauth2Entry {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entry, ok := a.tokens[key]
+	if !ok {
+		entry = &oauth2Entry{}
+		a.tokens[key] = entry
+	}
+	return entry
+}
+
+func (a *oauth2Auth) describe(creds *Credentials) string {
+	form := a.grantForm(creds)
+	if user := form.Get("username"); user != "" {
+		return fmt.Sprintf("oauth2 %s (client %s, user %s)", form.Get("grant_type"), a.cfg.ClientID, user)
+	}
+	return fmt.Sprintf("oauth2 %s (client %s)", form.Get("grant_type"), a.cfg.ClientID)
+}
+
+// grantForm builds the token request for creds; per-request credentials always use
+// the password grant.
+func (a *oauth2Auth) grantForm(creds *Credentials) url.Values {
+	form := url.Values{}
+	switch {
+	case creds != nil:
+		form.Set("grant_type", GrantPassword)
+		form.Set("username", creds.Username)
+		form.Set("password", creds.Password)
+	case a.cfg.GrantType == GrantPassword:
+		form.Set("grant_type", GrantPassword)
+		form.Set("username", a.username)
+		form.Set("password", a.password)
+	default:
+		form.Set("grant_type", GrantClientCredentials)
+	}
+	return form
+}
+
+func (a *oauth2Auth) renew(ctx context.Context, expired *oauth2Token, form url.Values) (*oauth2Token, error) {
+	if expired != nil && expired.refreshToken != "" {
+		refresh := url.Values{}
+		refresh.Set("grant_type", "refresh_token")
+		refresh.Set("refresh_token", expired.refreshToken)
+		if token, err := a.fetch(ctx, refresh); err == nil {
+			return token, nil
+		}
+	}
+	return a.fetch(ctx, form)
+}
+
+// fetch posts a token request with the client credentials in the form body.
+func (a *oauth2Auth) fetch(ctx context.Context, form url.Values) (*oauth2Token, error) {
+	form.Set("client_id", a.cfg.ClientID)
+	if a.cfg.ClientSecret != "" {
+		form.Set("client_secret", a.cfg.ClientSecret)
+	}
+	if len(a.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(a.cfg.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OAuth2 token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OAuth2 token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth2 token response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("OAuth2 token endpoint returned %s: %s", resp.Status, truncate(string(body), 200))
+	}
+
+	var payload struct {
+		AccessToken  string `json:"access_token"`
+		TokenType    string `json:"token_type"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid OAuth2 token response: %w", err)
+	}
+	if payload.AccessToken == "" {
+		return nil, fmt.Errorf("OAuth2 token response has no access_token")
+	}
+
+	token := &oauth2Token{
+		accessToken:  payload.AccessToken,
+		tokenType:    "Bearer",
+		refreshToken: payload.RefreshToken,
+	}
+	if payload.TokenType != "" && !strings.EqualFold(payload.TokenType, "bearer") {
+		token.tokenType = payload.TokenType
+	}
+	if payload.ExpiresIn > 0 {
+		lifetime := time.Duration(payload.ExpiresIn) * time.Second
+		token.expiry = time.Now().Add(lifetime - min(lifetime/10, maxTokenExpiryDelta))
+	}
+	return token, nil
+}
+
+func truncate(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	return s[:limit] + "..."
+}
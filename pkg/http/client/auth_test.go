@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+// tokenStub is a local OAuth2 token endpoint that records the grants it served.
+type tokenStub struct {
+	mu        sync.Mutex
+	grants    []string
+	expiresIn int
+	refresh   bool
+	fail      bool
+}
+
+func (s *tokenStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_ = r.ParseForm()
+	grant := r.PostForm.Get("grant_type")
+	s.grants = append(s.grants, grant)
+
+	if s.fail || r.PostForm.Get("client_id") != "svc" {
+		http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+		return
+	}
+
+	token := grant + "-" + r.PostForm.Get("username") + "-" + r.PostForm.Get("scope")
+	resp := map[string]any{"access_token": token, "token_type": "bearer", "expires_in": s.expiresIn}
+	if s.refresh {
+		resp["refresh_token"] = "refresh-" + token
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *tokenStub) served() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.grants...)
+}
+
+// newEchoAuthServer answers every request with its Authorization header.
+func newEchoAuthServer(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func sendAs(t *testing.T, c *Client, creds *Credentials, headers map[string]string) string {
+	t.Helper()
+	resp, err := c.Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: "/", Headers: headers, Credentials: creds})
+	require.NoError(t, err)
+	return string(resp.RawBody)
+}
+
+func TestAuth_BasicAndBearer(t *testing.T) {
+	baseURL := newEchoAuthServer(t)
+
+	basic, err := New(Config{BaseURL: baseURL, Auth: config.AuthConfig{Type: "basic", Username: "admin", Password: "pw"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Basic YWRtaW46cHc=", sendAs(t, basic, nil, nil))
+	assert.Equal(t, "Bearer per-call", sendAs(t, basic, &Credentials{Token: "per-call"}, nil))
+	assert.Equal(t, "Custom x", sendAs(t, basic, nil, map[string]string{"Authorization": "Custom x"}))
+	assert.Equal(t, "basic (user admin)", basic.DescribeAuth(nil))
+
+	bearer, err := New(Config{BaseURL: baseURL, Auth: config.AuthConfig{Type: "bearer", Token: "static"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer static", sendAs(t, bearer, nil, nil))
+	assert.Equal(t, "Basic Ym9iOnB3", sendAs(t, bearer, &Credentials{Username: "bob", Password: "pw"}, nil))
+
+	none, err := New(Config{BaseURL: baseURL})
+	require.NoError(t, err)
+	assert.Equal(t, "", sendAs(t, none, nil, nil))
+	assert.Equal(t, "", none.DescribeAuth(nil))
+	assert.Equal(t, "Basic Ym9iOnB3", sendAs(t, none, &Credentials{Username: "bob", Password: "pw"}, nil))
+}
+
+func TestAuth_OAuth2ClientCredentialsIsCached(t *testing.T) {
+	stub := &tokenStub{expiresIn: 3600}
+	tokenSrv := httptest.NewServer(stub)
+	t.Cleanup(tokenSrv.Close)
+
+	c, err := New(Config{BaseURL: newEchoAuthServer(t), Auth: config.AuthConfig{
+		Type:   "oauth2",
+		OAuth2: config.OAuth2Config{TokenURL: tokenSrv.URL, ClientID: "svc", ClientSecret: "s3cr3t", Scopes: []string{"read", "write"}},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer client_credentials--read write", sendAs(t, c, nil, nil))
+	assert.Equal(t, "Bearer client_credentials--read write", sendAs(t, c, nil, nil))
+	assert.Equal(t, []string{"client_credentials"}, stub.served())
+
+	// Per-request users get their own password-grant token, cached separately.
+	assert.Equal(t, "Bearer password-alice-read write", sendAs(t, c, &Credentials{Username: "alice", Password: "pw"}, nil))
+	assert.Equal(t, "Bearer password-alice-read write", sendAs(t, c, &Credentials{Username: "alice", Password: "pw"}, nil))
+	assert.Equal(t, "Bearer password-bob-read write", sendAs(t, c, &Credentials{Username: "bob", Password: "pw"}, nil))
+	assert.Equal(t, []string{"client_credentials", "password", "password"}, stub.served())
+
+	assert.Equal(t, "oauth2 client_credentials (client svc)", c.DescribeAuth(nil))
+	assert.Equal(t, "oauth2 password (client svc, user alice)", c.DescribeAuth(&Credentials{Username: "alice"}))
+}
+
+func TestAuth_OAuth2RefreshesExpiredToken(t *testing.T) {
+	stub := &tokenStub{expiresIn: 3600, refresh: true}
+	tokenSrv := httptest.NewServer(stub)
+	t.Cleanup(tokenSrv.Close)
+
+	c, err := New(Config{BaseURL: newEchoAuthServer(t), Auth: config.AuthConfig{
+		Type:     "oauth2",
+		Username: "svc-user",
+		Password: "pw",
+		OAuth2:   config.OAuth2Config{TokenURL: tokenSrv.URL, ClientID: "svc", GrantType: GrantPassword},
+	}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer password-svc-user-", sendAs(t, c, nil, nil))
+
+	// Expire the cached token: the refresh token is used instead of the password grant.
+	for _, entry := range c.auth.(*oauth2Auth).tokens {
+		entry.token.expiry = time.Now().Add(-time.Second)
+	}
+	assert.Equal(t, "Bearer refresh_token--", sendAs(t, c, nil, nil))
+	assert.Equal(t, []string{"password", "refresh_token"}, stub.served())
+}
+
+func TestAuth_OAuth2FetchDoesNotBlockOtherUsers(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	fetches := map[string]int{}
+	tokenSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		user := r.PostForm.Get("username")
+		mu.Lock()
+		fetches[user]++
+		mu.Unlock()
+		if user == "slow" {
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "token-" + user, "expires_in": 3600})
+	}))
+	t.Cleanup(tokenSrv.Close)
+
+	c, err := New(Config{BaseURL: newEchoAuthServer(t), Auth: config.AuthConfig{
+		Type:   "oauth2",
+		OAuth2: config.OAuth2Config{TokenURL: tokenSrv.URL, ClientID: "svc"},
+	}})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	slow := make([]string, 3)
+	for i := range slow {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := c.Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: "/", Credentials: &Credentials{Username: "slow", Password: "pw"}})
+			if assert.NoError(t, err) {
+				slow[i] = string(resp.RawBody)
+			}
+		}()
+	}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return fetches["slow"] == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The slow fetch is still in flight; another user gets its token meanwhile.
+	assert.Equal(t, "Bearer token-fast", sendAs(t, c, &Credentials{Username: "fast", Password: "pw"}, nil))
+
+	close(release)
+	wg.Wait()
+	assert.Equal(t, []string{"Bearer token-slow", "Bearer token-slow", "Bearer token-slow"}, slow)
+	assert.Equal(t, map[string]int{"slow": 1, "fast": 1}, fetches, "concurrent requests as one user share a fetch")
+}
+
+func TestAuth_OAuth2TokenErrorFailsRequest(t *testing.T) {
+	stub := &tokenStub{fail: true}
+	tokenSrv := httptest.NewServer(stub)
+	t.Cleanup(tokenSrv.Close)
+
+	c, err := New(Config{BaseURL: newEchoAuthServer(t), Auth: config.AuthConfig{
+		Type:   "oauth2",
+		OAuth2: config.OAuth2Config{TokenURL: tokenSrv.URL, ClientID: "svc"},
+	}})
+	require.NoError(t, err)
+
+	resp, err := c.Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: "/"})
+	require.Error(t, err)
+	assert.Contains(t, resp.NetworkError, "failed to authorize request")
+	assert.Contains(t, resp.NetworkError, "401 Unauthorized")
+}
+
+func TestNew_AuthValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		auth config.AuthConfig
+		want string
+	}{
+		{name: "unknown type", auth: config.AuthConfig{Type: "digest"}, want: "unknown type 'digest'"},
+		{name: "basic without username", auth: config.AuthConfig{Type: "basic"}, want: "basic requires username"},
+		{name: "bearer without token", auth: config.AuthConfig{Type: "bearer"}, want: "bearer requires token"},
+		{name: "oauth2 without tokenURL", auth: config.AuthConfig{Type: "oauth2"}, want: "oauth2.tokenURL"},
+		{
+			name: "oauth2 password without username",
+			auth: config.AuthConfig{Type: "oauth2", OAuth2: config.OAuth2Config{TokenURL: "http://idp", ClientID: "svc", GrantType: GrantPassword}},
+			want: "password grant requires username",
+		},
+		{
+			name: "oauth2 unsupported grant",
+			auth: config.AuthConfig{Type: "oauth2", OAuth2: config.OAuth2Config{TokenURL: "http://idp", ClientID: "svc", GrantType: "implicit"}},
+			want: "unsupported oauth2 grantType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(Config{BaseURL: "http://localhost", Auth: tt.auth})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
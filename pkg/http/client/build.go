@@ -43,6 +43,16 @@ func buildRequest[TReq any](ctx context.Context, c *Client, req *Request[TReq])
 	applyHeaders(httpReq.Header, c.DefaultHeaders, req.Headers)
 	setContentTypeIfMissing(httpReq.Header, contentType)
 
+	if httpReq.Header.Get("Authorization") == "" {
+		authorization, err := c.authorization(ctx, req.Credentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authorize request: %w", err)
+		}
+		if authorization != "" {
+			httpReq.Header.Set("Authorization", authorization)
+		}
+	}
+
 	return httpReq, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/cookiejar"
 	"strings"
 	"time"
 
@@ -18,17 +19,27 @@ type Client struct {
 	AsyncConfig       config.AsyncConfig
 	ContractValidator *contract.Validator
 	ContractBasePath  string
-	maskHeaders       map[string]bool
+	// ValidateRequests makes DSL calls validate outgoing requests against the contract.
+	ValidateRequests bool
+	maskHeaders      map[string]bool
+	healthPath       string
+	auth             authProvider
+	vcrMode          string
 }
 
 type Config struct {
-	BaseURL          string                `mapstructure:"baseURL" yaml:"baseURL" json:"baseURL"`
-	Timeout          time.Duration         `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
-	DefaultHeaders   map[string]string     `mapstructure:"defaultHeaders" yaml:"defaultHeaders" json:"defaultHeaders"`
-	MaskHeaders      string                `mapstructure:"maskHeaders" yaml:"maskHeaders" json:"maskHeaders"`
-	ContractSpec     string                `mapstructure:"contractSpec" yaml:"contractSpec" json:"contractSpec"`
-	ContractBasePath string                `mapstructure:"contractBasePath" yaml:"contractBasePath" json:"contractBasePath"`
-	AsyncConfig      config.AsyncConfig    `mapstructure:"async" yaml:"async" json:"async"`
+	BaseURL                  string             `mapstructure:"baseURL" yaml:"baseURL" json:"baseURL"`
+	Timeout                  time.Duration      `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+	DefaultHeaders           map[string]string  `mapstructure:"defaultHeaders" yaml:"defaultHeaders" json:"defaultHeaders"`
+	MaskHeaders              string             `mapstructure:"maskHeaders" yaml:"maskHeaders" json:"maskHeaders"`
+	ContractSpec             string             `mapstructure:"contractSpec" yaml:"contractSpec" json:"contractSpec"`
+	ContractBasePath         string             `mapstructure:"contractBasePath" yaml:"contractBasePath" json:"contractBasePath"`
+	ContractValidateRequests bool               `mapstructure:"contractValidateRequests" yaml:"contractValidateRequests" json:"contractValidateRequests"`
+	HealthPath               string             `mapstructure:"healthPath" yaml:"healthPath" json:"healthPath"`
+	Auth                     config.AuthConfig  `mapstructure:"auth" yaml:"auth" json:"auth"`
+	CookieJar                bool               `mapstructure:"cookieJar" yaml:"cookieJar" json:"cookieJar"`
+	VCR                      config.VCRConfig   `mapstructure:"vcr" yaml:"vcr" json:"vcr"`
+	AsyncConfig              config.AsyncConfig `mapstructure:"async" yaml:"async" json:"async"`
 }
 
 func New(cfg Config) (*Client, error) {
@@ -49,16 +60,53 @@ func New(cfg Config) (*Client, error) {
 		}
 	}
 
+	httpClient := &http.Client{
+		Timeout: cfg.Timeout,
+	}
+	if cfg.CookieJar {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+		}
+		httpClient.Jar = jar
+	}
+
+	vcr, err := newVCRTransport(cfg.VCR, http.DefaultTransport, maskHeaders)
+	if err != nil {
+		return nil, err
+	}
+	var vcrMode string
+	if vcr != nil {
+		httpClient.Transport = vcr
+		vcrMode = vcr.mode
+	}
+
+	// Token requests are recorded too, so replay works offline, but the client secret,
+	// password and issued tokens never reach the cassette.
+	authClient := httpClient
+	if vcr != nil {
+		tokenClient := *httpClient
+		tokenClient.Transport = vcr.withRedactedFields(oauth2SecretFields...)
+		authClient = &tokenClient
+	}
+
+	auth, err := newAuthProvider(cfg.Auth, authClient)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
-		BaseURL: cfg.BaseURL,
-		HTTPClient: &http.Client{
-			Timeout: cfg.Timeout,
-		},
+		BaseURL:           cfg.BaseURL,
+		HTTPClient:        httpClient,
 		DefaultHeaders:    cfg.DefaultHeaders,
 		AsyncConfig:       asyncCfg,
 		ContractValidator: contractValidator,
 		ContractBasePath:  cfg.ContractBasePath,
+		ValidateRequests:  cfg.ContractValidateRequests,
 		maskHeaders:       maskHeaders,
+		healthPath:        cfg.HealthPath,
+		auth:              auth,
+		vcrMode:           vcrMode,
 	}, nil
 }
 
@@ -82,6 +130,11 @@ func (c *Client) ShouldMaskHeader(name string) bool {
 	return c.maskHeaders[strings.ToLower(strings.TrimSpace(name))]
 }
 
+// VCRMode returns the configured VCR mode ("record", "replay" or "auto"), or "" when disabled.
+func (c *Client) VCRMode() string {
+	return c.vcrMode
+}
+
 func (c *Client) GetBaseURL() string {
 	return c.BaseURL
 }
@@ -109,7 +162,14 @@ func DoTyped[TReq any, TResp any](ctx context.Context, c *Client, req *Request[T
 		}, err
 	}
 
-	resp, err := c.HTTPClient.Do(httpReq)
+	httpClient := c.HTTPClient
+	if req.CookieJar != nil {
+		withJar := *c.HTTPClient
+		withJar.Jar = req.CookieJar
+		httpClient = &withJar
+	}
+
+	resp, err := httpClient.Do(httpReq)
 	if err != nil {
 		return &Response[TResp]{
 			NetworkError: fmt.Sprintf("request failed: %v", err),
@@ -118,5 +178,9 @@ func DoTyped[TReq any, TResp any](ctx context.Context, c *Client, req *Request[T
 	}
 	defer resp.Body.Close()
 
-	return decodeResponse[TResp](resp, time.Since(start))
+	result, err := decodeResponse[TResp](resp, time.Since(start))
+	if resp.Request != nil {
+		result.SentCookies = resp.Request.Cookies()
+	}
+	return result, err
 }
@@ -1,7 +1,11 @@
 package client
 
 import (
+	"context"
 	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -597,3 +601,49 @@ func TestCountTrue(t *testing.T) {
 		})
 	}
 }
+
+// newSessionServer sets a session cookie on /login and echoes the Cookie header elsewhere.
+func newSessionServer(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/login" {
+			http.SetCookie(w, &http.Cookie{Name: "SESSION", Value: "s1", Path: "/", HttpOnly: true})
+			return
+		}
+		_, _ = w.Write([]byte(r.Header.Get("Cookie")))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCookieJar(t *testing.T) {
+	baseURL := newSessionServer(t)
+	get := func(c *Client, path string, jar http.CookieJar) *Response[any] {
+		resp, err := c.Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: path, CookieJar: jar})
+		require.NoError(t, err)
+		return resp
+	}
+
+	withJar, err := New(Config{BaseURL: baseURL, CookieJar: true})
+	require.NoError(t, err)
+	login := get(withJar, "/login", nil)
+	require.NotNil(t, login.Cookie("SESSION"))
+	assert.Equal(t, "s1", login.Cookie("SESSION").Value)
+
+	me := get(withJar, "/me", nil)
+	assert.Equal(t, "SESSION=s1", string(me.RawBody))
+	require.Len(t, me.SentCookies, 1)
+	assert.Equal(t, "SESSION", me.SentCookies[0].Name)
+
+	withoutJar, err := New(Config{BaseURL: baseURL})
+	require.NoError(t, err)
+	get(withoutJar, "/login", nil)
+	assert.Empty(t, get(withoutJar, "/me", nil).RawBody)
+
+	// A per-request jar is used instead of the client's one.
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	get(withoutJar, "/login", jar)
+	assert.Equal(t, "SESSION=s1", string(get(withoutJar, "/me", jar).RawBody))
+	assert.Nil(t, withoutJar.HTTPClient.Jar)
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
+)
+
+// ContractPath resolves a request path template with its path params ({id} or :id)
+// and prefixes it with ContractBasePath, giving the path as written in the spec.
+func (c *Client) ContractPath(path string, pathParams map[string]string) string {
+	for key, value := range pathParams {
+		path = strings.ReplaceAll(path, "{"+key+"}", value)
+		path = strings.ReplaceAll(path, ":"+key, value)
+	}
+	if c.ContractBasePath != "" {
+		path = c.ContractBasePath + path
+	}
+	return path
+}
+
+// ContractRequest describes req as it will be sent, for contract.Validator.ValidateRequest:
+// the client's default headers are applied and the body is serialized the same way.
+// Authorization is not resolved, so no token is fetched.
+func ContractRequest[TReq any](c *Client, req *Request[TReq]) (contract.Request, error) {
+	query := url.Values{}
+	for key, value := range req.QueryParams {
+		query.Set(key, value)
+	}
+
+	headers := http.Header{}
+	applyHeaders(headers, c.DefaultHeaders, req.Headers)
+
+	var body []byte
+	reader, contentType, err := buildBody(req)
+	if err != nil {
+		return contract.Request{}, err
+	}
+	if reader != nil {
+		if body, err = io.ReadAll(reader); err != nil {
+			return contract.Request{}, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+	setContentTypeIfMissing(headers, contentType)
+
+	return contract.Request{
+		Method:  req.Method,
+		Path:    c.ContractPath(req.Path, req.PathParams),
+		Query:   query,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
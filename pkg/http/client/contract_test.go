@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractPath(t *testing.T) {
+	c := &Client{ContractBasePath: "/api/v1"}
+
+	assert.Equal(t, "/api/v1/users/42/orders/7",
+		c.ContractPath("/users/{id}/orders/:orderId", map[string]string{"id": "42", "orderId": "7"}))
+	assert.Equal(t, "/users", (&Client{}).ContractPath("/users", nil))
+}
+
+func TestContractRequest(t *testing.T) {
+	c := &Client{DefaultHeaders: map[string]string{"X-Tenant": "t-1", "X-Request-Id": "default"}}
+
+	req, err := ContractRequest(c, &Request[map[string]any]{
+		Method:      http.MethodPost,
+		Path:        "/users/{id}",
+		PathParams:  map[string]string{"id": "42"},
+		QueryParams: map[string]string{"notify": "true"},
+		Headers:     map[string]string{"X-Request-Id": "r-1"},
+		Body:        &map[string]any{"name": "John"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/users/42", req.Path)
+	assert.Equal(t, "true", req.Query.Get("notify"))
+	assert.Equal(t, "t-1", req.Headers.Get("X-Tenant"))
+	assert.Equal(t, "r-1", req.Headers.Get("X-Request-Id"), "request headers override the defaults")
+	assert.Equal(t, "application/json", req.Headers.Get("Content-Type"))
+	assert.JSONEq(t, `{"name":"John"}`, string(req.Body))
+}
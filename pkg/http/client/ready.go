@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+)
+
+// DefaultHealthPath is polled by WaitReady when no healthPath is configured.
+const DefaultHealthPath = "/health"
+
+// WaitReady polls GET on the health path with the default headers until it answers 2xx,
+// or returns an error with the last observed response after timeout.
+// In VCR replay mode there is no dependency to wait for and it returns at once.
+func (c *Client) WaitReady(timeout time.Duration) error {
+	if c.vcrMode == VCRReplay {
+		return nil
+	}
+
+	healthPath := c.healthPath
+	if healthPath == "" {
+		healthPath = DefaultHealthPath
+	}
+
+	healthURL, err := BuildEffectiveURL(c.BaseURL, healthPath, nil, nil)
+	if err != nil {
+		return fmt.Errorf("invalid health URL: %w", err)
+	}
+
+	err = polling.WaitReady(timeout, func(ctx context.Context) error {
+		return c.checkHealth(ctx, healthURL)
+	})
+	if err != nil {
+		return fmt.Errorf("HTTP %s not ready after %v: %w", healthURL, timeout, err)
+	}
+	return nil
+}
+
+func (c *Client) checkHealth(ctx context.Context, healthURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, healthURL, nil)
+	if err != nil {
+		return err
+	}
+	applyHeaders(req.Header, c.DefaultHeaders, nil)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check returned %s", resp.Status)
+	}
+	return nil
+}
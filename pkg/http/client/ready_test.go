@@ -0,0 +1,48 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitReady_PollsHealthPath(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/ready", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("X-Api-Key"))
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(Config{BaseURL: srv.URL, HealthPath: "/api/ready", DefaultHeaders: map[string]string{"X-Api-Key": "test-key"}})
+	require.NoError(t, err)
+
+	require.NoError(t, c.WaitReady(5*time.Second))
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestWaitReady_TimeoutReportsLastStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, DefaultHealthPath, r.URL.Path)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(Config{BaseURL: srv.URL})
+	require.NoError(t, err)
+
+	err = c.WaitReady(300 * time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not ready after 300ms")
+	assert.Contains(t, err.Error(), "503 Service Unavailable")
+}
@@ -19,6 +19,9 @@ type Request[T any] struct {
 	BodyMap     map[string]interface{}
 	RawBody     []byte
 	Multipart   *MultipartForm
+	Credentials *Credentials
+	// CookieJar overrides the client's jar for this request (see dsl.WithSession).
+	CookieJar http.CookieJar
 }
 
 type Response[V any] struct {
@@ -29,6 +32,27 @@ type Response[V any] struct {
 	Error        *ErrorResponse
 	Duration     time.Duration
 	NetworkError string
+	// SentCookies are the cookies the final request carried, including those from a cookie jar.
+	SentCookies []*http.Cookie
+}
+
+// Cookies parses the Set-Cookie headers of the response.
+func (r *Response[V]) Cookies() []*http.Cookie {
+	if r == nil {
+		return nil
+	}
+	return (&http.Response{Header: r.Headers}).Cookies()
+}
+
+// Cookie returns the last Set-Cookie with the given name, or nil.
+func (r *Response[V]) Cookie(name string) *http.Cookie {
+	var found *http.Cookie
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == name {
+			found = cookie
+		}
+	}
+	return found
 }
 
 func (r *Response[V]) GetNetworkError() string {
@@ -75,6 +99,7 @@ func (r *Response[V]) ToAny() *Response[any] {
 		Error:        r.Error,
 		Duration:     r.Duration,
 		NetworkError: r.NetworkError,
+		SentCookies:  r.SentCookies,
 	}
 }
 
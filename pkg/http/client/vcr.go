@@ -0,0 +1,328 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/redact"
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+const (
+	VCRRecord = "record"
+	VCRReplay = "replay"
+	VCRAuto   = "auto"
+)
+
+// DefaultCassetteDir is where cassettes are kept when no cassetteDir is configured.
+const DefaultCassetteDir = "testdata/cassettes"
+
+// redactedValue replaces redacted header and body values; it matches the Allure mask.
+const redactedValue = "***MASKED***"
+
+// vcrCredentialHeaders are redacted in cassettes even when they are not listed in maskHeaders.
+// Cookie and Set-Cookie are always redacted too, but only their values (see redactHeaders).
+var vcrCredentialHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+}
+
+// oauth2SecretFields are redacted in OAuth2 token exchanges whatever redactBodyFields lists.
+var oauth2SecretFields = []string{"client_secret", "password", "refresh_token", "access_token", "id_token"}
+
+// vcrTransport records exchanges to cassette files and serves them back.
+// A cassette is keyed by method, URL and a hash of the request body, so the same
+// request always maps to the same file and recording again overwrites it.
+type vcrTransport struct {
+	mode         string
+	dir          string
+	next         http.RoundTripper
+	maskHeaders  map[string]bool
+	redactFields map[string]bool
+}
+
+type cassette struct {
+	RecordedAt time.Time        `json:"recordedAt"`
+	Request    cassetteRequest  `json:"request"`
+	Response   cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+	// BodyEncoding is "base64" for bodies that are not valid UTF-8.
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+}
+
+func newVCRTransport(cfg config.VCRConfig, next http.RoundTripper, maskHeaders map[string]bool) (*vcrTransport, error) {
+	mode := strings.ToLower(strings.TrimSpace(cfg.Mode))
+	switch mode {
+	case "":
+		return nil, nil
+	case VCRRecord, VCRReplay, VCRAuto:
+	default:
+		return nil, fmt.Errorf("HTTP vcr: unknown mode '%s' (expected record, replay or auto)", cfg.Mode)
+	}
+
+	dir := cfg.CassetteDir
+	if dir == "" {
+		dir = DefaultCassetteDir
+	}
+
+	redactFields := make(map[string]bool, len(cfg.RedactBodyFields))
+	for _, field := range cfg.RedactBodyFields {
+		redactFields[strings.ToLower(strings.TrimSpace(field))] = true
+	}
+
+	return &vcrTransport{
+		mode:         mode,
+		dir:          dir,
+		next:         next,
+		maskHeaders:  maskHeaders,
+		redactFields: redactFields,
+	}, nil
+}
+
+// withRedactedFields returns a copy of t that also redacts fields in cassette bodies.
+func (t *vcrTransport) withRedactedFields(fields ...string) *vcrTransport {
+	clone := *t
+	clone.redactFields = maps.Clone(t.redactFields)
+	for _, field := range fields {
+		clone.redactFields[field] = true
+	}
+	return &clone
+}
+
+func (t *vcrTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("vcr: failed to read request body: %w", err)
+	}
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	path := filepath.Join(t.dir, cassetteName(req.Method, req.URL, body))
+
+	if t.mode != VCRRecord {
+		resp, err := loadCassette(path, req)
+		if err == nil {
+			return resp, nil
+		}
+		if t.mode == VCRReplay || !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("vcr: no cassette for %s %s (%s): %w", req.Method, req.URL, path, err)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if err := t.save(path, req, body, resp, respBody); err != nil {
+		return nil, fmt.Errorf("vcr: failed to record cassette %s: %w", path, err)
+	}
+	return resp, nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	return io.ReadAll(req.Body)
+}
+
+// cassetteName is "<METHOD>_<host_path>_<hash>.json"; the hash covers the method,
+// the full URL with query and the request body.
+func cassetteName(method string, u *url.URL, body []byte) string {
+	sum := sha256.Sum256([]byte(method + " " + u.String() + "\n" + string(body)))
+
+	slug := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, u.Host+u.Path)
+	slug = strings.Trim(slug, "_")
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+
+	return fmt.Sprintf("%s_%s_%s.json", method, slug, hex.EncodeToString(sum[:])[:12])
+}
+
+func loadCassette(path string, req *http.Request) (*http.Response, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c cassette
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette: %w", err)
+	}
+
+	body := []byte(c.Response.Body)
+	if c.Response.BodyEncoding == "base64" {
+		if body, err = base64.StdEncoding.DecodeString(c.Response.Body); err != nil {
+			return nil, fmt.Errorf("invalid cassette body: %w", err)
+		}
+	}
+
+	header := c.Response.Headers
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.Response.Status, http.StatusText(c.Response.Status)),
+		StatusCode:    c.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func (t *vcrTransport) save(path string, req *http.Request, reqBody []byte, resp *http.Response, respBody []byte) error {
+	c := cassette{
+		RecordedAt: time.Now().UTC(),
+		Request: cassetteRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: t.redactHeaders(req.Header),
+			Body:    string(t.redactBody(reqBody, req.Header.Get("Content-Type"))),
+		},
+		Response: cassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: t.redactHeaders(resp.Header),
+		},
+	}
+
+	respBody = t.redactBody(respBody, resp.Header.Get("Content-Type"))
+	if utf8.Valid(respBody) {
+		c.Response.Body = string(respBody)
+	} else {
+		c.Response.Body = base64.StdEncoding.EncodeToString(respBody)
+		c.Response.BodyEncoding = "base64"
+	}
+
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0644)
+}
+
+// redactHeaders masks credential headers and headers configured via maskHeaders.
+// Authorization values keep their scheme, e.g. "Bearer ***MASKED***". Cookie and Set-Cookie
+// keep cookie names and attributes, so a replayed Set-Cookie still fills the cookie jar.
+func (t *vcrTransport) redactHeaders(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := headers.Clone()
+	for key, values := range redacted {
+		lower := strings.ToLower(key)
+		for i, value := range values {
+			switch {
+			case lower == "cookie":
+				values[i] = redact.Cookie(value, redactedValue)
+			case lower == "set-cookie":
+				values[i] = redact.SetCookie(value, redactedValue)
+			case vcrCredentialHeaders[lower] || t.maskHeaders[lower]:
+				values[i] = redactCredential(lower, value)
+			}
+		}
+	}
+	return redacted
+}
+
+func redactCredential(header, value string) string {
+	scheme, _, found := strings.Cut(strings.TrimSpace(value), " ")
+	if strings.HasSuffix(header, "authorization") && found && scheme != "" {
+		return scheme + " " + redactedValue
+	}
+	return redactedValue
+}
+
+// redactBody masks the configured fields in JSON and form bodies; other bodies are kept as is.
+func (t *vcrTransport) redactBody(body []byte, contentType string) []byte {
+	if len(t.redactFields) == 0 || len(body) == 0 {
+		return body
+	}
+
+	if strings.Contains(strings.ToLower(contentType), "application/x-www-form-urlencoded") {
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return body
+		}
+		for key := range form {
+			if t.redactFields[strings.ToLower(key)] {
+				form.Set(key, redactedValue)
+			}
+		}
+		return []byte(form.Encode())
+	}
+
+	var decoded any
+	if json.Unmarshal(body, &decoded) != nil {
+		return body
+	}
+	redacted, err := json.Marshal(t.redactJSON(decoded))
+	if err != nil {
+		return body
+	}
+	return redacted
+}
+
+func (t *vcrTransport) redactJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if t.redactFields[strings.ToLower(key)] {
+				v[key] = redactedValue
+			} else {
+				v[key] = t.redactJSON(nested)
+			}
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = t.redactJSON(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+type vcrLogin struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func newVCRClient(t *testing.T, baseURL string, vcr config.VCRConfig) *Client {
+	t.Helper()
+	c, err := New(Config{BaseURL: baseURL, MaskHeaders: "X-Api-Key", VCR: vcr})
+	require.NoError(t, err)
+	return c
+}
+
+func login(t *testing.T, c *Client, user string) *Response[map[string]any] {
+	t.Helper()
+	resp, err := DoTyped[vcrLogin, map[string]any](context.Background(), c, &Request[vcrLogin]{
+		Method:  http.MethodPost,
+		Path:    "/login",
+		Headers: map[string]string{"Authorization": "Bearer secret", "X-Api-Key": "key-1"},
+		Body:    &vcrLogin{User: user, Password: "p@ss"},
+	})
+	require.NoError(t, err)
+	return resp
+}
+
+func TestVCR_RecordThenReplay(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		var body vcrLogin
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "s-1"})
+		_ = json.NewEncoder(w).Encode(map[string]any{"user": body.User, "token": "t-" + body.User})
+	}))
+	dir := t.TempDir()
+	vcr := config.VCRConfig{Mode: VCRRecord, CassetteDir: dir, RedactBodyFields: []string{"password", "Token"}}
+
+	recorded := login(t, newVCRClient(t, srv.URL, vcr), "john")
+	assert.Equal(t, "t-john", recorded.Body["token"], "the live response is not redacted")
+	login(t, newVCRClient(t, srv.URL, vcr), "jane")
+	srv.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "POST_*_login_*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "requests with different bodies get their own cassettes")
+
+	raw, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	var c cassette
+	require.NoError(t, json.Unmarshal(raw, &c))
+	assert.Equal(t, "Bearer "+redactedValue, c.Request.Headers.Get("Authorization"))
+	assert.Equal(t, redactedValue, c.Request.Headers.Get("X-Api-Key"))
+	assert.Contains(t, c.Request.Body, `"password":"`+redactedValue+`"`)
+	assert.Equal(t, "session="+redactedValue, c.Response.Headers.Get("Set-Cookie"))
+	assert.Contains(t, c.Response.Body, `"token":"`+redactedValue+`"`)
+
+	replay := newVCRClient(t, srv.URL, config.VCRConfig{Mode: VCRReplay, CassetteDir: dir})
+	assert.NoError(t, replay.WaitReady(0), "replay mode does not wait for the dependency")
+
+	resp := login(t, replay, "jane")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "jane", resp.Body["user"])
+	assert.Equal(t, int32(2), hits.Load(), "replay never reaches the network")
+
+	_, err = DoTyped[any, any](context.Background(), replay, &Request[any]{Method: http.MethodGet, Path: "/missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vcr: no cassette for GET")
+}
+
+func TestVCR_AutoRecordsOnlyMissingCassettes(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write([]byte{0xff, 0x00, 0x01})
+	}))
+	defer srv.Close()
+
+	c := newVCRClient(t, srv.URL, config.VCRConfig{Mode: VCRAuto, CassetteDir: t.TempDir()})
+	for range 3 {
+		resp, err := DoTyped[any, any](context.Background(), c, &Request[any]{Method: http.MethodGet, Path: "/blob", QueryParams: map[string]string{"v": "1"}})
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0x00, 0x01}, resp.RawBody, "binary bodies survive the cassette")
+	}
+	assert.Equal(t, int32(1), hits.Load())
+	assert.Equal(t, VCRAuto, c.VCRMode())
+}
+
+func TestVCR_UnknownMode(t *testing.T) {
+	_, err := New(Config{VCR: config.VCRConfig{Mode: "rewind"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown mode 'rewind'")
+}
+
+func TestVCR_RedactsOAuth2TokenExchange(t *testing.T) {
+	stub := &tokenStub{expiresIn: 3600, refresh: true}
+	tokenSrv := httptest.NewServer(stub)
+	apiSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	dir := t.TempDir()
+
+	newClient := func(mode string) *Client {
+		c, err := New(Config{BaseURL: apiSrv.URL, VCR: config.VCRConfig{Mode: mode, CassetteDir: dir}, Auth: config.AuthConfig{
+			Type:     "oauth2",
+			Username: "alice",
+			Password: "al1ce-pw",
+			OAuth2:   config.OAuth2Config{TokenURL: tokenSrv.URL, ClientID: "svc", ClientSecret: "s3cr3t", GrantType: GrantPassword},
+		}})
+		require.NoError(t, err)
+		return c
+	}
+
+	resp, err := newClient(VCRRecord).Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: "/me"})
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(resp.RawBody))
+	assert.Equal(t, []string{"password"}, stub.served(), "the live token is not redacted")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "the token request is recorded next to the API call")
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		require.NoError(t, err)
+		for _, secret := range []string{"s3cr3t", "al1ce-pw", "password-alice-", "refresh-password-alice-"} {
+			assert.NotContains(t, string(raw), secret, file)
+		}
+	}
+
+	tokenSrv.Close()
+	apiSrv.Close()
+	resp, err = newClient(VCRReplay).Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: "/me"})
+	require.NoError(t, err, "the redacted token exchange still replays")
+	assert.Equal(t, "ok", string(resp.RawBody))
+}
+
+func TestVCR_ReplaysSessionCookieFlow(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "s3ss10n", Path: "/", HttpOnly: true})
+		case "/profile":
+			if _, err := r.Cookie("sid"); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		}
+	}))
+	dir := t.TempDir()
+
+	flow := func(mode string) (*Response[any], *Response[any]) {
+		c, err := New(Config{BaseURL: srv.URL, CookieJar: true, VCR: config.VCRConfig{Mode: mode, CassetteDir: dir}})
+		require.NoError(t, err)
+		login, err := c.Do(context.Background(), &Request[any]{Method: http.MethodPost, Path: "/login"})
+		require.NoError(t, err)
+		profile, err := c.Do(context.Background(), &Request[any]{Method: http.MethodGet, Path: "/profile"})
+		require.NoError(t, err)
+		return login, profile
+	}
+
+	_, profile := flow(VCRRecord)
+	require.Equal(t, http.StatusOK, profile.StatusCode)
+	srv.Close()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "s3ss10n", file)
+	}
+
+	login, profile := flow(VCRReplay)
+	cookie, err := http.ParseSetCookie(login.Headers.Get("Set-Cookie"))
+	require.NoError(t, err, "the replayed Set-Cookie is a valid cookie")
+	assert.Equal(t, "sid", cookie.Name)
+	assert.Equal(t, "/", cookie.Path)
+	assert.True(t, cookie.HttpOnly)
+
+	assert.Equal(t, http.StatusOK, profile.StatusCode)
+	require.Len(t, profile.SentCookies, 1, "the replayed cookie reaches the jar")
+	assert.Equal(t, "sid", profile.SentCookies[0].Name)
+}
@@ -0,0 +1,240 @@
+package contract
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// coverage counts the exercised operation/response code pairs of one spec.
+// It is shared by all validators created for the same spec.
+type coverage struct {
+	spec  *openapi3.T
+	mu    sync.Mutex
+	calls map[coverageKey]int
+	// source is the spec file name, when the spec was loaded from a file.
+	source string
+}
+
+type coverageKey struct {
+	method string
+	path   string
+	status int
+}
+
+var (
+	coverageMu    sync.Mutex
+	coverageSpecs []*coverage
+	recordedCalls atomic.Uint64
+)
+
+func coverageFor(spec *openapi3.T) *coverage {
+	coverageMu.Lock()
+	defer coverageMu.Unlock()
+
+	for _, c := range coverageSpecs {
+		if c.spec == spec {
+			return c
+		}
+	}
+	c := &coverage{spec: spec, calls: make(map[coverageKey]int)}
+	coverageSpecs = append(coverageSpecs, c)
+	return c
+}
+
+func (c *coverage) setSource(source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.source == "" {
+		c.source = source
+	}
+}
+
+// CoverageReport shows which operations and documented response codes of a spec
+// were exercised, and which responses were received without being documented.
+type CoverageReport struct {
+	// Spec is the file name of the spec, e.g. "payments.yaml"; empty for specs not loaded from a file.
+	Spec       string              `json:"spec,omitempty"`
+	Title      string              `json:"title"`
+	Version    string              `json:"version"`
+	Summary    CoverageSummary     `json:"summary"`
+	Operations []OperationCoverage `json:"operations"`
+}
+
+type CoverageSummary struct {
+	Operations       int `json:"operations"`
+	TestedOperations int `json:"testedOperations"`
+	Responses        int `json:"responses"`
+	TestedResponses  int `json:"testedResponses"`
+}
+
+type OperationCoverage struct {
+	Method      string             `json:"method"`
+	Path        string             `json:"path"`
+	OperationID string             `json:"operationId,omitempty"`
+	Tested      bool               `json:"tested"`
+	Calls       int                `json:"calls"`
+	Responses   []ResponseCoverage `json:"responses"`
+}
+
+// ResponseCoverage is a response code of an operation: a documented one ("200", "4XX",
+// "default") or, with Documented false, a status received but missing from the spec.
+type ResponseCoverage struct {
+	Code       string `json:"code"`
+	Documented bool   `json:"documented"`
+	Tested     bool   `json:"tested"`
+	Calls      int    `json:"calls"`
+}
+
+// RecordCall marks the operation matching method and path as exercised with statusCode.
+// Calls to paths or methods not described in the spec are not recorded.
+func (v *Validator) RecordCall(method, path string, statusCode int) {
+	specPath, _, _, err := v.findRoute(method, path)
+	if err != nil {
+		return
+	}
+	key := coverageKey{method: strings.ToUpper(method), path: specPath, status: statusCode}
+
+	v.coverage.mu.Lock()
+	v.coverage.calls[key]++
+	v.coverage.mu.Unlock()
+	recordedCalls.Add(1)
+}
+
+// RecordedCalls is the number of calls recorded for all specs so far. It only grows,
+// so comparing it tells whether coverage changed since the last report was written.
+func RecordedCalls() uint64 {
+	return recordedCalls.Load()
+}
+
+// Coverage builds the coverage report of the validator's spec from the calls
+// recorded so far by all validators sharing the spec.
+func (v *Validator) Coverage() CoverageReport {
+	return v.coverage.report()
+}
+
+// CoverageReports returns the coverage of every spec a validator was created for.
+func CoverageReports() []CoverageReport {
+	coverageMu.Lock()
+	specs := append([]*coverage(nil), coverageSpecs...)
+	coverageMu.Unlock()
+
+	reports := make([]CoverageReport, 0, len(specs))
+	for _, c := range specs {
+		reports = append(reports, c.report())
+	}
+	return reports
+}
+
+func (c *coverage) report() CoverageReport {
+	c.mu.Lock()
+	calls := make(map[coverageKey]int, len(c.calls))
+	for key, n := range c.calls {
+		calls[key] = n
+	}
+	report := CoverageReport{Spec: c.source}
+	c.mu.Unlock()
+
+	if c.spec.Info != nil {
+		report.Title, report.Version = c.spec.Info.Title, c.spec.Info.Version
+	}
+	if c.spec.Paths == nil {
+		return report
+	}
+
+	for specPath, pathItem := range c.spec.Paths.Map() {
+		for method, op := range pathItem.Operations() {
+			opCov := operationCoverage(method, specPath, op, calls)
+			report.Operations = append(report.Operations, opCov)
+
+			report.Summary.Operations++
+			if opCov.Tested {
+				report.Summary.TestedOperations++
+			}
+			for _, resp := range opCov.Responses {
+				if !resp.Documented {
+					continue
+				}
+				report.Summary.Responses++
+				if resp.Tested {
+					report.Summary.TestedResponses++
+				}
+			}
+		}
+	}
+
+	sort.Slice(report.Operations, func(i, j int) bool {
+		a, b := report.Operations[i], report.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+	return report
+}
+
+func operationCoverage(method, specPath string, op *openapi3.Operation, calls map[coverageKey]int) OperationCoverage {
+	opCov := OperationCoverage{Method: method, Path: specPath, OperationID: op.OperationID}
+
+	counts := make(map[string]int)
+	var documented []string
+	if op.Responses != nil {
+		for code := range op.Responses.Map() {
+			documented = append(documented, code)
+			counts[code] = 0
+		}
+	}
+
+	for key, n := range calls {
+		if key.method != method || key.path != specPath {
+			continue
+		}
+		opCov.Calls += n
+		counts[matchResponseCode(documented, key.status)] += n
+	}
+	opCov.Tested = opCov.Calls > 0
+
+	isDocumented := make(map[string]bool, len(documented))
+	for _, code := range documented {
+		isDocumented[code] = true
+	}
+	for code, n := range counts {
+		opCov.Responses = append(opCov.Responses, ResponseCoverage{
+			Code:       code,
+			Documented: isDocumented[code],
+			Tested:     n > 0,
+			Calls:      n,
+		})
+	}
+	sort.Slice(opCov.Responses, func(i, j int) bool {
+		return opCov.Responses[i].Code < opCov.Responses[j].Code
+	})
+	return opCov
+}
+
+// matchResponseCode picks the documented response a status falls under: the exact
+// code, then its range ("4XX"), then "default". Otherwise the status is returned as is.
+func matchResponseCode(documented []string, status int) string {
+	code := strconv.Itoa(status)
+	rangeCode := code[:1] + "XX"
+
+	match := ""
+	for _, d := range documented {
+		switch {
+		case d == code:
+			return d
+		case strings.EqualFold(d, rangeCode):
+			match = d
+		case d == "default" && match == "":
+			match = d
+		}
+	}
+	if match != "" {
+		return match
+	}
+	return code
+}
@@ -0,0 +1,115 @@
+package contract
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coverageSpec = `
+openapi: 3.0.0
+info: {title: Orders, version: "2.1"}
+paths:
+  /orders:
+    get:
+      responses:
+        "200": {description: ok}
+    post:
+      operationId: createOrder
+      responses:
+        "201": {description: created}
+        "4XX": {description: client error}
+        default: {description: error}
+  /orders/{id}:
+    delete:
+      responses:
+        "204": {description: deleted}
+        "404": {description: not found}
+`
+
+func loadCoverageSpec(t *testing.T) *openapi3.T {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(coverageSpec))
+	require.NoError(t, err)
+	return spec
+}
+
+func findOperationCoverage(t *testing.T, report CoverageReport, method, path string) OperationCoverage {
+	t.Helper()
+	for _, op := range report.Operations {
+		if op.Method == method && op.Path == path {
+			return op
+		}
+	}
+	t.Fatalf("operation %s %s not in report", method, path)
+	return OperationCoverage{}
+}
+
+func TestCoverage(t *testing.T) {
+	spec := loadCoverageSpec(t)
+	v := NewValidatorFromSpec(spec)
+
+	v.RecordCall(http.MethodPost, "/orders", http.StatusCreated)
+	v.RecordCall(http.MethodPost, "/orders", http.StatusCreated)
+	v.RecordCall(http.MethodPost, "/orders", http.StatusConflict)
+	v.RecordCall(http.MethodPost, "/orders", http.StatusBadGateway)
+	v.RecordCall("delete", "/orders/42", http.StatusInternalServerError)
+	v.RecordCall(http.MethodGet, "/unknown", http.StatusOK)
+	NewValidatorFromSpec(spec).RecordCall(http.MethodDelete, "/orders/7", http.StatusNoContent)
+
+	report := v.Coverage()
+
+	assert.Equal(t, "Orders", report.Title)
+	assert.Equal(t, "2.1", report.Version)
+	assert.Equal(t, CoverageSummary{Operations: 3, TestedOperations: 2, Responses: 6, TestedResponses: 4}, report.Summary)
+
+	require.Len(t, report.Operations, 3)
+	assert.Equal(t, "/orders", report.Operations[0].Path, "operations are sorted by path and method")
+	assert.Equal(t, "GET", report.Operations[0].Method)
+	assert.False(t, report.Operations[0].Tested)
+
+	post := findOperationCoverage(t, report, "POST", "/orders")
+	assert.Equal(t, "createOrder", post.OperationID)
+	assert.Equal(t, 4, post.Calls)
+	assert.Equal(t, []ResponseCoverage{
+		{Code: "201", Documented: true, Tested: true, Calls: 2},
+		{Code: "4XX", Documented: true, Tested: true, Calls: 1},
+		{Code: "default", Documented: true, Tested: true, Calls: 1},
+	}, post.Responses)
+
+	del := findOperationCoverage(t, report, "DELETE", "/orders/{id}")
+	assert.Equal(t, []ResponseCoverage{
+		{Code: "204", Documented: true, Tested: true, Calls: 1},
+		{Code: "404", Documented: true},
+		{Code: "500", Tested: true, Calls: 1},
+	}, del.Responses, "validators of the same spec share coverage; undocumented codes are listed")
+}
+
+func TestCoverageReports(t *testing.T) {
+	spec := loadCoverageSpec(t)
+	NewValidatorFromSpec(spec)
+	NewValidatorFromSpec(spec)
+
+	var found int
+	for _, report := range CoverageReports() {
+		if report.Title == "Orders" && report.Summary.TestedOperations == 0 {
+			found++
+		}
+	}
+	assert.Equal(t, 1, found, "one report per spec")
+}
+
+func TestCoverage_SpecFileName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(coverageSpec), 0o600))
+
+	v, err := NewValidator(path)
+	require.NoError(t, err)
+	assert.Equal(t, "orders.yaml", v.Coverage().Spec)
+	assert.Empty(t, NewValidatorFromSpec(loadCoverageSpec(t)).Coverage().Spec)
+}
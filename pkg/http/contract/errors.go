@@ -11,6 +11,7 @@ const (
 	ErrSchemaNotFound
 	ErrInvalidJSON
 	ErrSchemaValidation
+	ErrRequestValidation
 )
 
 func (e ErrorType) String() string {
@@ -27,6 +28,8 @@ func (e ErrorType) String() string {
 		return "INVALID_JSON"
 	case ErrSchemaValidation:
 		return "SCHEMA_VALIDATION_FAILED"
+	case ErrRequestValidation:
+		return "REQUEST_VALIDATION_FAILED"
 	default:
 		return "UNKNOWN"
 	}
@@ -62,3 +65,10 @@ func IsSchemaValidationError(err error) bool {
 	}
 	return false
 }
+
+func IsRequestValidationError(err error) bool {
+	if ve, ok := err.(*ValidationError); ok {
+		return ve.Type == ErrRequestValidation
+	}
+	return false
+}
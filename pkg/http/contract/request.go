@@ -0,0 +1,232 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Request is an outgoing request checked by ValidateRequest.
+// Path is the resolved request path (path params substituted) relative to the spec.
+type Request struct {
+	Method  string
+	Path    string
+	Query   url.Values
+	Headers http.Header
+	Body    []byte
+}
+
+// ignoredHeaderParams are header parameters OpenAPI says to ignore: they are
+// described by the content and security definitions instead.
+var ignoredHeaderParams = map[string]bool{
+	"accept":        true,
+	"content-type":  true,
+	"authorization": true,
+}
+
+// ValidateRequest checks an outgoing request against the operation it targets:
+// path, query and header parameters (presence of required ones and their schemas)
+// and the JSON request body. All problems are reported in a single error.
+func (v *Validator) ValidateRequest(req Request) error {
+	specPath, pathItem, op, err := v.findRoute(req.Method, req.Path)
+	if err != nil {
+		return err
+	}
+
+	var problems []string
+
+	pathValues := pathParamValues(specPath, normalizePath(req.Path))
+	for _, param := range operationParameters(pathItem, op) {
+		switch param.In {
+		case openapi3.ParameterInPath:
+			if value, ok := pathValues[param.Name]; ok {
+				problems = appendParamProblem(problems, param, []string{value})
+			}
+		case openapi3.ParameterInQuery:
+			problems = appendParamProblem(problems, param, req.Query[param.Name])
+		case openapi3.ParameterInHeader:
+			if !ignoredHeaderParams[strings.ToLower(param.Name)] {
+				problems = appendParamProblem(problems, param, req.Headers.Values(param.Name))
+			}
+		}
+	}
+
+	problems = append(problems, validateRequestBody(op, req)...)
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{
+		Type: ErrRequestValidation,
+		Message: fmt.Sprintf("%s %s does not match %s %s in spec:\n  - %s",
+			strings.ToUpper(req.Method), req.Path, strings.ToUpper(req.Method), specPath, strings.Join(problems, "\n  - ")),
+	}
+}
+
+// operationParameters merges path item and operation parameters; the operation
+// overrides a path item parameter with the same name and location.
+func operationParameters(pathItem *openapi3.PathItem, op *openapi3.Operation) []*openapi3.Parameter {
+	byKey := make(map[string]*openapi3.Parameter)
+	var keys []string
+	for _, params := range []openapi3.Parameters{pathItem.Parameters, op.Parameters} {
+		for _, ref := range params {
+			if ref == nil || ref.Value == nil {
+				continue
+			}
+			key := ref.Value.In + ":" + ref.Value.Name
+			if _, seen := byKey[key]; !seen {
+				keys = append(keys, key)
+			}
+			byKey[key] = ref.Value
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]*openapi3.Parameter, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, byKey[key])
+	}
+	return result
+}
+
+func appendParamProblem(problems []string, param *openapi3.Parameter, values []string) []string {
+	if len(values) == 0 {
+		if param.Required {
+			return append(problems, fmt.Sprintf("%s parameter '%s' is required", param.In, param.Name))
+		}
+		return problems
+	}
+	if param.Schema == nil || param.Schema.Value == nil {
+		return problems
+	}
+	err := param.Schema.Value.VisitJSON(coerceParam(param.Schema.Value, values), openapi3.VisitAsRequest(), openapi3.MultiErrors())
+	for _, reason := range schemaErrorReasons(err) {
+		problems = append(problems, fmt.Sprintf("%s parameter '%s': %s", param.In, param.Name, reason))
+	}
+	return problems
+}
+
+// coerceParam converts raw parameter values to the JSON types their schema expects;
+// values that do not parse are kept as strings so the schema reports the mismatch.
+func coerceParam(schema *openapi3.Schema, values []string) any {
+	if schema.Type.Is("array") {
+		if len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		items := make([]any, 0, len(values))
+		for _, value := range values {
+			if schema.Items != nil && schema.Items.Value != nil {
+				items = append(items, coerceScalar(schema.Items.Value, value))
+			} else {
+				items = append(items, value)
+			}
+		}
+		return items
+	}
+	return coerceScalar(schema, values[0])
+}
+
+func coerceScalar(schema *openapi3.Schema, value string) any {
+	switch {
+	case schema.Type.Is("integer"):
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return float64(n)
+		}
+	case schema.Type.Is("number"):
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case schema.Type.Is("boolean"):
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// pathParamValues extracts the values of {name} segments of specPath from path.
+func pathParamValues(specPath, path string) map[string]string {
+	values := make(map[string]string)
+	actualParts := strings.Split(path, "/")
+	for i, part := range strings.Split(specPath, "/") {
+		if i < len(actualParts) && strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			value, err := url.PathUnescape(actualParts[i])
+			if err != nil {
+				value = actualParts[i]
+			}
+			values[strings.Trim(part, "{}")] = value
+		}
+	}
+	return values
+}
+
+// validateRequestBody checks presence of a required body and validates JSON bodies
+// against the application/json schema; other media types are not inspected.
+func validateRequestBody(op *openapi3.Operation, req Request) []string {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+	body := op.RequestBody.Value
+
+	if len(req.Body) == 0 {
+		if body.Required {
+			return []string{"request body is required"}
+		}
+		return nil
+	}
+
+	contentType := req.Headers.Get("Content-Type")
+	if contentType != "" && !strings.Contains(strings.ToLower(contentType), "json") {
+		return nil
+	}
+
+	mediaType := body.Content.Get("application/json")
+	if mediaType == nil || mediaType.Schema == nil || mediaType.Schema.Value == nil {
+		return nil
+	}
+
+	var data any
+	if err := json.Unmarshal(req.Body, &data); err != nil {
+		return []string{fmt.Sprintf("request body is not valid JSON: %v", err)}
+	}
+
+	var problems []string
+	err := mediaType.Schema.Value.VisitJSON(data, openapi3.VisitAsRequest(), openapi3.MultiErrors())
+	for _, reason := range schemaErrorReasons(err) {
+		problems = append(problems, "request body: "+reason)
+	}
+	return problems
+}
+
+// schemaErrorReasons flattens a schema validation error into short reasons,
+// e.g. `field "/amount": number must be at least 1`.
+func schemaErrorReasons(err error) []string {
+	if err == nil {
+		return nil
+	}
+
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) && len(multi) > 0 {
+		var reasons []string
+		for _, nested := range multi {
+			reasons = append(reasons, schemaErrorReasons(nested)...)
+		}
+		return reasons
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			return []string{fmt.Sprintf("field \"/%s\": %s", strings.Join(pointer, "/"), schemaErr.Reason)}
+		}
+		return []string{schemaErr.Reason}
+	}
+	return []string{formatSchemaError(err)}
+}
@@ -0,0 +1,123 @@
+package contract
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const requestSpec = `
+openapi: 3.0.0
+info: {title: Payments, version: "1.0"}
+paths:
+  /accounts/{accountId}/payments:
+    parameters:
+      - {name: accountId, in: path, required: true, schema: {type: integer}}
+    post:
+      parameters:
+        - {name: dryRun, in: query, schema: {type: boolean}}
+        - {name: X-Request-Id, in: header, required: true, schema: {type: string}}
+        - {name: Content-Type, in: header, required: true, schema: {type: string}}
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [amount, currency]
+              properties:
+                amount: {type: number, minimum: 1}
+                currency: {type: string, enum: [EUR, USD]}
+      responses:
+        "201": {description: created}
+`
+
+func newRequestValidator(t *testing.T) *Validator {
+	t.Helper()
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(requestSpec))
+	require.NoError(t, err)
+	return NewValidatorFromSpec(spec)
+}
+
+func paymentRequest() Request {
+	return Request{
+		Method:  http.MethodPost,
+		Path:    "/accounts/42/payments",
+		Query:   url.Values{"dryRun": {"true"}},
+		Headers: http.Header{"X-Request-Id": {"r-1"}, "Content-Type": {"application/json"}},
+		Body:    []byte(`{"amount": 10, "currency": "EUR"}`),
+	}
+}
+
+func TestValidateRequest_Valid(t *testing.T) {
+	assert.NoError(t, newRequestValidator(t).ValidateRequest(paymentRequest()))
+}
+
+func TestValidateRequest_Problems(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *Request)
+		want   []string
+	}{
+		{
+			name:   "path param type",
+			modify: func(r *Request) { r.Path = "/accounts/abc/payments" },
+			want:   []string{"path parameter 'accountId'"},
+		},
+		{
+			name:   "query param type",
+			modify: func(r *Request) { r.Query.Set("dryRun", "maybe") },
+			want:   []string{"query parameter 'dryRun'"},
+		},
+		{
+			name:   "missing required header",
+			modify: func(r *Request) { r.Headers.Del("X-Request-Id") },
+			want:   []string{"header parameter 'X-Request-Id' is required"},
+		},
+		{
+			name:   "missing body",
+			modify: func(r *Request) { r.Body = nil },
+			want:   []string{"request body is required"},
+		},
+		{
+			name:   "body schema",
+			modify: func(r *Request) { r.Body = []byte(`{"amount": 0, "currency": "GBP"}`) },
+			want:   []string{`request body: field "/amount"`, `request body: field "/currency"`},
+		},
+		{
+			name:   "body not json",
+			modify: func(r *Request) { r.Body = []byte(`{`) },
+			want:   []string{"request body is not valid JSON"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := paymentRequest()
+			tt.modify(&req)
+
+			err := newRequestValidator(t).ValidateRequest(req)
+
+			require.Error(t, err)
+			assert.True(t, IsRequestValidationError(err))
+			assert.Contains(t, err.Error(), "POST /accounts/{accountId}/payments")
+			for _, want := range tt.want {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestValidateRequest_UnknownRoute(t *testing.T) {
+	v := newRequestValidator(t)
+
+	err := v.ValidateRequest(Request{Method: http.MethodGet, Path: "/accounts/42/payments"})
+	assert.Contains(t, err.Error(), "OPERATION_NOT_FOUND")
+
+	err = v.ValidateRequest(Request{Method: http.MethodGet, Path: "/unknown"})
+	assert.True(t, IsPathNotFound(err))
+}
@@ -3,13 +3,15 @@ package contract
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
 
 type Validator struct {
-	spec *openapi3.T
+	spec     *openapi3.T
+	coverage *coverage
 }
 
 func NewValidator(specPath string) (*Validator, error) {
@@ -17,11 +19,13 @@ func NewValidator(specPath string) (*Validator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Validator{spec: spec}, nil
+	v := NewValidatorFromSpec(spec)
+	v.coverage.setSource(filepath.Base(specPath))
+	return v, nil
 }
 
 func NewValidatorFromSpec(spec *openapi3.T) *Validator {
-	return &Validator{spec: spec}
+	return &Validator{spec: spec, coverage: coverageFor(spec)}
 }
 
 func (v *Validator) ValidateResponse(method, path string, statusCode int, body []byte) error {
@@ -43,6 +47,12 @@ func (v *Validator) ValidateResponseBySchema(schemaName string, body []byte) err
 }
 
 func (v *Validator) findOperation(method, path string) (*openapi3.Operation, error) {
+	_, _, op, err := v.findRoute(method, path)
+	return op, err
+}
+
+// findRoute resolves the spec path template, its path item and the operation for a request.
+func (v *Validator) findRoute(method, path string) (string, *openapi3.PathItem, *openapi3.Operation, error) {
 	method = strings.ToUpper(method)
 
 	normalizedPath := normalizePath(path)
@@ -54,15 +64,15 @@ func (v *Validator) findOperation(method, path string) (*openapi3.Operation, err
 
 		op := pathItem.GetOperation(method)
 		if op == nil {
-			return nil, &ValidationError{
+			return "", nil, nil, &ValidationError{
 				Type:    ErrOperationNotFound,
 				Message: fmt.Sprintf("method %s not defined for path %s in spec", method, specPath),
 			}
 		}
-		return op, nil
+		return specPath, pathItem, op, nil
 	}
 
-	return nil, &ValidationError{
+	return "", nil, nil, &ValidationError{
 		Type:    ErrPathNotFound,
 		Message: fmt.Sprintf("path %s not found in spec", path),
 	}
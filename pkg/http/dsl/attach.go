@@ -22,6 +22,10 @@ func attachHTTPReport[TReq, TResp any](
 		Response: allure.ToHTTPResponseDTO(resp),
 		Polling:  allure.ToPollingSummaryDTO(pollingSummary),
 	}
+	report.Request.Auth = httpClient.DescribeAuth(req.Credentials)
+	if resp != nil {
+		report.Request.Cookies = resp.SentCookies
+	}
 
 	httpReporter.AttachHTTPReport(stepCtx, httpClient, report)
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/ozontech/allure-go/pkg/framework/provider"
 
@@ -43,17 +42,21 @@ type Call[TReq any, TResp any] struct {
 	expectations     []*expect.Expectation[*client.Response[any]]
 	validateContract bool
 	contractSchema   string
+
+	validateRequestContract bool
+	skipRequestContract     bool
 }
 
 // NewCall creates a new HTTP request builder.
 //
 // Parameters:
-//   - sCtx: Allure step context for test reporting
+//   - sCtx: Allure step context for test reporting; a Session from WithSession
+//     makes the call use the session's cookie jar
 //   - httpClient: HTTP client configured with base URL and settings
 //
 // Returns a Call builder that can be configured with HTTP method, path, and expectations.
 func NewCall[TReq any, TResp any](stepCtx provider.StepCtx, httpClient *client.Client) *Call[TReq, TResp] {
-	return &Call[TReq, TResp]{
+	call := &Call[TReq, TResp]{
 		stepCtx: stepCtx,
 		client:  httpClient,
 		ctx:     context.Background(),
@@ -63,6 +66,10 @@ func NewCall[TReq any, TResp any](stepCtx provider.StepCtx, httpClient *client.C
 			QueryParams: make(map[string]string),
 		},
 	}
+	if session, ok := stepCtx.(*Session); ok {
+		call.req.CookieJar = session.jar
+	}
+	return call
 }
 
 // GET sets the HTTP method to GET and specifies the request path.
@@ -101,6 +108,15 @@ func (c *Call[TReq, TResp]) Header(key, value string) *Call[TReq, TResp] {
 	return c
 }
 
+// AsUser sends this request as another user instead of the client's configured auth:
+// a Token is sent as a bearer token, Username and Password with basic auth or, for
+// oauth2 clients, exchanged for a cached token with the password grant.
+// An explicit Authorization header takes precedence.
+func (c *Call[TReq, TResp]) AsUser(creds client.Credentials) *Call[TReq, TResp] {
+	c.req.Credentials = &creds
+	return c
+}
+
 // PathParam adds a path parameter that will replace {key} or :key in the URL path.
 func (c *Call[TReq, TResp]) PathParam(key, value string) *Call[TReq, TResp] {
 	c.req.PathParams[key] = value
@@ -138,11 +154,16 @@ func (c *Call[TReq, TResp]) Send() *client.Response[TResp] {
 	c.validateContractConfig()
 
 	c.stepCtx.WithNewStep(c.stepName(), func(stepCtx provider.StepCtx) {
+		if !c.performRequestContractValidation(stepCtx) {
+			return
+		}
+
 		resp, err, summary := c.execute(stepCtx, c.expectations)
 		c.resp = resp
 		c.sent = true
 
 		attachHTTPReport(stepCtx, c.client, c.req, c.resp, summary)
+		c.recordContractCoverage(c.resp)
 		c.assertResults(stepCtx, err)
 		c.performContractValidation(stepCtx, c.resp)
 	})
@@ -185,7 +206,7 @@ func (c *Call[TReq, TResp]) validate() {
 }
 
 func (c *Call[TReq, TResp]) validateContractConfig() {
-	if !c.validateContract && c.contractSchema == "" {
+	if !c.validateContract && c.contractSchema == "" && !c.validateRequestContract {
 		return
 	}
 
@@ -214,14 +235,7 @@ func (c *Call[TReq, TResp]) performContractValidation(stepCtx provider.StepCtx,
 	if c.contractSchema != "" {
 		validationErr = c.client.ContractValidator.ValidateResponseBySchema(c.contractSchema, resp.RawBody)
 	} else if c.validateContract {
-		path := c.req.Path
-		for key, value := range c.req.PathParams {
-			path = strings.ReplaceAll(path, "{"+key+"}", value)
-			path = strings.ReplaceAll(path, ":"+key, value)
-		}
-		if c.client.ContractBasePath != "" {
-			path = c.client.ContractBasePath + path
-		}
+		path := c.client.ContractPath(c.req.Path, c.req.PathParams)
 		validationErr = c.client.ContractValidator.ValidateResponse(c.req.Method, path, resp.StatusCode, resp.RawBody)
 	}
 
@@ -229,3 +243,40 @@ func (c *Call[TReq, TResp]) performContractValidation(stepCtx provider.StepCtx,
 		stepCtx.Require().NoError(validationErr, "Contract validation failed")
 	}
 }
+
+// recordContractCoverage counts the call in the contract coverage report; every call of a
+// client with a contract spec is counted, whether or not its response is validated.
+func (c *Call[TReq, TResp]) recordContractCoverage(resp *client.Response[TResp]) {
+	if c.client.ContractValidator == nil || resp == nil || resp.NetworkError != "" {
+		return
+	}
+	c.client.ContractValidator.RecordCall(c.req.Method, c.client.ContractPath(c.req.Path, c.req.PathParams), resp.StatusCode)
+}
+
+func (c *Call[TReq, TResp]) shouldValidateRequest() bool {
+	if c.skipRequestContract || c.client.ContractValidator == nil {
+		return false
+	}
+	return c.validateRequestContract || c.client.ValidateRequests
+}
+
+// performRequestContractValidation checks the request against the contract before it is sent.
+// It returns false when the request was rejected; the request is then attached but not sent.
+func (c *Call[TReq, TResp]) performRequestContractValidation(stepCtx provider.StepCtx) bool {
+	if !c.shouldValidateRequest() {
+		return true
+	}
+
+	contractReq, err := client.ContractRequest(c.client, c.req)
+	if err == nil {
+		err = c.client.ContractValidator.ValidateRequest(contractReq)
+	}
+	if err == nil {
+		return true
+	}
+
+	c.sent = true
+	attachHTTPReport[TReq, TResp](stepCtx, c.client, c.req, nil, polling.PollingSummary{})
+	stepCtx.Require().NoError(err, "Request contract validation failed")
+	return false
+}
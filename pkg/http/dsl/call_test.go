@@ -193,6 +193,17 @@ func TestCallHeader(t *testing.T) {
 	assert.Equal(t, "Bearer token123", call.req.Headers["Authorization"])
 }
 
+func TestCallAsUser(t *testing.T) {
+	mockCtx := &mockStepCtx{}
+	call := NewCall[any, any](mockCtx, newTestClient())
+
+	result := call.AsUser(client.Credentials{Username: "alice", Password: "secret"})
+
+	assert.Same(t, call, result)
+	require.NotNil(t, call.req.Credentials)
+	assert.Equal(t, "alice", call.req.Credentials.Username)
+}
+
 func TestCallHeaderMultiple(t *testing.T) {
 	mockCtx := &mockStepCtx{}
 	call := NewCall[any, any](mockCtx, newTestClient())
@@ -389,6 +400,7 @@ func TestCallValidateContractConfig(t *testing.T) {
 	tests := []struct {
 		name             string
 		validateContract bool
+		validateRequest  bool
 		contractSchema   string
 		wantBroken       bool
 	}{
@@ -410,6 +422,11 @@ func TestCallValidateContractConfig(t *testing.T) {
 			contractSchema:   "UserResponse",
 			wantBroken:       true,
 		},
+		{
+			name:            "request validation without validator",
+			validateRequest: true,
+			wantBroken:      true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -419,6 +436,7 @@ func TestCallValidateContractConfig(t *testing.T) {
 
 			call.validateContract = tt.validateContract
 			call.contractSchema = tt.contractSchema
+			call.validateRequestContract = tt.validateRequest
 
 			call.validateContractConfig()
 
@@ -561,6 +579,8 @@ func TestCallMethodsReturnSelf(t *testing.T) {
 	assert.Same(t, call, call.ExpectFieldFalse("deleted"))
 	assert.Same(t, call, call.ExpectMatchesContract())
 	assert.Same(t, call, call.ExpectMatchesSchema("Schema"))
+	assert.Same(t, call, call.ExpectRequestMatchesContract())
+	assert.Same(t, call, call.SkipRequestContractValidation())
 	assert.Same(t, call, call.ExpectArrayContains("items", nil))
 	assert.Same(t, call, call.ExpectArrayContainsExact("items", nil))
 	assert.Same(t, call, call.ExpectBodyEquals(nil))
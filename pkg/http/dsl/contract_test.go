@@ -0,0 +1,124 @@
+package dsl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/client"
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/contract"
+)
+
+const usersSpec = `
+openapi: 3.0.0
+info: {title: Users, version: "1.0"}
+paths:
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name: {type: string}
+      responses:
+        "201": {description: created}
+`
+
+func newContractClient(t *testing.T, validateRequests bool) (*client.Client, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(usersSpec))
+	require.NoError(t, err)
+
+	c, err := client.New(client.Config{BaseURL: srv.URL})
+	require.NoError(t, err)
+	c.ContractValidator = contract.NewValidatorFromSpec(spec)
+	c.ValidateRequests = validateRequests
+	return c, &hits
+}
+
+func TestSend_RequestContractValidation(t *testing.T) {
+	invalidBody := map[string]interface{}{"name": 42}
+
+	tests := []struct {
+		name             string
+		validateRequests bool
+		setup            func(c *Call[any, any])
+		wantSent         bool
+	}{
+		{
+			name: "valid request is sent",
+			setup: func(c *Call[any, any]) {
+				c.RequestBodyMap(map[string]interface{}{"name": "John"}).ExpectRequestMatchesContract()
+			},
+			wantSent: true,
+		},
+		{
+			name:  "invalid request is not sent",
+			setup: func(c *Call[any, any]) { c.RequestBodyMap(invalidBody).ExpectRequestMatchesContract() },
+		},
+		{
+			name:             "client validates every request",
+			validateRequests: true,
+			setup:            func(c *Call[any, any]) { c.RequestBodyMap(invalidBody) },
+		},
+		{
+			name:             "negative test opts out",
+			validateRequests: true,
+			setup:            func(c *Call[any, any]) { c.RequestBodyMap(invalidBody).SkipRequestContractValidation() },
+			wantSent:         true,
+		},
+		{
+			name:     "validation is off by default",
+			setup:    func(c *Call[any, any]) { c.RequestBodyMap(invalidBody) },
+			wantSent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient, hits := newContractClient(t, tt.validateRequests)
+			call := NewCall[any, any](&mockStepCtx{}, httpClient).POST("/users")
+			tt.setup(call)
+
+			resp := call.Send()
+
+			if tt.wantSent {
+				assert.Equal(t, int32(1), hits.Load())
+				require.NotNil(t, resp)
+				assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			} else {
+				assert.Zero(t, hits.Load())
+				assert.Nil(t, resp)
+			}
+		})
+	}
+}
+
+func TestSend_RecordsContractCoverage(t *testing.T) {
+	httpClient, _ := newContractClient(t, false)
+
+	NewCall[any, any](&mockStepCtx{}, httpClient).POST("/users").
+		RequestBodyMap(map[string]interface{}{"name": "John"}).
+		Send()
+
+	report := httpClient.ContractValidator.Coverage()
+	assert.Equal(t, 1, report.Summary.TestedOperations, "calls are counted without ExpectMatchesContract")
+	require.Len(t, report.Operations, 1)
+	assert.Equal(t, []contract.ResponseCoverage{{Code: "201", Documented: true, Tested: true, Calls: 1}}, report.Operations[0].Responses)
+}
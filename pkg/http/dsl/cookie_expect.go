@@ -0,0 +1,137 @@
+package dsl
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/client"
+)
+
+// CookieAttributes are the Set-Cookie attributes checked by ExpectCookieAttributes.
+// Empty Path and Domain and zero MaxAge and SameSite are not checked;
+// HttpOnly and Secure are checked only when true.
+type CookieAttributes struct {
+	Path     string
+	Domain   string
+	MaxAge   int
+	HttpOnly bool
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// ExpectCookie expects the response to set cookie name to value.
+// An empty value only checks that the cookie is set.
+func (c *Call[TReq, TResp]) ExpectCookie(name, value string) *Call[TReq, TResp] {
+	c.addExpectation(makeCookieExpectation(name, value))
+	return c
+}
+
+// ExpectCookieAttributes expects the response to set cookie name with the given attributes.
+func (c *Call[TReq, TResp]) ExpectCookieAttributes(name string, attrs CookieAttributes) *Call[TReq, TResp] {
+	c.addExpectation(makeCookieAttributesExpectation(name, attrs))
+	return c
+}
+
+func makeCookieExpectation(name, value string) *expect.Expectation[*client.Response[any]] {
+	expName := fmt.Sprintf("Expect cookie %s", name)
+	if value != "" {
+		expName = fmt.Sprintf("Expect cookie %s=%s", name, value)
+	}
+	return expect.New(
+		expName,
+		func(err error, resp *client.Response[any]) polling.CheckResult {
+			cookie, res, ok := responseCookie(err, resp, name)
+			if !ok {
+				return res
+			}
+			// The actual value is left out of the reason: it is usually a session id
+			// and cookie values are masked everywhere else in HTTP reports.
+			if value != "" && cookie.Value != value {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Cookie %s is set with a different value", name),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Response[any]](expName),
+	)
+}
+
+func makeCookieAttributesExpectation(name string, attrs CookieAttributes) *expect.Expectation[*client.Response[any]] {
+	expName := fmt.Sprintf("Expect cookie %s attributes", name)
+	return expect.New(
+		expName,
+		func(err error, resp *client.Response[any]) polling.CheckResult {
+			cookie, res, ok := responseCookie(err, resp, name)
+			if !ok {
+				return res
+			}
+
+			var mismatches []string
+			if attrs.Path != "" && cookie.Path != attrs.Path {
+				mismatches = append(mismatches, fmt.Sprintf("Path: expected %q, got %q", attrs.Path, cookie.Path))
+			}
+			if attrs.Domain != "" && !strings.EqualFold(strings.TrimPrefix(cookie.Domain, "."), strings.TrimPrefix(attrs.Domain, ".")) {
+				mismatches = append(mismatches, fmt.Sprintf("Domain: expected %q, got %q", attrs.Domain, cookie.Domain))
+			}
+			if attrs.MaxAge != 0 && cookie.MaxAge != attrs.MaxAge {
+				mismatches = append(mismatches, fmt.Sprintf("Max-Age: expected %d, got %d", attrs.MaxAge, cookie.MaxAge))
+			}
+			if attrs.HttpOnly && !cookie.HttpOnly {
+				mismatches = append(mismatches, "HttpOnly is not set")
+			}
+			if attrs.Secure && !cookie.Secure {
+				mismatches = append(mismatches, "Secure is not set")
+			}
+			if attrs.SameSite != 0 && cookie.SameSite != attrs.SameSite {
+				mismatches = append(mismatches, fmt.Sprintf("SameSite: expected %s, got %s", sameSiteName(attrs.SameSite), sameSiteName(cookie.SameSite)))
+			}
+
+			if len(mismatches) > 0 {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Cookie %s: %s", name, strings.Join(mismatches, "; ")),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Response[any]](expName),
+	)
+}
+
+// responseCookie finds the Set-Cookie named name after the usual response pre-checks.
+func responseCookie(err error, resp *client.Response[any], name string) (*http.Cookie, polling.CheckResult, bool) {
+	if res, ok := preCheck(err, resp); !ok {
+		return nil, res, false
+	}
+	cookie := resp.Cookie(name)
+	if cookie == nil {
+		return nil, polling.CheckResult{
+			Ok:        false,
+			Retryable: true,
+			Reason:    fmt.Sprintf("Response does not set cookie %s", name),
+		}, false
+	}
+	return cookie, polling.CheckResult{}, true
+}
+
+func sameSiteName(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteDefaultMode:
+		return "Default"
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return "unset"
+	}
+}
@@ -0,0 +1,79 @@
+package dsl
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/http/client"
+)
+
+func cookieResponse(setCookies ...string) *client.Response[any] {
+	return &client.Response[any]{StatusCode: 200, Headers: http.Header{"Set-Cookie": setCookies}}
+}
+
+func TestExpectCookie(t *testing.T) {
+	resp := cookieResponse("JSESSIONID=abc123; Path=/; HttpOnly", "lang=en")
+
+	tests := []struct {
+		name         string
+		cookie       string
+		value        string
+		resp         *client.Response[any]
+		wantOk       bool
+		wantContains string
+	}{
+		{name: "value matches", cookie: "lang", value: "en", resp: resp, wantOk: true},
+		{name: "presence only", cookie: "JSESSIONID", resp: resp, wantOk: true},
+		{name: "value mismatches", cookie: "JSESSIONID", value: "other", resp: resp, wantContains: "Cookie JSESSIONID is set with a different value"},
+		{name: "cookie missing", cookie: "token", resp: resp, wantContains: "does not set cookie token"},
+		{name: "network error", cookie: "lang", resp: &client.Response[any]{NetworkError: "timeout"}, wantContains: "Network error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := makeCookieExpectation(tt.cookie, tt.value).Check(nil, tt.resp)
+
+			assert.Equal(t, tt.wantOk, res.Ok)
+			if !tt.wantOk {
+				assert.True(t, res.Retryable)
+				assert.Contains(t, res.Reason, tt.wantContains)
+				assert.NotContains(t, res.Reason, "abc123", "the actual cookie value is not reported")
+			}
+		})
+	}
+}
+
+func TestExpectCookieAttributes(t *testing.T) {
+	resp := cookieResponse("SESSION=abc; Path=/app; Domain=.example.com; Max-Age=3600; HttpOnly; SameSite=Strict", "plain=1")
+
+	res := makeCookieAttributesExpectation("SESSION", CookieAttributes{
+		Path:     "/app",
+		Domain:   ".example.com",
+		MaxAge:   3600,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}).Check(nil, resp)
+	assert.True(t, res.Ok, res.Reason)
+
+	res = makeCookieAttributesExpectation("plain", CookieAttributes{Path: "/", HttpOnly: true, Secure: true, SameSite: http.SameSiteLaxMode}).Check(nil, resp)
+	assert.False(t, res.Ok)
+	assert.Contains(t, res.Reason, `Path: expected "/", got ""`)
+	assert.Contains(t, res.Reason, "HttpOnly is not set")
+	assert.Contains(t, res.Reason, "Secure is not set")
+	assert.Contains(t, res.Reason, "SameSite: expected Lax, got unset")
+}
+
+func TestWithSession(t *testing.T) {
+	mockCtx := &mockStepCtx{}
+	sess := WithSession(mockCtx)
+
+	call := NewCall[any, any](sess, newTestClient())
+	assert.Same(t, sess.Jar(), call.req.CookieJar)
+
+	other := NewCall[any, any](mockCtx, newTestClient())
+	assert.Nil(t, other.req.CookieJar)
+
+	assert.NotSame(t, sess.Jar(), WithSession(mockCtx).Jar())
+}
@@ -114,6 +114,31 @@ func (c *Call[TReq, TResp]) ExpectMatchesContract() *Call[TReq, TResp] {
 	return c
 }
 
+// ExpectRequestMatchesContract validates the outgoing request (path, query and header
+// parameters and the JSON body) against its operation in the contract before sending.
+// A request that does not match fails the step and is not sent.
+func (c *Call[TReq, TResp]) ExpectRequestMatchesContract() *Call[TReq, TResp] {
+	if c.sent {
+		c.stepCtx.Break(errors.MethodAfterSend("HTTP", "ExpectRequestMatchesContract"))
+		c.stepCtx.BrokenNow()
+		return c
+	}
+	c.validateRequestContract = true
+	return c
+}
+
+// SkipRequestContractValidation sends the request as is, even when the client validates
+// every request (contractValidateRequests). Use it for negative tests with invalid payloads.
+func (c *Call[TReq, TResp]) SkipRequestContractValidation() *Call[TReq, TResp] {
+	if c.sent {
+		c.stepCtx.Break(errors.MethodAfterSend("HTTP", "SkipRequestContractValidation"))
+		c.stepCtx.BrokenNow()
+		return c
+	}
+	c.skipRequestContract = true
+	return c
+}
+
 func (c *Call[TReq, TResp]) ExpectMatchesSchema(schemaName string) *Call[TReq, TResp] {
 	if c.sent {
 		c.stepCtx.Break(errors.MethodAfterSend("HTTP", "ExpectMatchesSchema"))
@@ -0,0 +1,43 @@
+package dsl
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+// Session is a step context with its own cookie jar. Calls created with a Session
+// share cookies with each other, but not with other tests or the client's jar.
+//
+// Example:
+//
+//	sess := dsl.WithSession(sCtx)
+//	dsl.NewCall[LoginReq, any](sess, env.Legacy).POST("/login").RequestBody(creds).
+//	    ExpectCookie("JSESSIONID", "").Send()
+//	dsl.NewCall[any, Profile](sess, env.Legacy).GET("/profile").ExpectResponseStatus(200).Send()
+type Session struct {
+	provider.StepCtx
+	jar *cookiejar.Jar
+}
+
+// WithSession starts a cookie session scoped to the calls that use the returned context.
+func WithSession(stepCtx provider.StepCtx) *Session {
+	jar, _ := cookiejar.New(nil)
+	return &Session{StepCtx: stepCtx, jar: jar}
+}
+
+// Jar returns the session cookie jar.
+func (s *Session) Jar() http.CookieJar {
+	return s.jar
+}
+
+// Cookies returns the session cookies that would be sent to rawURL.
+func (s *Session) Cookies(rawURL string) []*http.Cookie {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+	return s.jar.Cookies(u)
+}
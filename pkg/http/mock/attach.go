@@ -0,0 +1,75 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/allure"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+)
+
+var mockReporter = allure.NewDefaultReporter()
+
+func attachMockReport(stepCtx provider.StepCtx, v *Verification, result *Received, summary polling.PollingSummary) {
+	report := allure.HTTPMockReportDTO{
+		Pattern: v.pattern.String(),
+		Polling: allure.ToPollingSummaryDTO(summary),
+	}
+
+	if result != nil {
+		report.Total = result.Total
+		for i := range result.Matched {
+			report.Matched = append(report.Matched, toMockRequestDTO(&result.Matched[i]))
+		}
+	}
+
+	for _, received := range v.server.Requests() {
+		if !v.pattern.matches(&received) {
+			report.Others = append(report.Others, toMockRequestDTO(&received))
+		}
+	}
+
+	mockReporter.AttachHTTPMockReport(stepCtx, report)
+}
+
+// toMockRequestDTO renders a journaled request in the HTTP report format.
+func toMockRequestDTO(r *ReceivedRequest) allure.HTTPMockRequestDTO {
+	req := allure.HTTPRequestDTO{
+		Method:      r.Method,
+		Path:        r.Path,
+		QueryParams: flatten(r.Query),
+		Headers:     flatten(r.Headers),
+	}
+	var body any
+	if len(r.Body) > 0 && json.Unmarshal(r.Body, &body) == nil {
+		req.Body = body
+	} else {
+		req.RawBody = r.Body
+	}
+
+	resp := allure.HTTPResponseDTO{StatusCode: http.StatusNotFound, RawBody: []byte("(no stub matched)")}
+	if r.Response != nil {
+		resp = allure.HTTPResponseDTO{
+			StatusCode: r.Response.Status,
+			Headers:    r.Response.Headers,
+			RawBody:    r.Response.Body,
+			Duration:   r.Response.Delay,
+		}
+	}
+
+	return allure.HTTPMockRequestDTO{ReceivedAt: r.ReceivedAt, Request: req, Response: resp}
+}
+
+func flatten(values map[string][]string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(values))
+	for k, v := range values {
+		result[k] = strings.Join(v, ", ")
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+// Server is an in-process HTTP server that answers with registered stubs and
+// journals every request it receives. Point the service under test at URL().
+//
+// Example:
+//
+//	payments, err := mock.New(mock.Config{Addr: "127.0.0.1:18080"})
+//	defer payments.Close()
+//
+//	payments.When().POST("/payments").WithBodyField("amount", 100).
+//	    Respond(201, map[string]any{"id": "pay-1", "status": "accepted"})
+//
+//	// ... exercise the service under test ...
+//
+//	payments.ExpectReceived(sCtx, mock.Request().POST("/payments")).Times(1)
+type Server struct {
+	AsyncConfig config.AsyncConfig
+
+	httpServer *http.Server
+	listener   net.Listener
+
+	mu      sync.Mutex
+	stubs   []*Stub
+	journal []ReceivedRequest
+}
+
+// Config is the mock server configuration.
+type Config = config.MockConfig
+
+// ReceivedRequest is a journaled request and the stub response it was answered with.
+type ReceivedRequest struct {
+	Method     string
+	Path       string
+	Query      url.Values
+	Headers    http.Header
+	Body       []byte
+	ReceivedAt time.Time
+	// Response is nil when no stub matched and the server answered 404.
+	Response *StubResponse
+}
+
+// StubResponse is what a stub answers with.
+type StubResponse struct {
+	Status  int
+	Headers http.Header
+	Body    []byte
+	Delay   time.Duration
+}
+
+// New starts a mock server on cfg.Addr.
+func New(cfg Config) (*Server, error) {
+	addr := cfg.Addr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start HTTP mock on '%s': %w", addr, err)
+	}
+
+	s := &Server{
+		AsyncConfig: cfg.AsyncConfig.WithDefaults(),
+		listener:    listener,
+	}
+	s.httpServer = &http.Server{Handler: http.HandlerFunc(s.handle), ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = s.httpServer.Serve(listener) }()
+
+	return s, nil
+}
+
+// URL returns the base URL of the server, e.g. "http://127.0.0.1:54321".
+func (s *Server) URL() string {
+	return "http://" + s.listener.Addr().String()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.httpServer.Close()
+}
+
+// Reset removes all stubs and clears the request journal.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs = nil
+	s.journal = nil
+}
+
+// Requests returns a copy of the request journal in arrival order.
+func (s *Server) Requests() []ReceivedRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]ReceivedRequest(nil), s.journal...)
+}
+
+// When starts a stub definition; finish it with Respond.
+func (s *Server) When() *RequestPattern {
+	return &RequestPattern{server: s}
+}
+
+func (s *Server) addStub(stub *Stub) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs = append(s.stubs, stub)
+}
+
+// matching returns the journaled requests that match pattern.
+func (s *Server) matching(pattern *RequestPattern) (matched []ReceivedRequest, total int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.journal {
+		if pattern.matches(&s.journal[i]) {
+			matched = append(matched, s.journal[i])
+		}
+	}
+	return matched, len(s.journal)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	received := ReceivedRequest{
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Query:      r.URL.Query(),
+		Headers:    r.Header.Clone(),
+		Body:       body,
+		ReceivedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	// The most recently registered matching stub wins, so tests can override defaults.
+	var resp *StubResponse
+	for i := len(s.stubs) - 1; i >= 0; i-- {
+		if s.stubs[i].pattern.matches(&received) {
+			copied := s.stubs[i].response
+			copied.Headers = copied.Headers.Clone()
+			resp = &copied
+			break
+		}
+	}
+	received.Response = resp
+	s.journal = append(s.journal, received)
+	s.mu.Unlock()
+
+	if resp == nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"error": fmt.Sprintf("no stub matched %s %s", r.Method, r.URL.Path),
+		})
+		return
+	}
+
+	if resp.Delay > 0 {
+		select {
+		case <-time.After(resp.Delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	for key, values := range resp.Headers {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.Status)
+	_, _ = w.Write(resp.Body)
+}
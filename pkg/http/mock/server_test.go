@@ -0,0 +1,125 @@
+package mock
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	s, err := New(Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func send(t *testing.T, s *Server, method, path, body string, headers map[string]string) (int, string, http.Header) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, s.URL()+path, strings.NewReader(body))
+	require.NoError(t, err)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(raw), resp.Header
+}
+
+func TestServer_StubsAndJournal(t *testing.T) {
+	s := newTestServer(t)
+
+	s.When().POST("/payments").Respond(400, "invalid")
+	s.When().POST("/payments").WithBodyField("amount", 100).
+		Respond(201, map[string]any{"id": "pay-1"}).
+		Header("X-Trace", "t1")
+
+	status, body, headers := send(t, s, http.MethodPost, "/payments", `{"amount":100}`, nil)
+	assert.Equal(t, 201, status)
+	assert.JSONEq(t, `{"id":"pay-1"}`, body)
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "t1", headers.Get("X-Trace"))
+
+	status, body, _ = send(t, s, http.MethodPost, "/payments", `{"amount":5}`, nil)
+	assert.Equal(t, 400, status)
+	assert.Equal(t, "invalid", body)
+
+	status, body, _ = send(t, s, http.MethodGet, "/unknown", "", nil)
+	assert.Equal(t, 404, status)
+	assert.Contains(t, body, "no stub matched GET /unknown")
+
+	journal := s.Requests()
+	require.Len(t, journal, 3)
+	assert.Equal(t, `{"amount":100}`, string(journal[0].Body))
+	assert.Equal(t, 201, journal[0].Response.Status)
+	assert.Nil(t, journal[2].Response)
+
+	s.Reset()
+	assert.Empty(t, s.Requests())
+	status, _, _ = send(t, s, http.MethodPost, "/payments", `{"amount":100}`, nil)
+	assert.Equal(t, 404, status)
+}
+
+func TestServer_Delay(t *testing.T) {
+	s := newTestServer(t)
+	s.When().GET("/slow").Respond(204, nil).Delay(100 * time.Millisecond)
+
+	start := time.Now()
+	status, _, _ := send(t, s, http.MethodGet, "/slow", "", nil)
+	assert.Equal(t, 204, status)
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRequestPattern_Matches(t *testing.T) {
+	received := &ReceivedRequest{
+		Method:  http.MethodPut,
+		Path:    "/users/42/roles",
+		Query:   map[string][]string{"dry": {"true"}},
+		Headers: http.Header{"X-Tenant": {"acme"}},
+		Body:    []byte(`{"role":"admin","meta":{"by":7}}`),
+	}
+
+	tests := []struct {
+		name    string
+		pattern *RequestPattern
+		want    bool
+	}{
+		{name: "path param", pattern: Request().PUT("/users/{id}/roles"), want: true},
+		{name: "wildcard", pattern: Request().PUT("/users/*/roles"), want: true},
+		{name: "any method", pattern: Request().Method("", "/users/42/roles"), want: true},
+		{name: "wrong method", pattern: Request().POST("/users/42/roles")},
+		{name: "wrong path length", pattern: Request().PUT("/users/{id}")},
+		{name: "header", pattern: Request().WithHeader("x-tenant", "acme"), want: true},
+		{name: "header mismatch", pattern: Request().WithHeader("X-Tenant", "other")},
+		{name: "query", pattern: Request().WithQueryParam("dry", "true"), want: true},
+		{name: "query missing", pattern: Request().WithQueryParam("force", "")},
+		{name: "body fields", pattern: Request().WithBodyField("role", "admin").WithBodyField("meta.by", 7), want: true},
+		{name: "body field mismatch", pattern: Request().WithBodyField("role", "user")},
+		{name: "body field missing", pattern: Request().WithBodyField("owner", nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pattern.matches(received))
+		})
+	}
+}
+
+func TestRequestPattern_String(t *testing.T) {
+	p := Request().POST("/payments").WithHeader("X-Tenant", "acme").WithQueryParam("b", "2").WithQueryParam("a", "1").WithBodyField("amount", 100)
+	assert.Equal(t, "POST /payments X-Tenant: acme ?a=1&b=2 amount=100", p.String())
+	assert.Equal(t, "ANY *", Request().String())
+}
+
+func TestRespond_RequiresServer(t *testing.T) {
+	assert.Panics(t, func() { Request().GET("/").Respond(200, nil) })
+}
@@ -0,0 +1,220 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/jsonutil"
+)
+
+// RequestPattern matches received requests. Patterns created with Server.When
+// become stubs via Respond; patterns created with Request are used in ExpectReceived.
+// Path segments written as {name} or * match any single segment.
+type RequestPattern struct {
+	server *Server
+
+	method     string
+	path       string
+	headers    map[string]string
+	query      map[string]string
+	bodyFields []bodyField
+}
+
+type bodyField struct {
+	path     string
+	expected any
+}
+
+// Request creates a pattern for ExpectReceived.
+func Request() *RequestPattern {
+	return &RequestPattern{}
+}
+
+func (p *RequestPattern) GET(path string) *RequestPattern {
+	return p.Method(http.MethodGet, path)
+}
+
+func (p *RequestPattern) POST(path string) *RequestPattern {
+	return p.Method(http.MethodPost, path)
+}
+
+func (p *RequestPattern) PUT(path string) *RequestPattern {
+	return p.Method(http.MethodPut, path)
+}
+
+func (p *RequestPattern) PATCH(path string) *RequestPattern {
+	return p.Method(http.MethodPatch, path)
+}
+
+func (p *RequestPattern) DELETE(path string) *RequestPattern {
+	return p.Method(http.MethodDelete, path)
+}
+
+// Method matches the HTTP method and path; an empty method matches any method.
+func (p *RequestPattern) Method(method, path string) *RequestPattern {
+	p.method, p.path = strings.ToUpper(method), path
+	return p
+}
+
+// WithHeader matches requests carrying the header with exactly this value.
+func (p *RequestPattern) WithHeader(key, value string) *RequestPattern {
+	if p.headers == nil {
+		p.headers = make(map[string]string)
+	}
+	p.headers[http.CanonicalHeaderKey(key)] = value
+	return p
+}
+
+// WithQueryParam matches requests with the query parameter set to value.
+func (p *RequestPattern) WithQueryParam(key, value string) *RequestPattern {
+	if p.query == nil {
+		p.query = make(map[string]string)
+	}
+	p.query[key] = value
+	return p
+}
+
+// WithBodyField matches JSON bodies whose field at path (GJSON syntax) equals expected.
+func (p *RequestPattern) WithBodyField(path string, expected any) *RequestPattern {
+	p.bodyFields = append(p.bodyFields, bodyField{path: path, expected: expected})
+	return p
+}
+
+// Respond registers the pattern as a stub answering with status and body:
+// nil for no body, string or []byte as is, anything else as JSON.
+func (p *RequestPattern) Respond(status int, body any) *Stub {
+	if p.server == nil {
+		panic("mock: Respond requires a pattern created with Server.When()")
+	}
+
+	stub := &Stub{
+		server:   p.server,
+		pattern:  p,
+		response: StubResponse{Status: status, Headers: http.Header{}},
+	}
+
+	switch b := body.(type) {
+	case nil:
+	case string:
+		stub.response.Body = []byte(b)
+	case []byte:
+		stub.response.Body = b
+	default:
+		raw, err := json.Marshal(body)
+		if err != nil {
+			panic(fmt.Sprintf("mock: failed to marshal stub body for %s: %v", p, err))
+		}
+		stub.response.Body = raw
+		stub.response.Headers.Set("Content-Type", "application/json")
+	}
+
+	p.server.addStub(stub)
+	return stub
+}
+
+// String describes the pattern, e.g. "POST /payments amount=100".
+func (p *RequestPattern) String() string {
+	method := p.method
+	if method == "" {
+		method = "ANY"
+	}
+	path := p.path
+	if path == "" {
+		path = "*"
+	}
+
+	parts := []string{method + " " + path}
+	parts = append(parts, sortedPairs(p.headers, ": ")...)
+	if query := sortedPairs(p.query, "="); len(query) > 0 {
+		parts = append(parts, "?"+strings.Join(query, "&"))
+	}
+	for _, f := range p.bodyFields {
+		parts = append(parts, fmt.Sprintf("%s=%v", f.path, f.expected))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (p *RequestPattern) matches(req *ReceivedRequest) bool {
+	if p.method != "" && p.method != req.Method {
+		return false
+	}
+	if p.path != "" && !pathMatches(p.path, req.Path) {
+		return false
+	}
+	for key, value := range p.headers {
+		if req.Headers.Get(key) != value {
+			return false
+		}
+	}
+	for key, value := range p.query {
+		if !req.Query.Has(key) || req.Query.Get(key) != value {
+			return false
+		}
+	}
+	for _, f := range p.bodyFields {
+		res, err := jsonutil.GetField(req.Body, f.path)
+		if err != nil || !res.Exists() {
+			return false
+		}
+		if ok, _ := jsonutil.Compare(res, f.expected); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func pathMatches(pattern, path string) bool {
+	patternSegs := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegs := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegs) != len(pathSegs) {
+		return false
+	}
+	for i, seg := range patternSegs {
+		if seg == "*" || (strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}")) {
+			if pathSegs[i] == "" {
+				return false
+			}
+			continue
+		}
+		if seg != pathSegs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedPairs(m map[string]string, sep string) []string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+sep+v)
+	}
+	sort.Strings(pairs)
+	return pairs
+}
+
+// Stub is a registered response. Its setters may be chained right after Respond.
+type Stub struct {
+	server   *Server
+	pattern  *RequestPattern
+	response StubResponse
+}
+
+// Header adds a response header.
+func (s *Stub) Header(key, value string) *Stub {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+	s.response.Headers.Add(key, value)
+	return s
+}
+
+// Delay makes the stub wait before answering, e.g. to test client timeouts.
+func (s *Stub) Delay(d time.Duration) *Stub {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+	s.response.Delay = d
+	return s
+}
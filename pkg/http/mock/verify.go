@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/journal"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+)
+
+// Verification checks how many journaled requests match a pattern.
+// In async mode (AsyncStep) the journal is polled until the count matches.
+type Verification struct {
+	stepCtx provider.StepCtx
+	server  *Server
+	pattern *RequestPattern
+	ctx     context.Context
+}
+
+// Received is the journal state observed by a verification.
+type Received = journal.Received[ReceivedRequest]
+
+// ExpectReceived starts a verification of requests matching pattern.
+//
+// Example:
+//
+//	payments.ExpectReceived(sCtx, mock.Request().POST("/payments").WithBodyField("amount", 100)).Times(2)
+func (s *Server) ExpectReceived(stepCtx provider.StepCtx, pattern *RequestPattern) *Verification {
+	return &Verification{
+		stepCtx: stepCtx,
+		server:  s,
+		pattern: pattern,
+		ctx:     context.Background(),
+	}
+}
+
+// Times expects exactly n matching requests and returns them.
+func (v *Verification) Times(n int) []ReceivedRequest {
+	return v.verify(journal.Exactly(n))
+}
+
+// Once expects exactly one matching request.
+func (v *Verification) Once() []ReceivedRequest {
+	return v.Times(1)
+}
+
+// AtLeast expects n or more matching requests and returns them.
+func (v *Verification) AtLeast(n int) []ReceivedRequest {
+	return v.verify(journal.AtLeast(n))
+}
+
+// Never expects no matching request at the time of the check.
+func (v *Verification) Never() {
+	v.verify(journal.None())
+}
+
+func (v *Verification) verify(count journal.Count) []ReceivedRequest {
+	v.validate()
+
+	return journal.Verifier[ReceivedRequest]{
+		Step:        fmt.Sprintf("HTTP mock received %s", v.pattern),
+		Noun:        "request",
+		AsyncConfig: v.server.AsyncConfig,
+		Read: func() *Received {
+			matched, total := v.server.matching(v.pattern)
+			return &Received{Matched: matched, Total: total}
+		},
+		Attach: func(stepCtx provider.StepCtx, result *Received, summary polling.PollingSummary) {
+			attachMockReport(stepCtx, v, result, summary)
+		},
+	}.Verify(v.ctx, v.stepCtx, count)
+}
+
+func (v *Verification) validate() {
+	val := validation.New(v.stepCtx, "HTTP mock")
+	val.RequireNotNil(v.server, "HTTP mock server")
+	val.RequireNotNil(v.pattern, "request pattern")
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMockRequestDTO(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	dto := toMockRequestDTO(&ReceivedRequest{
+		Method:     http.MethodPost,
+		Path:       "/payments",
+		Query:      map[string][]string{"a": {"1", "2"}},
+		Headers:    http.Header{"Authorization": {"Bearer secret"}},
+		Body:       []byte(`{"amount":100}`),
+		ReceivedAt: at,
+		Response:   &StubResponse{Status: 201, Body: []byte(`{"id":"pay-1"}`)},
+	})
+
+	assert.Equal(t, at, dto.ReceivedAt)
+	assert.Equal(t, map[string]string{"a": "1, 2"}, dto.Request.QueryParams)
+	assert.Equal(t, map[string]any{"amount": float64(100)}, dto.Request.Body)
+	assert.Equal(t, 201, dto.Response.StatusCode)
+
+	unmatched := toMockRequestDTO(&ReceivedRequest{Method: http.MethodGet, Path: "/x", Body: []byte("plain")})
+	assert.Equal(t, []byte("plain"), unmatched.Request.RawBody)
+	require.Equal(t, http.StatusNotFound, unmatched.Response.StatusCode)
+}
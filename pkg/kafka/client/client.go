@@ -3,27 +3,32 @@ package client
 import (
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/gorelov-m-v/go-test-framework/internal/kafka/consumer"
+	"github.com/gorelov-m-v/go-test-framework/internal/kafka/producer"
 	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/codec"
 )
 
 type Config struct {
-	AsyncConfig              config.AsyncConfig     `mapstructure:"async" yaml:"async" json:"async"`
-	BootstrapServers         []string               `mapstructure:"bootstrapServers" yaml:"bootstrapServers" json:"bootstrapServers"`
-	GroupID                  string                 `mapstructure:"groupId" yaml:"groupId" json:"groupId"`
-	Topics                   []string               `mapstructure:"topics" yaml:"topics" json:"topics"`
-	TopicPrefix              string                 `mapstructure:"topicPrefix" yaml:"topicPrefix" json:"topicPrefix"`
-	BufferSize               int                    `mapstructure:"bufferSize" yaml:"bufferSize" json:"bufferSize"`
-	FindMessageTimeout       time.Duration          `mapstructure:"findMessageTimeout" yaml:"findMessageTimeout" json:"findMessageTimeout"`
-	FindMessageSleepInterval time.Duration          `mapstructure:"findMessageSleepInterval" yaml:"findMessageSleepInterval" json:"findMessageSleepInterval"`
-	UniqueDuplicateWindowMs  int64                  `mapstructure:"uniqueDuplicateWindowMs" yaml:"uniqueDuplicateWindowMs" json:"uniqueDuplicateWindowMs"`
-	WarmupTimeout            time.Duration          `mapstructure:"warmupTimeout" yaml:"warmupTimeout" json:"warmupTimeout"`
-	StartFromNewest          bool                   `mapstructure:"startFromNewest" yaml:"startFromNewest" json:"startFromNewest"`
-	SkipExisting             bool                   `mapstructure:"skipExisting" yaml:"skipExisting" json:"skipExisting"`
-	Version                  string                 `mapstructure:"version" yaml:"version" json:"version"`
-	SaramaConfig             map[string]interface{} `mapstructure:"saramaConfig" yaml:"saramaConfig" json:"saramaConfig"`
+	AsyncConfig              config.AsyncConfig         `mapstructure:"async" yaml:"async" json:"async"`
+	BootstrapServers         []string                   `mapstructure:"bootstrapServers" yaml:"bootstrapServers" json:"bootstrapServers"`
+	GroupID                  string                     `mapstructure:"groupId" yaml:"groupId" json:"groupId"`
+	Topics                   []string                   `mapstructure:"topics" yaml:"topics" json:"topics"`
+	TopicPrefix              string                     `mapstructure:"topicPrefix" yaml:"topicPrefix" json:"topicPrefix"`
+	BufferSize               int                        `mapstructure:"bufferSize" yaml:"bufferSize" json:"bufferSize"`
+	FindMessageTimeout       time.Duration              `mapstructure:"findMessageTimeout" yaml:"findMessageTimeout" json:"findMessageTimeout"`
+	FindMessageSleepInterval time.Duration              `mapstructure:"findMessageSleepInterval" yaml:"findMessageSleepInterval" json:"findMessageSleepInterval"`
+	UniqueDuplicateWindowMs  int64                      `mapstructure:"uniqueDuplicateWindowMs" yaml:"uniqueDuplicateWindowMs" json:"uniqueDuplicateWindowMs"`
+	WarmupTimeout            time.Duration              `mapstructure:"warmupTimeout" yaml:"warmupTimeout" json:"warmupTimeout"`
+	StartFromNewest          bool                       `mapstructure:"startFromNewest" yaml:"startFromNewest" json:"startFromNewest"`
+	SkipExisting             bool                       `mapstructure:"skipExisting" yaml:"skipExisting" json:"skipExisting"`
+	Version                  string                     `mapstructure:"version" yaml:"version" json:"version"`
+	SaramaConfig             map[string]interface{}     `mapstructure:"saramaConfig" yaml:"saramaConfig" json:"saramaConfig"`
+	Codecs                   []codec.Config             `mapstructure:"codecs" yaml:"codecs" json:"codecs"`
+	SchemaRegistry           codec.SchemaRegistryConfig `mapstructure:"schemaRegistry" yaml:"schemaRegistry" json:"schemaRegistry"`
 }
 
 func DefaultConfig() Config {
@@ -65,8 +70,17 @@ type Client struct {
 	buffer             MessageBufferInterface
 	backgroundConsumer BackgroundConsumerInterface
 	defaultTimeout     time.Duration
+	pollInterval       time.Duration
 	uniqueWindow       time.Duration
 	AsyncConfig        config.AsyncConfig
+
+	producerCfg  producer.ProducerConfig
+	producer     ProducerInterface
+	producerOnce sync.Once
+	producerErr  error
+
+	codecsMu sync.RWMutex
+	codecs   map[string]codec.Codec
 }
 
 func New(cfg Config) (*Client, error) {
@@ -81,6 +95,11 @@ func New(cfg Config) (*Client, error) {
 		fullTopics[i] = cfg.TopicPrefix + topic
 	}
 
+	codecs, err := buildCodecs(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	buffer := consumer.NewMessageBuffer(fullTopics, cfg.BufferSize)
 
 	consumerCfg := consumer.ConsumerConfig{
@@ -107,8 +126,15 @@ func New(cfg Config) (*Client, error) {
 		buffer:             buffer,
 		backgroundConsumer: backgroundConsumer,
 		defaultTimeout:     cfg.FindMessageTimeout,
+		pollInterval:       cfg.FindMessageSleepInterval,
 		uniqueWindow:       time.Duration(cfg.UniqueDuplicateWindowMs) * time.Millisecond,
 		AsyncConfig:        cfg.AsyncConfig,
+		producerCfg: producer.ProducerConfig{
+			BootstrapServers: cfg.BootstrapServers,
+			Version:          cfg.Version,
+			SaramaConfig:     cfg.SaramaConfig,
+		},
+		codecs: codecs,
 	}
 
 	// Warmup: wait for consumer to join group and be ready
@@ -125,16 +151,27 @@ func New(cfg Config) (*Client, error) {
 }
 
 func (c *Client) Close() error {
+	var consumerErr error
 	if c.backgroundConsumer != nil {
-		return c.backgroundConsumer.Stop()
+		consumerErr = c.backgroundConsumer.Stop()
+	}
+	if c.producer != nil {
+		if err := c.producer.Close(); err != nil && consumerErr == nil {
+			return err
+		}
 	}
-	return nil
+	return consumerErr
 }
 
 func (c *Client) GetDefaultTimeout() time.Duration {
 	return c.defaultTimeout
 }
 
+// GetPollInterval returns how often the buffer is re-checked while watching a topic (findMessageSleepInterval).
+func (c *Client) GetPollInterval() time.Duration {
+	return c.pollInterval
+}
+
 func (c *Client) GetUniqueWindow() time.Duration {
 	return c.uniqueWindow
 }
@@ -151,6 +188,73 @@ func (c *Client) GetTopicPrefix() string {
 	return c.topicPrefix
 }
 
+// GetCodec returns the value codec configured for the full topic name, JSON by default.
+func (c *Client) GetCodec(topicName string) codec.Codec {
+	c.codecsMu.RLock()
+	defer c.codecsMu.RUnlock()
+	if cd, ok := c.codecs[topicName]; ok {
+		return cd
+	}
+	return codec.JSON()
+}
+
+// RegisterCodec sets the value codec for a topic (name without topicPrefix, as in config).
+// Use it for codecs that cannot be described in config, e.g. codec.NewProtobuf with a generated type.
+func (c *Client) RegisterCodec(topicName string, cd codec.Codec) {
+	c.codecsMu.Lock()
+	defer c.codecsMu.Unlock()
+	if c.codecs == nil {
+		c.codecs = make(map[string]codec.Codec)
+	}
+	c.codecs[c.topicPrefix+topicName] = cd
+}
+
+func buildCodecs(cfg Config) (map[string]codec.Codec, error) {
+	codecs := make(map[string]codec.Codec, len(cfg.Codecs))
+	if len(cfg.Codecs) == 0 {
+		return codecs, nil
+	}
+
+	var registry codec.SchemaRegistry
+	if cfg.SchemaRegistry.URL != "" {
+		r, err := codec.NewSchemaRegistry(cfg.SchemaRegistry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create schema registry client: %w", err)
+		}
+		registry = r
+	}
+
+	for _, codecCfg := range cfg.Codecs {
+		if codecCfg.Topic == "" {
+			return nil, fmt.Errorf("codec config without 'topic' in kafka config")
+		}
+		cd, err := codec.New(codecCfg, registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create codec for topic '%s': %w", codecCfg.Topic, err)
+		}
+		codecs[cfg.TopicPrefix+codecCfg.Topic] = cd
+	}
+
+	return codecs, nil
+}
+
+// GetProducer returns the client's sync producer, connecting it on first use
+// so that consume-only test runs never open a producer connection.
+func (c *Client) GetProducer() (ProducerInterface, error) {
+	c.producerOnce.Do(func() {
+		if c.producer != nil {
+			return
+		}
+		p, err := producer.NewSyncProducer(c.producerCfg)
+		if err != nil {
+			c.producerErr = fmt.Errorf("failed to create producer: %w", err)
+			return
+		}
+		c.producer = p
+	})
+	return c.producer, c.producerErr
+}
+
 // WaitReady blocks until the consumer has joined the group and is ready to consume.
 // This should be called before running tests to ensure Kafka messages can be received.
 func (c *Client) WaitReady(timeout time.Duration) error {
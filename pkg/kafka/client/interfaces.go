@@ -5,6 +5,8 @@ import "github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 type KafkaMessage = types.KafkaMessage
 type MessageBufferInterface = types.MessageBufferInterface
 type BackgroundConsumerInterface = types.BackgroundConsumerInterface
+type ProducerMessage = types.ProducerMessage
+type ProducerInterface = types.ProducerInterface
 
 type KafkaSetter interface {
 	SetKafka(c *Client)
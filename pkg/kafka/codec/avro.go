@@ -0,0 +1,109 @@
+package codec
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+// avroCodec decodes Avro binary values into standard JSON
+// (unions are not wrapped into {"type": value} objects).
+type avroCodec struct {
+	topic     string
+	confluent bool
+	local     *goavro.Codec
+	registry  SchemaRegistry
+
+	mu   sync.Mutex
+	byID map[int]*goavro.Codec
+}
+
+func newAvroCodec(cfg Config, registry SchemaRegistry) (*avroCodec, error) {
+	c := &avroCodec{
+		topic:     cfg.Topic,
+		confluent: cfg.isConfluent(),
+		registry:  registry,
+		byID:      make(map[int]*goavro.Codec),
+	}
+
+	if cfg.SchemaFile != "" {
+		schema, err := os.ReadFile(cfg.SchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Avro schema for topic '%s': %w", cfg.Topic, err)
+		}
+		local, err := goavro.NewCodecForStandardJSONFull(string(schema))
+		if err != nil {
+			return nil, fmt.Errorf("invalid Avro schema %s: %w", cfg.SchemaFile, err)
+		}
+		c.local = local
+	}
+
+	if c.local == nil && (!c.confluent || registry == nil) {
+		return nil, fmt.Errorf("avro codec for topic '%s' needs either 'schemaFile' or wireFormat 'confluent' with a configured schemaRegistry", cfg.Topic)
+	}
+
+	return c, nil
+}
+
+func (c *avroCodec) Name() string {
+	if c.confluent {
+		return TypeAvro + "/" + WireFormatConfluent
+	}
+	return TypeAvro
+}
+
+func (c *avroCodec) ToJSON(data []byte) ([]byte, error) {
+	payload := data
+	schemaCodec := c.local
+
+	if c.confluent {
+		schemaID, p, err := ParseConfluentHeader(data)
+		if err != nil {
+			return nil, err
+		}
+		payload = p
+
+		if c.registry != nil {
+			schemaCodec, err = c.codecByID(schemaID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	native, _, err := schemaCodec.NativeFromBinary(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Avro value: %w", err)
+	}
+
+	textual, err := schemaCodec.TextualFromNative(nil, native)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert Avro value to JSON: %w", err)
+	}
+
+	return textual, nil
+}
+
+func (c *avroCodec) codecByID(id int) (*goavro.Codec, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.byID[id]; ok {
+		return cached, nil
+	}
+
+	schema, err := c.registry.SchemaByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaCodec, err := goavro.NewCodecForStandardJSONFull(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Avro schema %d from registry: %w", id, err)
+	}
+
+	c.byID[id] = schemaCodec
+	return schemaCodec, nil
+}
@@ -0,0 +1,92 @@
+package codec
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	TypeJSON     = "json"
+	TypeAvro     = "avro"
+	TypeProtobuf = "protobuf"
+
+	WireFormatRaw       = "raw"
+	WireFormatConfluent = "confluent"
+)
+
+// Codec converts a raw Kafka message value into the JSON view
+// that filters (With, WithContains) and expectations (ExpectField*) operate on.
+type Codec interface {
+	Name() string
+	ToJSON(data []byte) ([]byte, error)
+}
+
+// Config selects the value codec of one topic.
+//
+// Fields:
+//   - Topic: Topic name without topicPrefix
+//   - Type: json (default), avro or protobuf
+//   - WireFormat: raw (default) or confluent (magic byte + schema ID header)
+//   - SchemaFile: Path to a local .avsc file (avro)
+//   - MessageType: Fully-qualified protobuf message name, e.g. "player.v1.PlayerCreated" (protobuf)
+type Config struct {
+	Topic       string `mapstructure:"topic" yaml:"topic" json:"topic"`
+	Type        string `mapstructure:"type" yaml:"type" json:"type"`
+	WireFormat  string `mapstructure:"wireFormat" yaml:"wireFormat" json:"wireFormat"`
+	SchemaFile  string `mapstructure:"schemaFile" yaml:"schemaFile" json:"schemaFile"`
+	MessageType string `mapstructure:"messageType" yaml:"messageType" json:"messageType"`
+}
+
+func (c Config) isConfluent() bool {
+	return strings.EqualFold(c.WireFormat, WireFormatConfluent)
+}
+
+// New builds the codec described by cfg.
+// registry may be nil unless cfg is an Avro codec in Confluent wire format without a local schema file.
+func New(cfg Config, registry SchemaRegistry) (Codec, error) {
+	switch strings.ToLower(cfg.Type) {
+	case "", TypeJSON:
+		if cfg.isConfluent() {
+			return &confluentJSONCodec{}, nil
+		}
+		return JSON(), nil
+	case TypeAvro:
+		return newAvroCodec(cfg, registry)
+	case TypeProtobuf:
+		return newProtobufCodec(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported codec type %q for topic '%s' (supported: json, avro, protobuf)", cfg.Type, cfg.Topic)
+	}
+}
+
+type jsonCodec struct{}
+
+var defaultJSON Codec = jsonCodec{}
+
+// JSON returns the default codec that treats message values as JSON.
+func JSON() Codec {
+	return defaultJSON
+}
+
+func (jsonCodec) Name() string {
+	return TypeJSON
+}
+
+func (jsonCodec) ToJSON(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// confluentJSONCodec strips the schema-registry header from JSON Schema payloads.
+type confluentJSONCodec struct{}
+
+func (confluentJSONCodec) Name() string {
+	return TypeJSON + "/" + WireFormatConfluent
+}
+
+func (confluentJSONCodec) ToJSON(data []byte) ([]byte, error) {
+	_, payload, err := ParseConfluentHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
@@ -0,0 +1,202 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const playerSchema = `{
+  "type": "record",
+  "name": "Player",
+  "fields": [
+    {"name": "id", "type": "string"},
+    {"name": "level", "type": "int"},
+    {"name": "nickname", "type": ["null", "string"], "default": null}
+  ]
+}`
+
+func encodeAvro(t *testing.T, native map[string]any) []byte {
+	t.Helper()
+	c, err := goavro.NewCodecForStandardJSONFull(playerSchema)
+	require.NoError(t, err)
+	data, err := c.BinaryFromNative(nil, native)
+	require.NoError(t, err)
+	return data
+}
+
+func confluentFrame(schemaID int, payload []byte) []byte {
+	header := make([]byte, confluentHeaderSize)
+	binary.BigEndian.PutUint32(header[1:], uint32(schemaID))
+	return append(header, payload...)
+}
+
+func writeSchemaFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "player.avsc")
+	require.NoError(t, os.WriteFile(path, []byte(playerSchema), 0o600))
+	return path
+}
+
+func TestNew_DefaultIsJSON(t *testing.T) {
+	c, err := New(Config{Topic: "events"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, TypeJSON, c.Name())
+
+	out, err := c.ToJSON([]byte(`{"id": 1}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": 1}`, string(out))
+}
+
+func TestNew_UnsupportedType(t *testing.T) {
+	_, err := New(Config{Topic: "events", Type: "thrift"}, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported codec type")
+}
+
+func TestConfluentJSON_StripsHeader(t *testing.T) {
+	c, err := New(Config{Topic: "events", WireFormat: WireFormatConfluent}, nil)
+	require.NoError(t, err)
+
+	out, err := c.ToJSON(confluentFrame(7, []byte(`{"id": 1}`)))
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"id": 1}`, string(out))
+}
+
+func TestParseConfluentHeader(t *testing.T) {
+	id, payload, err := ParseConfluentHeader(confluentFrame(42, []byte("abc")))
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+	assert.Equal(t, []byte("abc"), payload)
+}
+
+func TestParseConfluentHeader_TooShort(t *testing.T) {
+	_, _, err := ParseConfluentHeader([]byte{0, 1})
+
+	assert.Error(t, err)
+}
+
+func TestParseConfluentHeader_WrongMagicByte(t *testing.T) {
+	_, _, err := ParseConfluentHeader([]byte{1, 0, 0, 0, 1, 2})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "magic byte")
+}
+
+func TestAvro_LocalSchemaFile(t *testing.T) {
+	c, err := New(Config{Topic: "players", Type: TypeAvro, SchemaFile: writeSchemaFile(t)}, nil)
+	require.NoError(t, err)
+
+	data := encodeAvro(t, map[string]any{"id": "p1", "level": 5, "nickname": goavro.Union("string", "neo")})
+	out, err := c.ToJSON(data)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "p1", "level": 5, "nickname": "neo"}`, string(out))
+}
+
+func TestAvro_MissingSchema(t *testing.T) {
+	_, err := New(Config{Topic: "players", Type: TypeAvro}, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "schemaFile")
+}
+
+func TestAvro_InvalidPayload(t *testing.T) {
+	c, err := New(Config{Topic: "players", Type: TypeAvro, SchemaFile: writeSchemaFile(t)}, nil)
+	require.NoError(t, err)
+
+	_, err = c.ToJSON([]byte{0xff})
+
+	assert.Error(t, err)
+}
+
+func TestAvro_SchemaRegistry(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/schemas/ids/3" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"schema": playerSchema})
+	}))
+	defer server.Close()
+
+	registry, err := NewSchemaRegistry(SchemaRegistryConfig{URL: server.URL})
+	require.NoError(t, err)
+
+	c, err := New(Config{Topic: "players", Type: TypeAvro, WireFormat: WireFormatConfluent}, registry)
+	require.NoError(t, err)
+	assert.Equal(t, "avro/confluent", c.Name())
+
+	data := confluentFrame(3, encodeAvro(t, map[string]any{"id": "p2", "level": 1, "nickname": nil}))
+	for i := 0; i < 3; i++ {
+		out, err := c.ToJSON(data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"id": "p2", "level": 1, "nickname": null}`, string(out))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	_, err = c.ToJSON(confluentFrame(4, encodeAvro(t, map[string]any{"id": "p3", "level": 1, "nickname": nil})))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestNewSchemaRegistry_EmptyURL(t *testing.T) {
+	_, err := NewSchemaRegistry(SchemaRegistryConfig{})
+
+	assert.Error(t, err)
+}
+
+func TestProtobuf_Raw(t *testing.T) {
+	c, err := New(Config{Topic: "names", Type: TypeProtobuf, MessageType: "google.protobuf.StringValue"}, nil)
+	require.NoError(t, err)
+
+	data, err := proto.Marshal(wrapperspb.String("neo"))
+	require.NoError(t, err)
+
+	out, err := c.ToJSON(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, `"neo"`, string(out))
+}
+
+func TestProtobuf_Confluent(t *testing.T) {
+	c := NewProtobuf((&wrapperspb.Int64Value{}).ProtoReflect().Type(), true)
+
+	data, err := proto.Marshal(wrapperspb.Int64(42))
+	require.NoError(t, err)
+
+	out, err := c.ToJSON(confluentFrame(1, append([]byte{0}, data...)))
+
+	require.NoError(t, err)
+	assert.Equal(t, `"42"`, string(out))
+}
+
+func TestProtobuf_UnknownMessageType(t *testing.T) {
+	_, err := New(Config{Topic: "names", Type: TypeProtobuf, MessageType: "acme.v1.Unknown"}, nil)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not registered")
+}
+
+func TestProtobuf_MissingMessageType(t *testing.T) {
+	_, err := New(Config{Topic: "names", Type: TypeProtobuf}, nil)
+
+	assert.Error(t, err)
+}
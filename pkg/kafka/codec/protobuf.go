@@ -0,0 +1,81 @@
+package codec
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// protobufCodec decodes values into a generated message type looked up in the
+// global protobuf registry, so the generated package must be imported by the tests.
+type protobufCodec struct {
+	messageType protoreflect.MessageType
+	confluent   bool
+	marshaler   protojson.MarshalOptions
+}
+
+func newProtobufCodec(cfg Config) (Codec, error) {
+	if cfg.MessageType == "" {
+		return nil, fmt.Errorf("protobuf codec for topic '%s' needs 'messageType'", cfg.Topic)
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(cfg.MessageType))
+	if err != nil {
+		return nil, fmt.Errorf("protobuf message type %q for topic '%s' is not registered (import the generated package): %w",
+			cfg.MessageType, cfg.Topic, err)
+	}
+
+	return NewProtobuf(messageType, cfg.isConfluent()), nil
+}
+
+// NewProtobuf creates a codec for a generated message type, e.g.
+// codec.NewProtobuf((&playerv1.PlayerCreated{}).ProtoReflect().Type(), false).
+// JSON field names are the proto field names, and unset fields are rendered with zero values.
+func NewProtobuf(messageType protoreflect.MessageType, confluent bool) Codec {
+	return &protobufCodec{
+		messageType: messageType,
+		confluent:   confluent,
+		marshaler: protojson.MarshalOptions{
+			UseProtoNames:   true,
+			EmitUnpopulated: true,
+		},
+	}
+}
+
+func (c *protobufCodec) Name() string {
+	name := TypeProtobuf + "(" + string(c.messageType.Descriptor().FullName()) + ")"
+	if c.confluent {
+		return name + "/" + WireFormatConfluent
+	}
+	return name
+}
+
+func (c *protobufCodec) ToJSON(data []byte) ([]byte, error) {
+	payload := data
+
+	if c.confluent {
+		_, p, err := ParseConfluentHeader(data)
+		if err != nil {
+			return nil, err
+		}
+		payload, err = skipProtobufMessageIndexes(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msg := c.messageType.New().Interface()
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, fmt.Errorf("failed to decode protobuf %s: %w", c.messageType.Descriptor().FullName(), err)
+	}
+
+	jsonBytes, err := c.marshaler.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert protobuf %s to JSON: %w", c.messageType.Descriptor().FullName(), err)
+	}
+
+	return jsonBytes, nil
+}
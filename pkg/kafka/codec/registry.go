@@ -0,0 +1,116 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SchemaRegistry resolves schemas referenced by ID in Confluent-framed messages.
+type SchemaRegistry interface {
+	SchemaByID(id int) (string, error)
+}
+
+type SchemaRegistryConfig struct {
+	URL      string        `mapstructure:"url" yaml:"url" json:"url"`
+	Username string        `mapstructure:"username" yaml:"username" json:"username"`
+	Password string        `mapstructure:"password" yaml:"password" json:"password"`
+	Timeout  time.Duration `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+// HTTPSchemaRegistry is a minimal Confluent Schema Registry REST client.
+// Schemas are immutable per ID, so every ID is fetched at most once.
+type HTTPSchemaRegistry struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+
+	mu    sync.RWMutex
+	cache map[int]string
+}
+
+func NewSchemaRegistry(cfg SchemaRegistryConfig) (*HTTPSchemaRegistry, error) {
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, fmt.Errorf("schema registry URL is empty")
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &HTTPSchemaRegistry{
+		baseURL:    strings.TrimRight(cfg.URL, "/"),
+		username:   cfg.Username,
+		password:   cfg.Password,
+		httpClient: &http.Client{Timeout: timeout},
+		cache:      make(map[int]string),
+	}, nil
+}
+
+type schemaByIDResponse struct {
+	Schema string `json:"schema"`
+}
+
+func (r *HTTPSchemaRegistry) SchemaByID(id int) (string, error) {
+	r.mu.RLock()
+	schema, ok := r.cache[id]
+	r.mu.RUnlock()
+	if ok {
+		return schema, nil
+	}
+
+	schema, err := r.fetch(id)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.cache[id] = schema
+	r.mu.Unlock()
+
+	return schema, nil
+}
+
+func (r *HTTPSchemaRegistry) fetch(id int) (string, error) {
+	url := fmt.Sprintf("%s/schemas/ids/%d", r.baseURL, id)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create schema registry request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.schemaregistry.v1+json")
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch schema %d: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read schema %d: %w", id, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("schema registry returned %d for schema %d: %s", resp.StatusCode, id, strings.TrimSpace(string(body)))
+	}
+
+	var parsed schemaByIDResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse schema %d: %w", id, err)
+	}
+	if parsed.Schema == "" {
+		return "", fmt.Errorf("schema registry returned empty schema for ID %d", id)
+	}
+
+	return parsed.Schema, nil
+}
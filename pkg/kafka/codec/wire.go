@@ -0,0 +1,46 @@
+package codec
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	confluentMagicByte  = 0
+	confluentHeaderSize = 5
+)
+
+// ParseConfluentHeader splits a Confluent Schema Registry framed value
+// (magic byte 0, 4-byte big-endian schema ID) into the schema ID and payload.
+func ParseConfluentHeader(data []byte) (int, []byte, error) {
+	if len(data) < confluentHeaderSize {
+		return 0, nil, fmt.Errorf("value too short for Confluent wire format: %d bytes", len(data))
+	}
+	if data[0] != confluentMagicByte {
+		return 0, nil, fmt.Errorf("unknown magic byte %d, expected %d (Confluent wire format)", data[0], confluentMagicByte)
+	}
+	schemaID := int(binary.BigEndian.Uint32(data[1:confluentHeaderSize]))
+	return schemaID, data[confluentHeaderSize:], nil
+}
+
+// skipProtobufMessageIndexes removes the message-index array that Confluent
+// Protobuf serializers write between the header and the payload.
+func skipProtobufMessageIndexes(payload []byte) ([]byte, error) {
+	count, n := protowire.ConsumeVarint(payload)
+	if n < 0 {
+		return nil, fmt.Errorf("invalid protobuf message indexes: %w", protowire.ParseError(n))
+	}
+	payload = payload[n:]
+
+	for i := int64(0); i < protowire.DecodeZigZag(count); i++ {
+		_, n = protowire.ConsumeVarint(payload)
+		if n < 0 {
+			return nil, fmt.Errorf("invalid protobuf message index %d: %w", i, protowire.ParseError(n))
+		}
+		payload = payload[n:]
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,158 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	kafkaErrors "github.com/gorelov-m-v/go-test-framework/internal/kafka/errors"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
+)
+
+// ExpectNoMessage asserts that no message matching the filters appears in the topic
+// during the client's default timeout (findMessageTimeout).
+// The buffer is watched for the whole window; the step fails as soon as a matching message shows up.
+//
+// Example:
+//
+//	dsl.Consume[topics.PaymentEvents](sCtx, kafkaClient).
+//	    With("paymentId", rejectedPaymentID).
+//	    ExpectNoMessage().
+//	    Send()
+func (q *Query[T]) ExpectNoMessage() *Query[T] {
+	q.expectNoMessage = true
+	return q
+}
+
+// ExpectNoMessageFor asserts that no message matching the filters appears in the topic within d.
+func (q *Query[T]) ExpectNoMessageFor(d time.Duration) *Query[T] {
+	q.expectNoMessage = true
+	q.noMessageWindow = d
+	return q
+}
+
+func (q *Query[T]) validateNoMessage() {
+	if !q.expectNoMessage {
+		return
+	}
+	v := validation.New(q.stepCtx, "Kafka")
+	v.Require(q.noMessageWindow >= 0, "ExpectNoMessageFor window must not be negative")
+	v.Require(q.expectedCount == 0 && !q.unique,
+		"ExpectNoMessage cannot be combined with ExpectCount or Unique")
+	v.Require(len(q.expectations) == 0 && len(q.messageExpectations) == 0,
+		"ExpectNoMessage cannot be combined with field expectations: there is no message to check")
+}
+
+const defaultNoMessagePollInterval = 200 * time.Millisecond
+
+func (q *Query[T]) getNoMessageWindow() time.Duration {
+	if q.noMessageWindow > 0 {
+		return q.noMessageWindow
+	}
+	return q.client.GetDefaultTimeout()
+}
+
+func (q *Query[T]) sendExpectNoMessage() *Result[T] {
+	window := q.getNoMessageWindow()
+
+	q.stepCtx.WithNewStep(q.noMessageStepName(window), func(stepCtx provider.StepCtx) {
+		msg, err, summary := q.watchNoMessage(q.ctx, q.client.GetBuffer(), window, q.client.GetPollInterval())
+		q.lastError = err
+		q.found = msg != nil
+		if q.found {
+			q.message = msg
+			q.messageBytes = q.valueJSON(msg)
+		}
+
+		attachKafkaReport(stepCtx, q, summary)
+
+		assertionMode := polling.GetAssertionModeFromStepMode(polling.GetStepMode(stepCtx))
+		if err != nil {
+			polling.NoError(stepCtx, assertionMode, err, err.Error())
+		} else if q.found {
+			errMsg := fmt.Sprintf("Unexpected Kafka message in topic '%s' (partition %d, offset %d). Filters: %v\nMessage: %s",
+				q.topicName, msg.Partition, msg.Offset, q.describeFilters(), string(q.messageBytes))
+			polling.NoError(stepCtx, assertionMode, fmt.Errorf("%s", errMsg), errMsg)
+		}
+
+		q.result = q.buildResult()
+		q.sent = true
+	})
+
+	return q.result
+}
+
+func (q *Query[T]) noMessageStepName(window time.Duration) string {
+	return fmt.Sprintf("Kafka: Expect no message in '%s' for %s", q.topicName, window)
+}
+
+// watchNoMessage re-checks the buffer every interval until the window elapses.
+// It returns the first matching message as soon as one is seen, or nil if the window passed clean.
+func (q *Query[T]) watchNoMessage(
+	ctx context.Context,
+	buffer types.MessageBufferInterface,
+	window, interval time.Duration,
+) (*types.KafkaMessage, error, polling.PollingSummary) {
+	if interval <= 0 {
+		interval = defaultNoMessagePollInterval
+	}
+
+	start := time.Now()
+	deadline := start.Add(window)
+	summary := polling.PollingSummary{}
+
+	finish := func(msg *types.KafkaMessage, err error) (*types.KafkaMessage, error, polling.PollingSummary) {
+		summary.ElapsedTime = time.Since(start).String()
+		summary.Success = msg == nil && err == nil
+		if err != nil {
+			summary.LastError = err.Error()
+		}
+		if msg != nil {
+			summary.FailedChecks = []string{fmt.Sprintf("unexpected message at partition %d offset %d", msg.Partition, msg.Offset)}
+		}
+		return msg, err, summary
+	}
+
+	if !buffer.IsTopicConfigured(q.topicName) {
+		return finish(nil, &kafkaErrors.KafkaTopicNotListenedError{
+			TopicName:        q.topicName,
+			MessageType:      "unknown",
+			ConfiguredTopics: buffer.GetConfiguredTopics(),
+		})
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return finish(nil, ctx.Err())
+		case <-timer.C:
+		}
+
+		summary.Attempts++
+		if msg := q.findFirstMatch(buffer.GetMessages(q.topicName)); msg != nil {
+			return finish(msg, nil)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return finish(nil, nil)
+		}
+		timer.Reset(min(interval, remaining))
+	}
+}
+
+func (q *Query[T]) findFirstMatch(messages []*types.KafkaMessage) *types.KafkaMessage {
+	for _, msg := range messages {
+		if q.matchesMessage(msg) {
+			return msg
+		}
+	}
+	return nil
+}
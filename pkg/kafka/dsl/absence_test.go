@@ -0,0 +1,117 @@
+package dsl
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/kafka/consumer"
+	kafkaErrors "github.com/gorelov-m-v/go-test-framework/internal/kafka/errors"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
+)
+
+func newAbsenceQuery(filters map[string]string) *Query[any] {
+	return &Query[any]{
+		topicName:       "payments",
+		filters:         filters,
+		containsFilters: make(map[string]string),
+		headerFilters:   make(map[string]string),
+	}
+}
+
+func TestWatchNoMessage_CleanWindow(t *testing.T) {
+	buffer := consumer.NewMessageBuffer([]string{"payments"}, 10)
+	buffer.AddMessage(&types.KafkaMessage{Topic: "payments", Value: []byte(`{"paymentId": "other"}`)})
+	q := newAbsenceQuery(map[string]string{"paymentId": "p1"})
+
+	start := time.Now()
+	msg, err, summary := q.watchNoMessage(context.Background(), buffer, 60*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+	assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+	assert.True(t, summary.Success)
+	assert.Greater(t, summary.Attempts, 1)
+}
+
+func TestWatchNoMessage_AlreadyInBuffer(t *testing.T) {
+	buffer := consumer.NewMessageBuffer([]string{"payments"}, 10)
+	buffer.AddMessage(&types.KafkaMessage{Topic: "payments", Offset: 5, Value: []byte(`{"paymentId": "p1"}`)})
+	q := newAbsenceQuery(map[string]string{"paymentId": "p1"})
+
+	msg, err, summary := q.watchNoMessage(context.Background(), buffer, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.Equal(t, int64(5), msg.Offset)
+	assert.False(t, summary.Success)
+	assert.Equal(t, 1, summary.Attempts)
+	assert.Contains(t, summary.FailedChecks[0], "offset 5")
+}
+
+func TestWatchNoMessage_FailsAsSoonAsMessageArrives(t *testing.T) {
+	buffer := consumer.NewMessageBuffer([]string{"payments"}, 10)
+	q := newAbsenceQuery(map[string]string{"paymentId": "p1"})
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		buffer.AddMessage(&types.KafkaMessage{Topic: "payments", Value: []byte(`{"paymentId": "p1"}`)})
+	}()
+
+	start := time.Now()
+	msg, err, summary := q.watchNoMessage(context.Background(), buffer, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, summary.Success)
+}
+
+func TestWatchNoMessage_MetadataFilters(t *testing.T) {
+	buffer := consumer.NewMessageBuffer([]string{"payments"}, 10)
+	buffer.AddMessage(&types.KafkaMessage{Topic: "payments", Key: []byte("p2"), Value: []byte(`{}`)})
+	q := newAbsenceQuery(make(map[string]string))
+	q.WithKey("p1")
+
+	msg, err, _ := q.watchNoMessage(context.Background(), buffer, 20*time.Millisecond, 5*time.Millisecond)
+
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+}
+
+func TestWatchNoMessage_TopicNotConfigured(t *testing.T) {
+	buffer := consumer.NewMessageBuffer([]string{"orders"}, 10)
+	q := newAbsenceQuery(make(map[string]string))
+
+	msg, err, summary := q.watchNoMessage(context.Background(), buffer, time.Second, 10*time.Millisecond)
+
+	assert.Nil(t, msg)
+	var notListened *kafkaErrors.KafkaTopicNotListenedError
+	assert.ErrorAs(t, err, &notListened)
+	assert.False(t, summary.Success)
+	assert.NotEmpty(t, summary.LastError)
+}
+
+func TestWatchNoMessage_ContextCancelled(t *testing.T) {
+	buffer := consumer.NewMessageBuffer([]string{"payments"}, 10)
+	q := newAbsenceQuery(make(map[string]string))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err, summary := q.watchNoMessage(ctx, buffer, time.Second, 10*time.Millisecond)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, summary.Success)
+}
+
+func TestExpectNoMessageFor_SetsWindow(t *testing.T) {
+	q := newAbsenceQuery(make(map[string]string))
+
+	q.ExpectNoMessageFor(3 * time.Second)
+
+	assert.True(t, q.expectNoMessage)
+	assert.Equal(t, 3*time.Second, q.getNoMessageWindow())
+}
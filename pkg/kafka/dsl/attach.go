@@ -5,6 +5,7 @@ import (
 
 	"github.com/gorelov-m-v/go-test-framework/internal/allure"
 	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 var kafkaReporter = allure.NewDefaultReporter()
@@ -15,7 +16,7 @@ func attachKafkaReport[T any](
 	pollingSummary polling.PollingSummary,
 ) {
 	report := allure.KafkaReportDTO{
-		Search: allure.ToKafkaSearchDTO(q.topicName, q.filters, q.client.GetDefaultTimeout(), q.unique),
+		Search: allure.ToKafkaSearchDTO(q.topicName, q.describeFilters(), q.client.GetDefaultTimeout(), q.unique),
 		Result: allure.ToKafkaResultDTO(allure.KafkaResultParams{
 			Found:           q.found,
 			MessageBytes:    q.messageBytes,
@@ -24,6 +25,59 @@ func attachKafkaReport[T any](
 		}),
 		Polling: allure.ToPollingSummaryDTO(pollingSummary),
 	}
+	report.Search.Codec = q.getCodec().Name()
+	if q.expectNoMessage {
+		report.Search.ExpectNoMessage = true
+		report.Search.Timeout = q.getNoMessageWindow()
+	}
 
 	kafkaReporter.AttachKafkaReport(stepCtx, report)
 }
+
+func attachKafkaProduceReport(
+	stepCtx provider.StepCtx,
+	msg *types.ProducerMessage,
+	result *ProduceResult,
+) {
+	var delivered *types.KafkaMessage
+	if result.Error == nil {
+		delivered = &types.KafkaMessage{Partition: result.Partition, Offset: result.Offset}
+	}
+
+	report := allure.KafkaProduceReportDTO{
+		Message: allure.ToKafkaProduceMessageDTO(msg),
+		Result:  allure.ToKafkaProduceResultDTO(delivered, result.Duration, result.Error),
+	}
+	if msg == nil {
+		report.Message.Topic = result.Topic
+	}
+
+	kafkaReporter.AttachKafkaProduceReport(stepCtx, report)
+}
+
+func attachKafkaSequenceReport[T any](
+	stepCtx provider.StepCtx,
+	s *Sequence[T],
+	pollingSummary polling.PollingSummary,
+) {
+	report := allure.KafkaSequenceReportDTO{
+		Topic:    s.query.topicName,
+		Codec:    s.query.getCodec().Name(),
+		Filters:  s.query.describeFilters(),
+		Complete: s.result.Complete,
+		Failure:  s.result.Failure,
+		Polling:  allure.ToPollingSummaryDTO(pollingSummary),
+	}
+
+	for i, step := range s.steps {
+		var msg *types.KafkaMessage
+		var value []byte
+		if i < len(s.result.Messages) {
+			msg = s.result.Messages[i]
+			value = s.result.RawMessages[i]
+		}
+		report.Steps = append(report.Steps, allure.ToKafkaSequenceStepDTO(step.name, step.query.describeFilters(), msg, value))
+	}
+
+	kafkaReporter.AttachKafkaSequenceReport(stepCtx, report)
+}
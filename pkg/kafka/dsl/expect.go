@@ -1,8 +1,12 @@
 package dsl
 
 import (
+	"fmt"
+
 	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
 	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/client"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 var bytesPreCheck = client.BuildBytesPreCheck()
@@ -17,6 +21,86 @@ func (q *Query[T]) addExpectation(exp *expect.Expectation[[]byte]) {
 	expect.AddExpectation(q.stepCtx, q.sent, &q.expectations, exp, "Kafka")
 }
 
+func (q *Query[T]) addMessageExpectation(exp *expect.Expectation[*types.KafkaMessage]) {
+	expect.AddExpectation(q.stepCtx, q.sent, &q.messageExpectations, exp, "Kafka")
+}
+
+// ExpectKeyEquals expects the found message key to equal key.
+func (q *Query[T]) ExpectKeyEquals(key string) *Query[T] {
+	q.addMessageExpectation(makeKeyEqualsExpectation(key))
+	return q
+}
+
+// ExpectHeaderEquals expects the found message to carry header name with the given value.
+func (q *Query[T]) ExpectHeaderEquals(name, value string) *Query[T] {
+	q.addMessageExpectation(makeHeaderEqualsExpectation(name, value))
+	return q
+}
+
+func makeKeyEqualsExpectation(expected string) *expect.Expectation[*types.KafkaMessage] {
+	name := fmt.Sprintf("Expect key == %s", expected)
+	return expect.New(
+		name,
+		func(err error, msg *types.KafkaMessage) polling.CheckResult {
+			if res, ok := messagePreCheck(err, msg); !ok {
+				return res
+			}
+			if actual := string(msg.Key); actual != expected {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected key '%s', got '%s'", expected, actual),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReportWithActual(name, func(msg *types.KafkaMessage) string {
+			if msg == nil {
+				return "<nil>"
+			}
+			return string(msg.Key)
+		}),
+	)
+}
+
+func makeHeaderEqualsExpectation(header, expected string) *expect.Expectation[*types.KafkaMessage] {
+	name := fmt.Sprintf("Expect header '%s' == %s", header, expected)
+	return expect.New(
+		name,
+		func(err error, msg *types.KafkaMessage) polling.CheckResult {
+			if res, ok := messagePreCheck(err, msg); !ok {
+				return res
+			}
+			actual, exists := msg.Headers[header]
+			if !exists {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Header '%s' is missing", header),
+				}
+			}
+			if actual != expected {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected header '%s' to be '%s', got '%s'", header, expected, actual),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReportWithActual(name, func(msg *types.KafkaMessage) string {
+			if msg == nil {
+				return "<nil>"
+			}
+			return msg.Headers[header]
+		}),
+	)
+}
+
+var messagePreCheck = expect.BuildSimplePreCheck(expect.SimplePreCheckConfig[*types.KafkaMessage]{
+	IsNil: func(msg *types.KafkaMessage) bool { return msg == nil },
+})
+
 func (q *Query[T]) ExpectFieldEquals(field string, expectedValue interface{}) *Query[T] {
 	q.addExpectation(bytesSource.FieldEquals(field, expectedValue))
 	return q
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 func TestFieldEquals_Success(t *testing.T) {
@@ -512,3 +513,64 @@ func TestExpectation_EmptyJSON(t *testing.T) {
 
 	assert.False(t, result.Ok)
 }
+
+func TestKeyEquals_Success(t *testing.T) {
+	exp := makeKeyEqualsExpectation("order-1")
+
+	result := exp.Check(nil, &types.KafkaMessage{Key: []byte("order-1")})
+
+	assert.True(t, result.Ok)
+}
+
+func TestKeyEquals_Failure(t *testing.T) {
+	exp := makeKeyEqualsExpectation("order-1")
+
+	result := exp.Check(nil, &types.KafkaMessage{Key: []byte("order-2")})
+
+	assert.False(t, result.Ok)
+	assert.True(t, result.Retryable)
+	assert.Contains(t, result.Reason, "order-2")
+}
+
+func TestKeyEquals_NilMessage(t *testing.T) {
+	exp := makeKeyEqualsExpectation("order-1")
+
+	result := exp.Check(nil, nil)
+
+	assert.False(t, result.Ok)
+}
+
+func TestHeaderEquals_Success(t *testing.T) {
+	exp := makeHeaderEqualsExpectation("tenantId", "t1")
+
+	result := exp.Check(nil, &types.KafkaMessage{Headers: map[string]string{"tenantId": "t1"}})
+
+	assert.True(t, result.Ok)
+}
+
+func TestHeaderEquals_Missing(t *testing.T) {
+	exp := makeHeaderEqualsExpectation("tenantId", "t1")
+
+	result := exp.Check(nil, &types.KafkaMessage{Headers: map[string]string{}})
+
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "missing")
+}
+
+func TestHeaderEquals_WrongValue(t *testing.T) {
+	exp := makeHeaderEqualsExpectation("tenantId", "t1")
+
+	result := exp.Check(nil, &types.KafkaMessage{Headers: map[string]string{"tenantId": "t2"}})
+
+	assert.False(t, result.Ok)
+	assert.Contains(t, result.Reason, "t2")
+}
+
+func TestHeaderEquals_Error(t *testing.T) {
+	exp := makeHeaderEqualsExpectation("tenantId", "t1")
+
+	result := exp.Check(assert.AnError, nil)
+
+	assert.False(t, result.Ok)
+	assert.True(t, result.Retryable)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/tidwall/gjson"
 
 	"github.com/gorelov-m-v/go-test-framework/internal/jsonutil"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/codec"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 // With adds a filter to match messages where the JSON field at key equals value.
@@ -27,6 +29,25 @@ func (q *Query[T]) WithContains(key string, value interface{}) *Query[T] {
 	return q
 }
 
+// WithKey adds a filter to match messages whose key equals key.
+func (q *Query[T]) WithKey(key string) *Query[T] {
+	q.keyFilter = &key
+	return q
+}
+
+// WithHeader adds a filter to match messages that carry header name with the given value.
+// Multiple WithHeader calls use AND logic.
+func (q *Query[T]) WithHeader(name, value string) *Query[T] {
+	q.headerFilters[name] = value
+	return q
+}
+
+// WithPartition restricts the search to messages from the given partition.
+func (q *Query[T]) WithPartition(partition int32) *Query[T] {
+	q.partitionFilter = &partition
+	return q
+}
+
 // Unique ensures only one matching message exists within the default window.
 // Test fails if duplicates are found.
 func (q *Query[T]) Unique() *Query[T] {
@@ -48,6 +69,78 @@ func (q *Query[T]) ExpectCount(count int) *Query[T] {
 	return q
 }
 
+func (q *Query[T]) matchesMessage(msg *types.KafkaMessage) bool {
+	if msg == nil {
+		return false
+	}
+	if !q.matchesMetadata(msg) {
+		return false
+	}
+	jsonValue, ok := q.decodeValue(msg)
+	if !ok {
+		return false
+	}
+	return q.matchesFilter(jsonValue)
+}
+
+// decodeValue returns the JSON view of the message value produced by the topic codec.
+// Results are cached per message, so every buffered message is decoded at most once per query.
+// Messages that cannot be decoded never match; the last decode error is kept for the failure report.
+func (q *Query[T]) decodeValue(msg *types.KafkaMessage) ([]byte, bool) {
+	if cached, ok := q.decoded[msg]; ok {
+		return cached.json, cached.ok
+	}
+
+	jsonValue, err := q.getCodec().ToJSON(msg.Value)
+	if err != nil {
+		q.decodeErr = fmt.Errorf("partition %d offset %d: %w", msg.Partition, msg.Offset, err)
+		jsonValue = nil
+	}
+
+	if q.decoded == nil {
+		q.decoded = make(map[*types.KafkaMessage]decodedValue)
+	}
+	q.decoded[msg] = decodedValue{json: jsonValue, ok: err == nil}
+
+	return jsonValue, err == nil
+}
+
+type decodedValue struct {
+	json []byte
+	ok   bool
+}
+
+func (q *Query[T]) valueJSON(msg *types.KafkaMessage) []byte {
+	jsonValue, _ := q.decodeValue(msg)
+	return jsonValue
+}
+
+func (q *Query[T]) getCodec() codec.Codec {
+	if q.valueCodec == nil {
+		return codec.JSON()
+	}
+	return q.valueCodec
+}
+
+func (q *Query[T]) matchesMetadata(msg *types.KafkaMessage) bool {
+	if q.keyFilter != nil && string(msg.Key) != *q.keyFilter {
+		return false
+	}
+
+	if q.partitionFilter != nil && msg.Partition != *q.partitionFilter {
+		return false
+	}
+
+	for name, expectedValue := range q.headerFilters {
+		actualValue, ok := msg.Headers[name]
+		if !ok || actualValue != expectedValue {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (q *Query[T]) matchesFilter(jsonValue []byte) bool {
 	if len(jsonValue) == 0 {
 		return len(q.filters) == 0 && len(q.containsFilters) == 0
@@ -1,10 +1,14 @@
 package dsl
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tidwall/gjson"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
 
 func TestFormatFilterValue_String(t *testing.T) {
@@ -361,3 +365,98 @@ func TestMatchesFilter_NullValue(t *testing.T) {
 	jsonData := []byte(`{"value": null}`)
 	assert.False(t, q.matchesFilter(jsonData))
 }
+
+func TestMatchesMessage_Key(t *testing.T) {
+	q := &Query[any]{}
+	q.WithKey("order-1")
+
+	assert.True(t, q.matchesMessage(&types.KafkaMessage{Key: []byte("order-1"), Value: []byte(`{}`)}))
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{Key: []byte("order-2"), Value: []byte(`{}`)}))
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{Value: []byte(`{}`)}))
+}
+
+func TestMatchesMessage_Header(t *testing.T) {
+	q := &Query[any]{headerFilters: make(map[string]string)}
+	q.WithHeader("tenantId", "t1").WithHeader("correlationId", "c1")
+
+	assert.True(t, q.matchesMessage(&types.KafkaMessage{
+		Headers: map[string]string{"tenantId": "t1", "correlationId": "c1", "other": "x"},
+	}))
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{
+		Headers: map[string]string{"tenantId": "t1"},
+	}))
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{
+		Headers: map[string]string{"tenantId": "t2", "correlationId": "c1"},
+	}))
+}
+
+func TestMatchesMessage_Partition(t *testing.T) {
+	q := &Query[any]{}
+	q.WithPartition(2)
+
+	assert.True(t, q.matchesMessage(&types.KafkaMessage{Partition: 2}))
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{Partition: 0}))
+}
+
+func TestMatchesMessage_MetadataAndPayload(t *testing.T) {
+	q := &Query[any]{
+		filters:       map[string]string{"status": "CREATED"},
+		headerFilters: make(map[string]string),
+	}
+	q.WithKey("order-1")
+
+	assert.True(t, q.matchesMessage(&types.KafkaMessage{
+		Key:   []byte("order-1"),
+		Value: []byte(`{"status": "CREATED"}`),
+	}))
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{
+		Key:   []byte("order-1"),
+		Value: []byte(`{"status": "UPDATED"}`),
+	}))
+}
+
+func TestMatchesMessage_Nil(t *testing.T) {
+	q := &Query[any]{}
+
+	assert.False(t, q.matchesMessage(nil))
+}
+
+type upperCodec struct {
+	calls int
+}
+
+func (c *upperCodec) Name() string { return "upper" }
+
+func (c *upperCodec) ToJSON(data []byte) ([]byte, error) {
+	c.calls++
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty payload")
+	}
+	return []byte(fmt.Sprintf(`{"status": %q}`, strings.ToUpper(string(data)))), nil
+}
+
+func TestMatchesMessage_DecodesWithCodec(t *testing.T) {
+	c := &upperCodec{}
+	q := &Query[any]{filters: map[string]string{"status": "CREATED"}, valueCodec: c}
+	msg := &types.KafkaMessage{Value: []byte("created")}
+
+	assert.True(t, q.matchesMessage(msg))
+	assert.True(t, q.matchesMessage(msg))
+	assert.Equal(t, 1, c.calls)
+	assert.JSONEq(t, `{"status": "CREATED"}`, string(q.valueJSON(msg)))
+	assert.NoError(t, q.decodeErr)
+}
+
+func TestMatchesMessage_DecodeErrorDoesNotMatch(t *testing.T) {
+	q := &Query[any]{valueCodec: &upperCodec{}}
+
+	assert.False(t, q.matchesMessage(&types.KafkaMessage{Partition: 1, Offset: 7}))
+	assert.Error(t, q.decodeErr)
+	assert.Contains(t, q.decodeErr.Error(), "offset 7")
+}
+
+func TestGetCodec_DefaultsToJSON(t *testing.T) {
+	q := &Query[any]{}
+
+	assert.Equal(t, "json", q.getCodec().Name())
+}
@@ -0,0 +1,223 @@
+package dsl
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/client"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/topic"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
+)
+
+// Producer represents a Kafka message publisher with fluent interface.
+// It serializes the value of type T, publishes it synchronously and reports the
+// delivered message to Allure.
+//
+// Example:
+//
+//	dsl.Produce[topics.PaymentCommands, PaymentCommand](sCtx, kafkaClient).
+//	    Key(paymentID).
+//	    Header("correlationId", correlationID).
+//	    Value(PaymentCommand{ID: paymentID, Amount: 100}).
+//	    Send()
+type Producer[T any] struct {
+	stepCtx provider.StepCtx
+	client  *client.Client
+
+	topicName string
+	key       []byte
+	headers   map[string]string
+	value     T
+	hasValue  bool
+	partition *int32
+
+	result *ProduceResult
+}
+
+// ProduceResult represents the outcome of publishing a Kafka message.
+//
+// Fields:
+//   - Topic: Full topic name the message was published to
+//   - Partition: Partition assigned by the broker
+//   - Offset: Offset assigned by the broker
+//   - Key: Message key bytes
+//   - Value: Serialized message value
+//   - Headers: Message headers
+//   - Duration: Time spent waiting for the broker acknowledgement
+//   - Error: Serialization or delivery error, if any
+type ProduceResult struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+	Duration  time.Duration
+	Error     error
+}
+
+// NewProducer creates a new Kafka producer builder for the specified topic.
+//
+// Parameters:
+//   - sCtx: Allure step context for test reporting
+//   - kafkaClient: Kafka client
+//   - topicName: Full topic name to publish to
+//
+// Prefer using Produce[TTopic, T] for typed topics.
+func NewProducer[T any](stepCtx provider.StepCtx, kafkaClient *client.Client, topicName string) *Producer[T] {
+	return &Producer[T]{
+		stepCtx:   stepCtx,
+		client:    kafkaClient,
+		topicName: topicName,
+		headers:   make(map[string]string),
+	}
+}
+
+// Produce creates a Kafka producer for a typed topic.
+// The topic name is derived from the TTopic type's TopicName() method and the client's topic prefix.
+// Type parameter T is the message value type accepted by Value; use []byte, json.RawMessage
+// or string to publish a payload as is.
+//
+// Example:
+//
+//	dsl.Produce[topics.PlayerCommands, BlockPlayerCommand](sCtx, kafkaClient).
+//	    Key(playerID).
+//	    Value(BlockPlayerCommand{PlayerID: playerID}).
+//	    Send()
+func Produce[TTopic topic.TopicName, T any](stepCtx provider.StepCtx, kafkaClient *client.Client) *Producer[T] {
+	var topicName TTopic
+	fullTopicName := kafkaClient.GetTopicPrefix() + topicName.TopicName()
+	return NewProducer[T](stepCtx, kafkaClient, fullTopicName)
+}
+
+// Key sets the message key. Messages with the same key land in the same partition.
+func (p *Producer[T]) Key(key string) *Producer[T] {
+	p.key = []byte(key)
+	return p
+}
+
+// Header adds a message header.
+func (p *Producer[T]) Header(name, value string) *Producer[T] {
+	p.headers[name] = value
+	return p
+}
+
+// Value sets the message payload.
+// []byte, json.RawMessage and string values are sent as is, anything else is serialized to JSON.
+// Without a Value call the message is published with an empty (tombstone) value.
+func (p *Producer[T]) Value(value T) *Producer[T] {
+	p.value = value
+	p.hasValue = true
+	return p
+}
+
+// Partition publishes the message to an explicit partition instead of hashing the key.
+func (p *Producer[T]) Partition(partition int32) *Producer[T] {
+	p.partition = &partition
+	return p
+}
+
+func (p *Producer[T]) validate() {
+	v := validation.New(p.stepCtx, "Kafka")
+	v.RequireNotNil(p.client, "Kafka client")
+	v.RequireNotEmptyWithHint(p.topicName, "Topic name", "Use Produce[TopicType, ValueType]() or NewProducer().")
+}
+
+// Send serializes and publishes the message, waiting for the broker acknowledgement.
+// Returns the ProduceResult with the assigned partition and offset.
+func (p *Producer[T]) Send() *ProduceResult {
+	p.validate()
+
+	p.stepCtx.WithNewStep(p.stepName(), func(stepCtx provider.StepCtx) {
+		msg, err := p.buildMessage()
+		if err == nil {
+			p.result = p.publish(msg)
+		} else {
+			p.result = &ProduceResult{Topic: p.topicName, Key: p.key, Headers: p.headers, Error: err}
+		}
+
+		attachKafkaProduceReport(stepCtx, msg, p.result)
+
+		if p.result.Error != nil {
+			mode := polling.GetAssertionModeFromStepMode(polling.GetStepMode(stepCtx))
+			errMsg := fmt.Sprintf("Kafka message was not published to topic '%s': %v", p.topicName, p.result.Error)
+			polling.NoError(stepCtx, mode, p.result.Error, errMsg)
+		}
+	})
+
+	return p.result
+}
+
+func (p *Producer[T]) stepName() string {
+	return fmt.Sprintf("Kafka: Produce to '%s'", p.topicName)
+}
+
+func (p *Producer[T]) buildMessage() (*types.ProducerMessage, error) {
+	var value []byte
+	if p.hasValue {
+		var err error
+		if value, err = encodeValue(p.value); err != nil {
+			return nil, err
+		}
+	}
+
+	return &types.ProducerMessage{
+		Topic:     p.topicName,
+		Partition: p.partition,
+		Key:       p.key,
+		Value:     value,
+		Headers:   p.headers,
+	}, nil
+}
+
+func (p *Producer[T]) publish(msg *types.ProducerMessage) *ProduceResult {
+	result := &ProduceResult{
+		Topic:   msg.Topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: msg.Headers,
+	}
+
+	producer, err := p.client.GetProducer()
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	start := time.Now()
+	delivered, err := producer.Send(msg)
+	result.Duration = time.Since(start)
+
+	if err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Partition = delivered.Partition
+	result.Offset = delivered.Offset
+	return result
+}
+
+func encodeValue(value any) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize message value %T: %w", value, err)
+		}
+		return data, nil
+	}
+}
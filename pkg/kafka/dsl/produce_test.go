@@ -0,0 +1,118 @@
+package dsl
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeValue_Nil(t *testing.T) {
+	data, err := encodeValue(nil)
+
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestEncodeValue_Bytes(t *testing.T) {
+	data, err := encodeValue([]byte("raw"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), data)
+}
+
+func TestEncodeValue_RawMessage(t *testing.T) {
+	data, err := encodeValue(json.RawMessage(`{"id":1}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte(`{"id":1}`), data)
+}
+
+func TestEncodeValue_String(t *testing.T) {
+	data, err := encodeValue("plain text")
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("plain text"), data)
+}
+
+func TestEncodeValue_Struct(t *testing.T) {
+	type event struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+	}
+
+	data, err := encodeValue(event{ID: 1, Status: "CREATED"})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"status":"CREATED"}`, string(data))
+}
+
+func TestEncodeValue_Unsupported(t *testing.T) {
+	data, err := encodeValue(make(chan int))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to serialize")
+	assert.Nil(t, data)
+}
+
+func TestProducer_BuildMessage(t *testing.T) {
+	p := NewProducer[map[string]any](nil, nil, "prefix.orders").
+		Key("order-1").
+		Header("correlationId", "abc").
+		Partition(2).
+		Value(map[string]any{"orderId": "order-1"})
+
+	msg, err := p.buildMessage()
+
+	require.NoError(t, err)
+	assert.Equal(t, "prefix.orders", msg.Topic)
+	assert.Equal(t, []byte("order-1"), msg.Key)
+	assert.Equal(t, map[string]string{"correlationId": "abc"}, msg.Headers)
+	require.NotNil(t, msg.Partition)
+	assert.Equal(t, int32(2), *msg.Partition)
+	assert.JSONEq(t, `{"orderId":"order-1"}`, string(msg.Value))
+}
+
+func TestProducer_BuildMessage_NoPartition(t *testing.T) {
+	p := NewProducer[string](nil, nil, "orders").Value("payload")
+
+	msg, err := p.buildMessage()
+
+	require.NoError(t, err)
+	assert.Nil(t, msg.Partition)
+	assert.Nil(t, msg.Key)
+}
+
+func TestProducer_BuildMessage_TypedValue(t *testing.T) {
+	type paymentCommand struct {
+		ID     string `json:"id"`
+		Amount int    `json:"amount"`
+	}
+
+	p := NewProducer[paymentCommand](nil, nil, "payments").
+		Value(paymentCommand{ID: "pay-1", Amount: 100})
+
+	msg, err := p.buildMessage()
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"pay-1","amount":100}`, string(msg.Value))
+}
+
+func TestProducer_BuildMessage_RawBytesValue(t *testing.T) {
+	p := NewProducer[[]byte](nil, nil, "orders").Value([]byte("raw"))
+
+	msg, err := p.buildMessage()
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("raw"), msg.Value)
+}
+
+func TestProducer_BuildMessage_NoValue(t *testing.T) {
+	p := NewProducer[map[string]any](nil, nil, "orders").Key("order-1")
+
+	msg, err := p.buildMessage()
+
+	require.NoError(t, err)
+	assert.Nil(t, msg.Value)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/gorelov-m-v/go-test-framework/internal/polling"
 	"github.com/gorelov-m-v/go-test-framework/internal/validation"
 	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/client"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/codec"
 	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/topic"
 	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
 )
@@ -34,22 +35,32 @@ type Query[T any] struct {
 	client  *client.Client
 	ctx     context.Context
 
-	topicName string
+	topicName  string
+	valueCodec codec.Codec
 
 	filters         map[string]string
 	containsFilters map[string]string
+	keyFilter       *string
+	headerFilters   map[string]string
+	partitionFilter *int32
 	unique          bool
 	duplicateWindow time.Duration
 	expectedCount   int
+	expectNoMessage bool
+	noMessageWindow time.Duration
 
 	result *Result[T]
 	sent   bool
 
-	expectations    []*expect.Expectation[[]byte]
-	allMatchingMsgs [][]byte
-	messageBytes    []byte
-	found           bool
-	lastError       error
+	expectations        []*expect.Expectation[[]byte]
+	messageExpectations []*expect.Expectation[*types.KafkaMessage]
+	allMatchingMsgs     [][]byte
+	messageBytes        []byte
+	message             *types.KafkaMessage
+	decoded             map[*types.KafkaMessage]decodedValue
+	decodeErr           error
+	found               bool
+	lastError           error
 }
 
 // Result represents the outcome of a Kafka message search.
@@ -57,17 +68,29 @@ type Query[T any] struct {
 // Fields:
 //   - Found: Whether a matching message was found
 //   - Message: Deserialized message of type T
-//   - RawMessage: Raw message bytes
+//   - RawMessage: Message value as JSON (decoded by the topic codec for Avro/Protobuf topics)
 //   - AllMessages: All matching messages (when using ExpectCount)
 //   - MatchCount: Number of matching messages
 //   - ParseError: Error if message could not be deserialized to T
+//   - Key: Key of the found message
+//   - Headers: Headers of the found message
+//   - Partition: Partition of the found message
+//   - Offset: Offset of the found message
+//   - Timestamp: Timestamp of the found message (Unix milliseconds)
+//   - EncodedValue: Message value exactly as read from the topic
 type Result[T any] struct {
-	Found       bool
-	Message     T
-	RawMessage  []byte
-	AllMessages [][]byte
-	MatchCount  int
-	ParseError  error
+	Found        bool
+	Message      T
+	RawMessage   []byte
+	AllMessages  [][]byte
+	MatchCount   int
+	ParseError   error
+	Key          []byte
+	Headers      map[string]string
+	Partition    int32
+	Offset       int64
+	Timestamp    int64
+	EncodedValue []byte
 }
 
 // NewQuery creates a new Kafka query builder for the specified topic.
@@ -79,13 +102,20 @@ type Result[T any] struct {
 //
 // Prefer using Consume[T] for typed topic consumption.
 func NewQuery[T any](stepCtx provider.StepCtx, kafkaClient *client.Client, topicName string) *Query[T] {
+	var valueCodec codec.Codec
+	if kafkaClient != nil {
+		valueCodec = kafkaClient.GetCodec(topicName)
+	}
+
 	return &Query[T]{
 		stepCtx:         stepCtx,
 		client:          kafkaClient,
 		ctx:             context.Background(),
 		topicName:       topicName,
+		valueCodec:      valueCodec,
 		filters:         make(map[string]string),
 		containsFilters: make(map[string]string),
+		headerFilters:   make(map[string]string),
 		expectations:    make([]*expect.Expectation[[]byte], 0),
 	}
 }
@@ -117,8 +147,16 @@ func (q *Query[T]) validate() {
 // Send executes the Kafka message search and validates all expectations.
 // In async mode (AsyncStep), automatically retries with backoff until a matching message is found.
 // Returns the Result containing the found message and metadata.
+//
+// With ExpectNoMessage the step instead watches the topic for the whole window
+// and fails if a matching message appears.
 func (q *Query[T]) Send() *Result[T] {
 	q.validate()
+	q.validateNoMessage()
+
+	if q.expectNoMessage {
+		return q.sendExpectNoMessage()
+	}
 
 	q.stepCtx.WithNewStep(q.stepName(), func(stepCtx provider.StepCtx) {
 		var summary polling.PollingSummary
@@ -150,12 +188,16 @@ func (q *Query[T]) handleNotFound(stepCtx provider.StepCtx, summary polling.Poll
 	assertionMode := polling.GetAssertionModeFromStepMode(mode)
 
 	msg := fmt.Sprintf("Kafka message in topic '%s' not found within %s. Filters: %v",
-		q.topicName, q.client.GetDefaultTimeout(), q.filters)
+		q.topicName, q.client.GetDefaultTimeout(), q.describeFilters())
 
 	if mode == polling.AsyncMode {
 		msg = polling.FinalFailureMessage(summary)
 	}
 
+	if q.decodeErr != nil {
+		msg += fmt.Sprintf("\nSome messages could not be decoded with codec '%s'. Last error: %v", q.getCodec().Name(), q.decodeErr)
+	}
+
 	polling.NoError(stepCtx, assertionMode, fmt.Errorf("%s", msg), msg)
 	q.result = &Result[T]{Found: false}
 }
@@ -196,6 +238,15 @@ func (q *Query[T]) buildResult() *Result[T] {
 		result.MatchCount = 1
 	}
 
+	if q.found && q.message != nil {
+		result.Key = q.message.Key
+		result.Headers = q.message.Headers
+		result.Partition = q.message.Partition
+		result.Offset = q.message.Offset
+		result.Timestamp = q.message.Timestamp
+		result.EncodedValue = q.message.Value
+	}
+
 	return result
 }
 
@@ -239,8 +290,9 @@ func (q *Query[T]) searchMessage(messages []*types.KafkaMessage) ([]byte, error)
 	for i := len(messages) - 1; i >= 0; i-- {
 		msg := messages[i]
 
-		if q.matchesFilter(msg.Value) {
-			return msg.Value, nil
+		if q.matchesMessage(msg) {
+			q.message = msg
+			return q.valueJSON(msg), nil
 		}
 	}
 
@@ -255,8 +307,11 @@ func (q *Query[T]) findAllMatching(messages []*types.KafkaMessage) ([][]byte, er
 	var result [][]byte
 	for i := len(messages) - 1; i >= 0; i-- {
 		msg := messages[i]
-		if q.matchesFilter(msg.Value) {
-			result = append(result, msg.Value)
+		if q.matchesMessage(msg) {
+			if len(result) == 0 {
+				q.message = msg
+			}
+			result = append(result, q.valueJSON(msg))
 		}
 	}
 
@@ -279,9 +334,10 @@ func (q *Query[T]) findAndCountWithinWindow(messages []*types.KafkaMessage) ([]b
 	for i := len(messages) - 1; i >= 0; i-- {
 		msg := messages[i]
 
-		if q.matchesFilter(msg.Value) {
+		if q.matchesMessage(msg) {
 			if count == 0 {
-				firstMatchBytes = msg.Value
+				q.message = msg
+				firstMatchBytes = q.valueJSON(msg)
 				firstMatchTimestamp = msg.Timestamp
 				count++
 			} else {
@@ -324,11 +380,33 @@ func (q *Query[T]) checkExpectedCount(stepCtx provider.StepCtx, mode polling.Ass
 	actualCount := len(q.allMatchingMsgs)
 	if actualCount != q.expectedCount {
 		msg := fmt.Sprintf("Expected %d Kafka messages, but found %d. Topic: %s, Filters: %v",
-			q.expectedCount, actualCount, q.topicName, q.filters)
+			q.expectedCount, actualCount, q.topicName, q.describeFilters())
 		polling.NoError(stepCtx, mode, fmt.Errorf("%s", msg), msg)
 	}
 }
 
 func (q *Query[T]) runExpectations(stepCtx provider.StepCtx, err error) {
+	expect.AssertExpectations(stepCtx, q.messageExpectations, err, q.message, nil)
 	expect.AssertExpectations(stepCtx, q.expectations, err, q.messageBytes, nil)
 }
+
+// describeFilters merges payload and metadata filters into one map for error messages and reports.
+func (q *Query[T]) describeFilters() map[string]string {
+	all := make(map[string]string, len(q.filters)+len(q.headerFilters)+2)
+	for k, v := range q.filters {
+		all[k] = v
+	}
+	for k, v := range q.containsFilters {
+		all[k+" contains"] = v
+	}
+	if q.keyFilter != nil {
+		all["<key>"] = *q.keyFilter
+	}
+	if q.partitionFilter != nil {
+		all["<partition>"] = fmt.Sprintf("%d", *q.partitionFilter)
+	}
+	for k, v := range q.headerFilters {
+		all["<header> "+k] = v
+	}
+	return all
+}
@@ -670,3 +670,72 @@ func TestQuery_ExpectationChaining(t *testing.T) {
 	assert.Equal(t, "user", q.filters["type"])
 	assert.Len(t, q.expectations, 4)
 }
+
+func TestSearchMessage_RecordsMatchedMessage(t *testing.T) {
+	messages := []*types.KafkaMessage{
+		{Key: []byte("k1"), Partition: 0, Offset: 10, Value: []byte(`{"id": 1}`)},
+		{Key: []byte("k2"), Partition: 1, Offset: 20, Value: []byte(`{"id": 2}`), Headers: map[string]string{"h": "v"}},
+	}
+
+	q := &Query[any]{
+		filters:         map[string]string{"id": "2"},
+		containsFilters: make(map[string]string),
+	}
+
+	_, err := q.searchMessage(messages)
+
+	require.NoError(t, err)
+	require.NotNil(t, q.message)
+	assert.Equal(t, int64(20), q.message.Offset)
+}
+
+func TestBuildResult_ExposesMetadata(t *testing.T) {
+	q := &Query[any]{
+		found:        true,
+		messageBytes: []byte(`{"id": 1}`),
+		message: &types.KafkaMessage{
+			Key:       []byte("order-1"),
+			Headers:   map[string]string{"tenantId": "t1"},
+			Partition: 3,
+			Offset:    42,
+			Timestamp: 1700000000000,
+		},
+	}
+
+	result := q.buildResult()
+
+	assert.Equal(t, []byte("order-1"), result.Key)
+	assert.Equal(t, "t1", result.Headers["tenantId"])
+	assert.Equal(t, int32(3), result.Partition)
+	assert.Equal(t, int64(42), result.Offset)
+	assert.Equal(t, int64(1700000000000), result.Timestamp)
+}
+
+func TestQuery_DescribeFilters(t *testing.T) {
+	q := &Query[any]{
+		filters:         map[string]string{"id": "1"},
+		containsFilters: make(map[string]string),
+		headerFilters:   make(map[string]string),
+	}
+	q.WithKey("order-1").WithHeader("tenantId", "t1").WithPartition(2)
+
+	filters := q.describeFilters()
+
+	assert.Equal(t, "1", filters["id"])
+	assert.Equal(t, "order-1", filters["<key>"])
+	assert.Equal(t, "2", filters["<partition>"])
+	assert.Equal(t, "t1", filters["<header> tenantId"])
+}
+
+func TestQuery_BuildChecker_WithMessageExpectations(t *testing.T) {
+	q := &Query[any]{
+		message: &types.KafkaMessage{Key: []byte("order-1")},
+	}
+	q.messageExpectations = append(q.messageExpectations, makeKeyEqualsExpectation("order-2"))
+
+	checker := q.buildChecker()
+	results := checker([]byte(`{}`), nil)
+
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Ok)
+}
@@ -40,11 +40,14 @@ func (q *Query[T]) buildChecker() retry.Checker[[]byte] {
 			}}
 		}
 
-		if len(q.expectations) == 0 {
+		if len(q.expectations) == 0 && len(q.messageExpectations) == 0 {
 			return []polling.CheckResult{{Ok: true}}
 		}
 
-		results := make([]polling.CheckResult, 0, len(q.expectations))
+		results := make([]polling.CheckResult, 0, len(q.expectations)+len(q.messageExpectations))
+		for _, exp := range q.messageExpectations {
+			results = append(results, exp.Check(err, q.message))
+		}
 		for _, exp := range q.expectations {
 			checkRes := exp.Check(err, result)
 			results = append(results, checkRes)
@@ -0,0 +1,322 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	kafkaErrors "github.com/gorelov-m-v/go-test-framework/internal/kafka/errors"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/client"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/topic"
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
+)
+
+// Sequence verifies that messages matching ordered steps appear in offset order
+// within a single partition. Common filters (With, WithKey, ...) select the messages
+// of one flow, each step then describes one event of that flow.
+//
+// Example:
+//
+//	dsl.ExpectSequence[topics.OrderEvents](sCtx, kafkaClient).
+//	    With("orderId", orderID).
+//	    Steps(
+//	        dsl.Step("created").With("status", "CREATED"),
+//	        dsl.Step("updated").With("status", "UPDATED"),
+//	        dsl.Step("completed").With("status", "COMPLETED"),
+//	    ).
+//	    Send()
+type Sequence[T any] struct {
+	query *Query[T]
+	steps []*SequenceStep
+
+	match  *sequenceMatch
+	result *SequenceResult
+}
+
+// SequenceStep describes one expected message of a sequence.
+// Step filters are applied on top of the sequence filters.
+type SequenceStep struct {
+	name  string
+	query *Query[any]
+}
+
+// SequenceResult represents the outcome of a sequence check.
+//
+// Fields:
+//   - Complete: Whether all steps were found in order
+//   - Partition: Partition the sequence was found in (or the best partial match)
+//   - Messages: Matched messages, one per found step, in step order
+//   - RawMessages: Matched message values as JSON, in step order
+//   - Failure: Description of the missing or out-of-order step
+type SequenceResult struct {
+	Complete    bool
+	Partition   int32
+	Messages    []*types.KafkaMessage
+	RawMessages [][]byte
+	Failure     string
+}
+
+// sequenceMatch is the outcome of matching steps against the buffered messages.
+type sequenceMatch struct {
+	complete  bool
+	partition int32
+	matched   []*types.KafkaMessage
+	failure   string
+}
+
+// NewSequence creates a new Kafka sequence check for the specified topic.
+//
+// Prefer using ExpectSequence[T] for typed topics.
+func NewSequence[T any](stepCtx provider.StepCtx, kafkaClient *client.Client, topicName string) *Sequence[T] {
+	return &Sequence[T]{
+		query: NewQuery[T](stepCtx, kafkaClient, topicName),
+	}
+}
+
+// ExpectSequence creates a Kafka sequence check for a typed topic.
+// The topic name is derived from the TTopic type's TopicName() method and the client's topic prefix.
+func ExpectSequence[TTopic topic.TopicName](stepCtx provider.StepCtx, kafkaClient *client.Client) *Sequence[TTopic] {
+	var topicName TTopic
+	fullTopicName := kafkaClient.GetTopicPrefix() + topicName.TopicName()
+	return NewSequence[TTopic](stepCtx, kafkaClient, fullTopicName)
+}
+
+// Step creates a sequence step. The name is used in failure messages and the report.
+func Step(name string) *SequenceStep {
+	return &SequenceStep{
+		name: name,
+		query: &Query[any]{
+			filters:         make(map[string]string),
+			containsFilters: make(map[string]string),
+			headerFilters:   make(map[string]string),
+		},
+	}
+}
+
+// With adds a filter on the JSON field at key. Supports GJSON path syntax.
+func (s *SequenceStep) With(key string, value interface{}) *SequenceStep {
+	s.query.With(key, value)
+	return s
+}
+
+// WithContains adds a filter on the JSON array at key containing value.
+func (s *SequenceStep) WithContains(key string, value interface{}) *SequenceStep {
+	s.query.WithContains(key, value)
+	return s
+}
+
+// WithHeader adds a filter on the message header name.
+func (s *SequenceStep) WithHeader(name, value string) *SequenceStep {
+	s.query.WithHeader(name, value)
+	return s
+}
+
+func (s *SequenceStep) matches(msg *types.KafkaMessage, jsonValue []byte) bool {
+	return s.query.matchesMetadata(msg) && s.query.matchesFilter(jsonValue)
+}
+
+// With adds a filter shared by all steps.
+func (s *Sequence[T]) With(key string, value interface{}) *Sequence[T] {
+	s.query.With(key, value)
+	return s
+}
+
+// WithContains adds a contains filter shared by all steps.
+func (s *Sequence[T]) WithContains(key string, value interface{}) *Sequence[T] {
+	s.query.WithContains(key, value)
+	return s
+}
+
+// WithKey restricts the sequence to messages with the given key.
+func (s *Sequence[T]) WithKey(key string) *Sequence[T] {
+	s.query.WithKey(key)
+	return s
+}
+
+// WithHeader adds a header filter shared by all steps.
+func (s *Sequence[T]) WithHeader(name, value string) *Sequence[T] {
+	s.query.WithHeader(name, value)
+	return s
+}
+
+// WithPartition restricts the sequence to a single partition.
+func (s *Sequence[T]) WithPartition(partition int32) *Sequence[T] {
+	s.query.WithPartition(partition)
+	return s
+}
+
+// Steps appends the expected steps in the order the messages must appear.
+func (s *Sequence[T]) Steps(steps ...*SequenceStep) *Sequence[T] {
+	s.steps = append(s.steps, steps...)
+	return s
+}
+
+func (s *Sequence[T]) validate() {
+	s.query.validate()
+	v := validation.New(s.query.stepCtx, "Kafka")
+	v.Require(len(s.steps) > 0, "Sequence has no steps. Use Steps(dsl.Step(...), ...).")
+	for i, step := range s.steps {
+		v.Require(step != nil, fmt.Sprintf("Sequence step %d is nil", i+1))
+	}
+}
+
+// Send waits for the sequence and asserts that all steps are present and ordered.
+// In async mode (AsyncStep), retries with backoff until the sequence is complete.
+func (s *Sequence[T]) Send() *SequenceResult {
+	s.validate()
+
+	q := s.query
+	q.stepCtx.WithNewStep(s.stepName(), func(stepCtx provider.StepCtx) {
+		match, err, summary := retry.ExecuteDSL(retry.DSLConfig[*sequenceMatch, *sequenceMatch]{
+			Ctx:         q.ctx,
+			StepCtx:     stepCtx,
+			AsyncConfig: q.client.AsyncConfig,
+			Executor:    s.executeMatch,
+			Checker:     s.buildChecker(),
+		})
+		s.match = match
+		s.result = s.buildResult()
+
+		attachKafkaSequenceReport(stepCtx, s, summary)
+
+		if err != nil {
+			mode := polling.GetStepMode(stepCtx)
+			assertionMode := polling.GetAssertionModeFromStepMode(mode)
+
+			msg := fmt.Sprintf("Kafka sequence in topic '%s' not satisfied: %v. Filters: %v",
+				q.topicName, err, q.describeFilters())
+			if mode == polling.AsyncMode {
+				msg = polling.FinalFailureMessage(summary)
+			}
+			polling.NoError(stepCtx, assertionMode, err, msg)
+		}
+	})
+
+	return s.result
+}
+
+func (s *Sequence[T]) stepName() string {
+	return fmt.Sprintf("Kafka: Expect sequence of %d messages in '%s'", len(s.steps), s.query.topicName)
+}
+
+func (s *Sequence[T]) executeMatch(ctx context.Context) (*sequenceMatch, error) {
+	buffer := s.query.client.GetBuffer()
+	if !buffer.IsTopicConfigured(s.query.topicName) {
+		return &sequenceMatch{}, &kafkaErrors.KafkaTopicNotListenedError{
+			TopicName:        s.query.topicName,
+			MessageType:      "unknown",
+			ConfiguredTopics: buffer.GetConfiguredTopics(),
+		}
+	}
+
+	match := s.matchSequence(buffer.GetMessages(s.query.topicName))
+	if !match.complete {
+		return match, fmt.Errorf("%s", match.failure)
+	}
+	return match, nil
+}
+
+func (s *Sequence[T]) buildChecker() retry.Checker[*sequenceMatch] {
+	return func(_ *sequenceMatch, err error) []polling.CheckResult {
+		if err != nil {
+			return []polling.CheckResult{{
+				Ok:        false,
+				Retryable: true,
+				Reason:    err.Error(),
+			}}
+		}
+		return []polling.CheckResult{{Ok: true}}
+	}
+}
+
+// matchSequence groups messages matching the sequence filters by partition and walks
+// each partition in offset order, advancing to the next step on every match.
+// The partition with the longest matched prefix wins.
+func (s *Sequence[T]) matchSequence(messages []*types.KafkaMessage) *sequenceMatch {
+	partitions := make(map[int32][]*types.KafkaMessage)
+	for _, msg := range messages {
+		if s.query.matchesMessage(msg) {
+			partitions[msg.Partition] = append(partitions[msg.Partition], msg)
+		}
+	}
+
+	if len(partitions) == 0 {
+		return &sequenceMatch{
+			failure: fmt.Sprintf("step 1 '%s' missing: no messages match the sequence filters", s.steps[0].name),
+		}
+	}
+
+	ids := make([]int32, 0, len(partitions))
+	for id := range partitions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var best *sequenceMatch
+	for _, id := range ids {
+		msgs := partitions[id]
+		sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].Offset < msgs[j].Offset })
+
+		candidate := &sequenceMatch{partition: id}
+		for _, msg := range msgs {
+			if len(candidate.matched) == len(s.steps) {
+				break
+			}
+			if s.steps[len(candidate.matched)].matches(msg, s.query.valueJSON(msg)) {
+				candidate.matched = append(candidate.matched, msg)
+			}
+		}
+
+		if best == nil || len(candidate.matched) > len(best.matched) {
+			best = candidate
+		}
+		if len(candidate.matched) == len(s.steps) {
+			best.complete = true
+			return best
+		}
+	}
+
+	best.failure = s.describeFailure(best, partitions[best.partition])
+	return best
+}
+
+// describeFailure explains why the step after the matched prefix was not found:
+// either no message matches it in the partition, or it only appears before the previous step.
+func (s *Sequence[T]) describeFailure(match *sequenceMatch, msgs []*types.KafkaMessage) string {
+	next := len(match.matched)
+	step := s.steps[next]
+
+	if next > 0 {
+		prev := match.matched[next-1]
+		for _, msg := range msgs {
+			if msg.Offset < prev.Offset && step.matches(msg, s.query.valueJSON(msg)) {
+				return fmt.Sprintf("step %d '%s' out of order in partition %d: found at offset %d, before step %d '%s' at offset %d",
+					next+1, step.name, match.partition, msg.Offset, next, s.steps[next-1].name, prev.Offset)
+			}
+		}
+	}
+
+	return fmt.Sprintf("step %d '%s' missing in partition %d (%d of %d steps found)",
+		next+1, step.name, match.partition, next, len(s.steps))
+}
+
+func (s *Sequence[T]) buildResult() *SequenceResult {
+	result := &SequenceResult{}
+	if s.match == nil {
+		return result
+	}
+
+	result.Complete = s.match.complete
+	result.Partition = s.match.partition
+	result.Failure = s.match.failure
+	result.Messages = s.match.matched
+	for _, msg := range s.match.matched {
+		result.RawMessages = append(result.RawMessages, s.query.valueJSON(msg))
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+package dsl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/kafka/types"
+)
+
+func newTestSequence(steps ...*SequenceStep) *Sequence[any] {
+	s := &Sequence[any]{
+		query: &Query[any]{
+			topicName:       "orders",
+			filters:         make(map[string]string),
+			containsFilters: make(map[string]string),
+			headerFilters:   make(map[string]string),
+		},
+	}
+	return s.Steps(steps...)
+}
+
+func orderEvent(partition int32, offset int64, orderID, status string) *types.KafkaMessage {
+	return &types.KafkaMessage{
+		Topic:     "orders",
+		Partition: partition,
+		Offset:    offset,
+		Value:     []byte(fmt.Sprintf(`{"orderId": %q, "status": %q}`, orderID, status)),
+	}
+}
+
+func orderSteps() []*SequenceStep {
+	return []*SequenceStep{
+		Step("created").With("status", "CREATED"),
+		Step("updated").With("status", "UPDATED"),
+		Step("completed").With("status", "COMPLETED"),
+	}
+}
+
+func TestMatchSequence_Complete(t *testing.T) {
+	s := newTestSequence(orderSteps()...).With("orderId", "o1")
+
+	match := s.matchSequence([]*types.KafkaMessage{
+		orderEvent(1, 10, "o1", "CREATED"),
+		orderEvent(1, 11, "o2", "CREATED"),
+		orderEvent(1, 12, "o1", "UPDATED"),
+		orderEvent(1, 13, "o1", "UPDATED"),
+		orderEvent(1, 14, "o1", "COMPLETED"),
+	})
+
+	require.True(t, match.complete)
+	assert.Equal(t, int32(1), match.partition)
+	require.Len(t, match.matched, 3)
+	assert.Equal(t, []int64{10, 12, 14}, []int64{match.matched[0].Offset, match.matched[1].Offset, match.matched[2].Offset})
+	assert.Empty(t, match.failure)
+}
+
+func TestMatchSequence_OrdersByOffsetNotArrival(t *testing.T) {
+	s := newTestSequence(orderSteps()...)
+
+	match := s.matchSequence([]*types.KafkaMessage{
+		orderEvent(0, 3, "o1", "COMPLETED"),
+		orderEvent(0, 1, "o1", "CREATED"),
+		orderEvent(0, 2, "o1", "UPDATED"),
+	})
+
+	assert.True(t, match.complete)
+}
+
+func TestMatchSequence_MissingStep(t *testing.T) {
+	s := newTestSequence(orderSteps()...).With("orderId", "o1")
+
+	match := s.matchSequence([]*types.KafkaMessage{
+		orderEvent(0, 1, "o1", "CREATED"),
+		orderEvent(0, 2, "o1", "COMPLETED"),
+	})
+
+	assert.False(t, match.complete)
+	assert.Len(t, match.matched, 1)
+	assert.Contains(t, match.failure, "step 2 'updated' missing in partition 0")
+}
+
+func TestMatchSequence_OutOfOrder(t *testing.T) {
+	s := newTestSequence(orderSteps()...)
+
+	match := s.matchSequence([]*types.KafkaMessage{
+		orderEvent(0, 1, "o1", "UPDATED"),
+		orderEvent(0, 2, "o1", "CREATED"),
+		orderEvent(0, 3, "o1", "COMPLETED"),
+	})
+
+	assert.False(t, match.complete)
+	assert.Contains(t, match.failure, "step 2 'updated' out of order")
+	assert.Contains(t, match.failure, "offset 1, before step 1 'created' at offset 2")
+}
+
+func TestMatchSequence_SplitAcrossPartitions(t *testing.T) {
+	s := newTestSequence(orderSteps()...)
+
+	match := s.matchSequence([]*types.KafkaMessage{
+		orderEvent(0, 1, "o1", "CREATED"),
+		orderEvent(1, 1, "o1", "UPDATED"),
+		orderEvent(1, 2, "o1", "COMPLETED"),
+		orderEvent(2, 5, "o1", "CREATED"),
+		orderEvent(2, 6, "o1", "UPDATED"),
+	})
+
+	assert.False(t, match.complete)
+	assert.Equal(t, int32(2), match.partition)
+	assert.Contains(t, match.failure, "step 3 'completed' missing in partition 2 (2 of 3 steps found)")
+}
+
+func TestMatchSequence_NoMessages(t *testing.T) {
+	s := newTestSequence(orderSteps()...).With("orderId", "o1")
+
+	match := s.matchSequence([]*types.KafkaMessage{orderEvent(0, 1, "o2", "CREATED")})
+
+	assert.False(t, match.complete)
+	assert.Empty(t, match.matched)
+	assert.Contains(t, match.failure, "step 1 'created' missing")
+}
+
+func TestMatchSequence_StepHeaderFilter(t *testing.T) {
+	s := newTestSequence(
+		Step("command").WithHeader("type", "command"),
+		Step("event").WithHeader("type", "event"),
+	).WithKey("o1")
+
+	match := s.matchSequence([]*types.KafkaMessage{
+		{Partition: 0, Offset: 1, Key: []byte("o1"), Value: []byte(`{}`), Headers: map[string]string{"type": "command"}},
+		{Partition: 0, Offset: 2, Key: []byte("o2"), Value: []byte(`{}`), Headers: map[string]string{"type": "event"}},
+		{Partition: 0, Offset: 3, Key: []byte("o1"), Value: []byte(`{}`), Headers: map[string]string{"type": "event"}},
+	})
+
+	require.True(t, match.complete)
+	assert.Equal(t, int64(3), match.matched[1].Offset)
+}
+
+func TestSequence_BuildResult(t *testing.T) {
+	s := newTestSequence(orderSteps()...)
+	s.match = s.matchSequence([]*types.KafkaMessage{
+		orderEvent(0, 1, "o1", "CREATED"),
+		orderEvent(0, 2, "o1", "UPDATED"),
+	})
+
+	result := s.buildResult()
+
+	assert.False(t, result.Complete)
+	assert.Len(t, result.Messages, 2)
+	assert.JSONEq(t, `{"orderId": "o1", "status": "UPDATED"}`, string(result.RawMessages[1]))
+	assert.NotEmpty(t, result.Failure)
+}
@@ -16,3 +16,8 @@ type BackgroundConsumerInterface interface {
 	Stop() error
 	WaitReady(timeout time.Duration) error
 }
+
+type ProducerInterface interface {
+	Send(msg *ProducerMessage) (*KafkaMessage, error)
+	Close() error
+}
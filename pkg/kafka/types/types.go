@@ -9,3 +9,13 @@ type KafkaMessage struct {
 	Timestamp int64
 	Headers   map[string]string
 }
+
+// ProducerMessage is an outgoing Kafka message.
+// Partition is nil when the partition should be chosen by the key hash.
+type ProducerMessage struct {
+	Topic     string
+	Partition *int32
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+}
@@ -3,62 +3,99 @@ package client
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
+	"github.com/gorelov-m-v/go-test-framework/internal/redis/pubsub"
 	"github.com/gorelov-m-v/go-test-framework/pkg/config"
 )
 
 type Client struct {
-	rdb         *redis.Client
+	rdb         redis.UniversalClient
 	addr        string
+	mode        Mode
+	nodes       *nodeTracker
+	subscriber  *pubsub.Subscriber
+	messages    *pubsub.MessageBuffer
 	AsyncConfig config.AsyncConfig
 }
 
 type Config struct {
-	Addr        string             `mapstructure:"addr" yaml:"addr" json:"addr"`
-	Password    string             `mapstructure:"password" yaml:"password" json:"password"`
-	DB          int                `mapstructure:"db" yaml:"db" json:"db"`
-	AsyncConfig config.AsyncConfig `mapstructure:"asyncConfig" yaml:"asyncConfig" json:"asyncConfig"`
+	Addr             string             `mapstructure:"addr" yaml:"addr" json:"addr"`
+	Addrs            []string           `mapstructure:"addrs" yaml:"addrs" json:"addrs"`
+	Mode             Mode               `mapstructure:"mode" yaml:"mode" json:"mode"`
+	MasterName       string             `mapstructure:"masterName" yaml:"masterName" json:"masterName"`
+	Username         string             `mapstructure:"username" yaml:"username" json:"username"`
+	Password         string             `mapstructure:"password" yaml:"password" json:"password"`
+	SentinelUsername string             `mapstructure:"sentinelUsername" yaml:"sentinelUsername" json:"sentinelUsername"`
+	SentinelPassword string             `mapstructure:"sentinelPassword" yaml:"sentinelPassword" json:"sentinelPassword"`
+	DB               int                `mapstructure:"db" yaml:"db" json:"db"`
+	TLS              config.TLSConfig   `mapstructure:"tls" yaml:"tls" json:"tls"`
+	Subscribe        SubscribeConfig    `mapstructure:"subscribe" yaml:"subscribe" json:"subscribe"`
+	AsyncConfig      config.AsyncConfig `mapstructure:"asyncConfig" yaml:"asyncConfig" json:"asyncConfig"`
 }
 
 func New(cfg Config) (*Client, error) {
-	if cfg.Addr == "" {
-		return nil, fmt.Errorf("Redis address is required")
+	opts, mode, err := universalOptions(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     cfg.Addr,
-		Password: cfg.Password,
-		DB:       cfg.DB,
-	})
+	rdb := redis.NewUniversalClient(opts)
+	nodes := &nodeTracker{}
+	rdb.AddHook(nodes)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := rdb.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		_ = rdb.Close()
+		return nil, fmt.Errorf("failed to connect to Redis (%s): %w", mode, err)
 	}
 
 	asyncCfg := cfg.AsyncConfig.WithDefaults()
 
-	return &Client{
+	client := &Client{
 		rdb:         rdb,
-		addr:        cfg.Addr,
+		addr:        strings.Join(opts.Addrs, ","),
+		mode:        mode,
+		nodes:       nodes,
 		AsyncConfig: asyncCfg,
-	}, nil
+	}
+
+	if err := client.startSubscriber(cfg.Subscribe); err != nil {
+		_ = rdb.Close()
+		return nil, err
+	}
+
+	return client, nil
 }
 
+// Addr returns the configured address; for cluster and sentinel it is the comma-separated seed list.
 func (c *Client) Addr() string {
 	return c.addr
 }
 
+func (c *Client) Mode() Mode {
+	if c.mode == "" {
+		return ModeStandalone
+	}
+	return c.mode
+}
+
 func (c *Client) Close() error {
+	var subscriberErr error
+	if c.subscriber != nil {
+		subscriberErr = c.subscriber.Stop()
+	}
 	if c.rdb != nil {
-		return c.rdb.Close()
+		if err := c.rdb.Close(); err != nil {
+			return err
+		}
 	}
-	return nil
+	return subscriberErr
 }
 
 func (c *Client) Get(ctx context.Context, key string) *Result {
@@ -69,6 +106,7 @@ func (c *Client) Get(ctx context.Context, key string) *Result {
 
 	result := &Result{
 		Key:      key,
+		Type:     TypeString,
 		Duration: duration,
 	}
 
@@ -136,6 +174,8 @@ func (c *Client) Del(ctx context.Context, keys ...string) error {
 	return c.rdb.Del(ctx, keys...).Err()
 }
 
-func (c *Client) RDB() *redis.Client {
+// RDB returns the underlying go-redis client: *redis.Client, *redis.ClusterClient
+// or a failover (sentinel) *redis.Client depending on Mode.
+func (c *Client) RDB() redis.UniversalClient {
 	return c.rdb
 }
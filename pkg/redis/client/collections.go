@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// StreamReadLimit is the maximum number of latest stream entries read by XRange.
+const StreamReadLimit = 1000
+
+// HGetAll reads all fields of a hash.
+func (c *Client) HGetAll(ctx context.Context, key string) *Result {
+	start := time.Now()
+	fields, err := c.rdb.HGetAll(ctx, key).Result()
+
+	result := &Result{Key: key, Type: TypeHash, Duration: time.Since(start), Error: err}
+	if err == nil {
+		result.Hash = fields
+		result.Length = int64(len(fields))
+		result.Exists = len(fields) > 0
+	}
+	return result
+}
+
+// LRange reads all elements of a list.
+func (c *Client) LRange(ctx context.Context, key string) *Result {
+	start := time.Now()
+	elements, err := c.rdb.LRange(ctx, key, 0, -1).Result()
+
+	result := &Result{Key: key, Type: TypeList, Duration: time.Since(start), Error: err}
+	if err == nil {
+		result.List = elements
+		result.Length = int64(len(elements))
+		result.Exists = len(elements) > 0
+	}
+	return result
+}
+
+// SMembers reads all members of a set, sorted for stable reports.
+func (c *Client) SMembers(ctx context.Context, key string) *Result {
+	start := time.Now()
+	members, err := c.rdb.SMembers(ctx, key).Result()
+
+	result := &Result{Key: key, Type: TypeSet, Duration: time.Since(start), Error: err}
+	if err == nil {
+		sort.Strings(members)
+		result.Members = members
+		result.Length = int64(len(members))
+		result.Exists = len(members) > 0
+	}
+	return result
+}
+
+// ZRange reads all members of a sorted set with their scores, lowest score first.
+func (c *Client) ZRange(ctx context.Context, key string) *Result {
+	start := time.Now()
+	members, err := c.rdb.ZRangeWithScores(ctx, key, 0, -1).Result()
+
+	result := &Result{Key: key, Type: TypeZSet, Duration: time.Since(start), Error: err}
+	if err == nil {
+		result.ZMembers = make([]ZMember, 0, len(members))
+		for _, z := range members {
+			member, _ := z.Member.(string)
+			result.ZMembers = append(result.ZMembers, ZMember{Member: member, Score: z.Score})
+		}
+		result.Length = int64(len(members))
+		result.Exists = len(members) > 0
+	}
+	return result
+}
+
+// XRange reads the latest StreamReadLimit entries of a stream in ID order.
+// Length is the full stream length, which may exceed the number of entries read.
+func (c *Client) XRange(ctx context.Context, key string) *Result {
+	start := time.Now()
+	entries, err := c.rdb.XRevRangeN(ctx, key, "+", "-", StreamReadLimit).Result()
+
+	result := &Result{Key: key, Type: TypeStream, Error: err}
+	if err == nil {
+		result.Stream = make([]StreamEntry, len(entries))
+		for i, msg := range entries {
+			result.Stream[len(entries)-1-i] = toStreamEntry(msg)
+		}
+		result.Length, result.Error = c.rdb.XLen(ctx, key).Result()
+		result.Exists = result.Length > 0
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// Read reads key as the given type and records the node that served it.
+func (c *Client) Read(ctx context.Context, key string, keyType KeyType) *Result {
+	var result *Result
+	switch keyType {
+	case TypeHash:
+		result = c.HGetAll(ctx, key)
+	case TypeList:
+		result = c.LRange(ctx, key)
+	case TypeSet:
+		result = c.SMembers(ctx, key)
+	case TypeZSet:
+		result = c.ZRange(ctx, key)
+	case TypeStream:
+		result = c.XRange(ctx, key)
+	default:
+		result = c.Get(ctx, key)
+	}
+	result.Node = c.NodeFor(ctx, key)
+	return result
+}
+
+func toStreamEntry(msg redis.XMessage) StreamEntry {
+	values := make(map[string]string, len(msg.Values))
+	for field, value := range msg.Values {
+		if s, ok := value.(string); ok {
+			values[field] = s
+		}
+	}
+	return StreamEntry{ID: msg.ID, Values: values}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMiniredisClient(t *testing.T) (*Client, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return &Client{rdb: rdb, addr: mr.Addr()}, mr
+}
+
+func TestRead_Hash(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	mr.HSet("player:1", "status", "active", "level", "7")
+
+	result := c.Read(context.Background(), "player:1", TypeHash)
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.Exists)
+	assert.Equal(t, TypeHash, result.Type)
+	assert.Equal(t, int64(2), result.Length)
+	assert.Equal(t, map[string]string{"status": "active", "level": "7"}, result.Hash)
+}
+
+func TestRead_List(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	_, _ = mr.RPush("queue", "a", "b", "c")
+
+	result := c.Read(context.Background(), "queue", TypeList)
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, []string{"a", "b", "c"}, result.List)
+	assert.Equal(t, int64(3), result.Length)
+}
+
+func TestRead_SetSorted(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	_, _ = mr.SAdd("online", "carol", "alice", "bob")
+
+	result := c.Read(context.Background(), "online", TypeSet)
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, result.Members)
+}
+
+func TestRead_ZSet(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	_, _ = mr.ZAdd("leaderboard", 300, "carol")
+	_, _ = mr.ZAdd("leaderboard", 100, "alice")
+
+	result := c.Read(context.Background(), "leaderboard", TypeZSet)
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, []ZMember{{Member: "alice", Score: 100}, {Member: "carol", Score: 300}}, result.ZMembers)
+}
+
+func TestRead_StreamInIDOrder(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	_, err := mr.XAdd("events", "1-0", []string{"event", "created"})
+	require.NoError(t, err)
+	_, err = mr.XAdd("events", "2-0", []string{"event", "paid"})
+	require.NoError(t, err)
+
+	result := c.Read(context.Background(), "events", TypeStream)
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, int64(2), result.Length)
+	assert.Equal(t, []StreamEntry{
+		{ID: "1-0", Values: map[string]string{"event": "created"}},
+		{ID: "2-0", Values: map[string]string{"event": "paid"}},
+	}, result.Stream)
+}
+
+func TestRead_MissingKeyAndWrongType(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	require.NoError(t, mr.Set("plain", "value"))
+
+	missing := c.Read(context.Background(), "missing", TypeHash)
+	require.NoError(t, missing.Error)
+	assert.False(t, missing.Exists)
+
+	wrong := c.Read(context.Background(), "plain", TypeList)
+	require.Error(t, wrong.Error)
+	assert.Contains(t, wrong.Error.Error(), "WRONGTYPE")
+
+	str := c.Read(context.Background(), "plain", "")
+	assert.Equal(t, TypeString, str.Type)
+	assert.Equal(t, "value", str.Value)
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/redis/pubsub"
+)
+
+// Message is a pub/sub message buffered by the client's background subscriber.
+type Message = pubsub.Message
+
+// SubscribeConfig enables the background subscriber. Patterns may include keyspace
+// notification channels such as "__keyspace@*__:player:*".
+//
+// NotifyKeyspaceEvents, when set, is applied with CONFIG SET notify-keyspace-events
+// (e.g. "KEA"); managed Redis services that forbid CONFIG must enable it on their side.
+type SubscribeConfig struct {
+	Channels             []string      `mapstructure:"channels" yaml:"channels" json:"channels"`
+	Patterns             []string      `mapstructure:"patterns" yaml:"patterns" json:"patterns"`
+	BufferSize           int           `mapstructure:"bufferSize" yaml:"bufferSize" json:"bufferSize"`
+	NotifyKeyspaceEvents string        `mapstructure:"notifyKeyspaceEvents" yaml:"notifyKeyspaceEvents" json:"notifyKeyspaceEvents"`
+	Timeout              time.Duration `mapstructure:"timeout" yaml:"timeout" json:"timeout"`
+}
+
+func (c SubscribeConfig) enabled() bool {
+	return len(c.Channels) > 0 || len(c.Patterns) > 0
+}
+
+func (c *Client) startSubscriber(cfg SubscribeConfig) error {
+	if !cfg.enabled() {
+		return nil
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	if cfg.NotifyKeyspaceEvents != "" {
+		c.enableKeyspaceEvents(cfg.NotifyKeyspaceEvents, cfg.Timeout)
+	}
+
+	buffer := pubsub.NewMessageBuffer(cfg.BufferSize)
+	subscriber, err := pubsub.NewSubscriber(c.rdb, pubsub.SubscriberConfig{
+		Channels: cfg.Channels,
+		Patterns: cfg.Patterns,
+	}, buffer)
+	if err != nil {
+		return fmt.Errorf("failed to create Redis subscriber: %w", err)
+	}
+	if err := subscriber.Start(cfg.Timeout); err != nil {
+		return fmt.Errorf("failed to start Redis subscriber: %w", err)
+	}
+
+	c.messages = buffer
+	c.subscriber = subscriber
+	return nil
+}
+
+// enableKeyspaceEvents only logs failures: the subscriber still works for regular channels.
+func (c *Client) enableKeyspaceEvents(flags string, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var err error
+	if cluster, ok := c.rdb.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return node.ConfigSet(ctx, "notify-keyspace-events", flags).Err()
+		})
+	} else {
+		err = c.rdb.ConfigSet(ctx, "notify-keyspace-events", flags).Err()
+	}
+	if err != nil {
+		log.Printf("[Redis] Warning: failed to set notify-keyspace-events=%s: %v", flags, err)
+	}
+}
+
+// Subscriptions returns the channels and patterns of the background subscriber.
+func (c *Client) Subscriptions() []string {
+	if c.subscriber == nil {
+		return nil
+	}
+	return c.subscriber.Subscriptions()
+}
+
+// Messages returns the buffered pub/sub messages, oldest first.
+func (c *Client) Messages() []*Message {
+	if c.messages == nil {
+		return nil
+	}
+	return c.messages.GetMessages()
+}
+
+// ClearMessages drops all buffered pub/sub messages.
+func (c *Client) ClearMessages() {
+	if c.messages != nil {
+		c.messages.Clear()
+	}
+}
+
+// Publish posts message to channel and returns the number of subscribers that received it.
+func (c *Client) Publish(ctx context.Context, channel string, message any) (int64, error) {
+	return c.rdb.Publish(ctx, channel, message).Result()
+}
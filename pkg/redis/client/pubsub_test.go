@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_WithSubscriber(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	c, err := New(Config{
+		Addr: mr.Addr(),
+		Subscribe: SubscribeConfig{
+			Channels:             []string{"cache:invalidate"},
+			Patterns:             []string{"__keyspace@*__:player:*"},
+			BufferSize:           10,
+			NotifyKeyspaceEvents: "KEA",
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	assert.Equal(t, []string{"cache:invalidate", "__keyspace@*__:player:*"}, c.Subscriptions())
+
+	receivers, err := c.Publish(context.Background(), "cache:invalidate", "player:1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), receivers)
+
+	require.Eventually(t, func() bool { return len(c.Messages()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "player:1", c.Messages()[0].Payload)
+
+	c.ClearMessages()
+	assert.Empty(t, c.Messages())
+}
+
+func TestClient_WithoutSubscriber(t *testing.T) {
+	c, _ := newMiniredisClient(t)
+
+	assert.Nil(t, c.Subscriptions())
+	assert.Nil(t, c.Messages())
+	c.ClearMessages()
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+)
+
+// ScanBatchSize is the COUNT hint passed to each SCAN call.
+const ScanBatchSize = 100
+
+// ScanResult is the set of keys matching a SCAN pattern.
+//
+// Fields:
+//   - Keys: Matched keys, sorted and de-duplicated
+//   - Truncated: More keys matched than the scan limit; Keys holds the first limit keys found
+//   - Values: String values of the matched keys, populated only by per-key expectations
+type ScanResult struct {
+	Pattern   string
+	Keys      []string
+	Truncated bool
+	Values    map[string]*Result
+	Error     error
+	Duration  time.Duration
+}
+
+func (r *ScanResult) GetError() error {
+	if r == nil {
+		return nil
+	}
+	return r.Error
+}
+
+func ScanResultPreCheckConfig() expect.PreCheckConfig[*ScanResult] {
+	return expect.PreCheckConfig[*ScanResult]{
+		IsNil:    func(r *ScanResult) bool { return r == nil },
+		HasError: func(r *ScanResult) error { return r.Error },
+	}
+}
+
+func BuildScanPreCheck() func(error, *ScanResult) (polling.CheckResult, bool) {
+	return expect.BuildPreCheck(ScanResultPreCheckConfig())
+}
+
+// Scan iterates keys matching pattern with SCAN (never KEYS), on every master in cluster mode.
+// limit caps the number of collected keys; 0 means no limit.
+func (c *Client) Scan(ctx context.Context, pattern string, limit int) *ScanResult {
+	start := time.Now()
+	result := &ScanResult{Pattern: pattern}
+
+	var mu sync.Mutex
+	seen := make(map[string]struct{})
+	collect := func(ctx context.Context, node redis.Cmdable) error {
+		iter := node.Scan(ctx, 0, pattern, ScanBatchSize).Iterator()
+		for iter.Next(ctx) {
+			mu.Lock()
+			if _, ok := seen[iter.Val()]; !ok {
+				if limit > 0 && len(seen) >= limit {
+					result.Truncated = true
+					mu.Unlock()
+					return nil
+				}
+				seen[iter.Val()] = struct{}{}
+			}
+			mu.Unlock()
+		}
+		return iter.Err()
+	}
+
+	if cluster, ok := c.rdb.(*redis.ClusterClient); ok {
+		result.Error = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return collect(ctx, node)
+		})
+	} else {
+		result.Error = collect(ctx, c.rdb)
+	}
+
+	result.Keys = make([]string, 0, len(seen))
+	for key := range seen {
+		result.Keys = append(result.Keys, key)
+	}
+	sort.Strings(result.Keys)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// GetMany reads the string values of keys in one pipeline.
+// Each key gets its own Result, so a missing or non-string key does not fail the others.
+func (c *Client) GetMany(ctx context.Context, keys []string) map[string]*Result {
+	results := make(map[string]*Result, len(keys))
+	if len(keys) == 0 {
+		return results
+	}
+
+	start := time.Now()
+	cmds := make([]*redis.StringCmd, len(keys))
+	pipe := c.rdb.Pipeline()
+	for i, key := range keys {
+		cmds[i] = pipe.Get(ctx, key)
+	}
+	_, _ = pipe.Exec(ctx)
+	duration := time.Since(start)
+
+	for i, key := range keys {
+		result := &Result{Key: key, Type: TypeString, Duration: duration}
+		val, err := cmds[i].Result()
+		switch {
+		case err == redis.Nil:
+		case err != nil:
+			result.Error = err
+		default:
+			result.Value = val
+			result.Exists = true
+		}
+		results[key] = result
+	}
+	return results
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan_MatchesPatternSorted(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	require.NoError(t, mr.Set("session:42:b", "1"))
+	require.NoError(t, mr.Set("session:42:a", "2"))
+	require.NoError(t, mr.Set("session:7:a", "3"))
+	mr.HSet("session:42:meta", "f", "v")
+
+	result := c.Scan(context.Background(), "session:42:*", 0)
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, "session:42:*", result.Pattern)
+	assert.Equal(t, []string{"session:42:a", "session:42:b", "session:42:meta"}, result.Keys)
+	assert.False(t, result.Truncated)
+}
+
+func TestScan_Limit(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, mr.Set(fmt.Sprintf("k:%d", i), "v"))
+	}
+
+	result := c.Scan(context.Background(), "k:*", 3)
+	assert.Len(t, result.Keys, 3)
+	assert.True(t, result.Truncated)
+
+	result = c.Scan(context.Background(), "k:*", 5)
+	assert.Len(t, result.Keys, 5)
+	assert.False(t, result.Truncated)
+}
+
+func TestGetMany(t *testing.T) {
+	c, mr := newMiniredisClient(t)
+	require.NoError(t, mr.Set("a", "1"))
+	mr.HSet("h", "f", "v")
+
+	results := c.GetMany(context.Background(), []string{"a", "missing", "h"})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "1", results["a"].Value)
+	assert.True(t, results["a"].Exists)
+	assert.False(t, results["missing"].Exists)
+	assert.NoError(t, results["missing"].Error)
+	assert.ErrorContains(t, results["h"].Error, "WRONGTYPE")
+
+	assert.Empty(t, c.GetMany(context.Background(), nil))
+}
+
+func TestScan_ClusterIteratesMasters(t *testing.T) {
+	mr := miniredis.RunT(t)
+	require.NoError(t, mr.Set("session:1", "v"))
+
+	c, err := New(Config{Addrs: []string{mr.Addr()}, Mode: ModeCluster})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	result := c.Scan(context.Background(), "session:*", 0)
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, []string{"session:1"}, result.Keys)
+}
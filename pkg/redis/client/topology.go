@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Mode is the Redis deployment topology the client connects to.
+type Mode string
+
+const (
+	ModeStandalone Mode = "standalone"
+	ModeCluster    Mode = "cluster"
+	ModeSentinel   Mode = "sentinel"
+)
+
+// universalOptions validates cfg and resolves the topology. An empty Mode is inferred:
+// masterName selects sentinel, several addresses select cluster, otherwise standalone.
+func universalOptions(cfg Config) (*redis.UniversalOptions, Mode, error) {
+	if cfg.Addr != "" && len(cfg.Addrs) > 0 {
+		return nil, "", fmt.Errorf("Redis config: set either addr or addrs, not both")
+	}
+	addrs := cfg.Addrs
+	if cfg.Addr != "" {
+		addrs = []string{cfg.Addr}
+	}
+	if len(addrs) == 0 {
+		return nil, "", fmt.Errorf("Redis address is required")
+	}
+
+	mode := Mode(strings.ToLower(string(cfg.Mode)))
+	if mode == "" {
+		switch {
+		case cfg.MasterName != "":
+			mode = ModeSentinel
+		case len(addrs) > 1:
+			mode = ModeCluster
+		default:
+			mode = ModeStandalone
+		}
+	}
+
+	switch mode {
+	case ModeStandalone:
+		if len(addrs) > 1 {
+			return nil, "", fmt.Errorf("Redis standalone mode accepts a single address, got %d (use mode: cluster or sentinel)", len(addrs))
+		}
+	case ModeCluster:
+		if cfg.DB != 0 {
+			return nil, "", fmt.Errorf("Redis Cluster supports only db 0, got db %d", cfg.DB)
+		}
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, "", fmt.Errorf("Redis sentinel mode requires masterName")
+		}
+	default:
+		return nil, "", fmt.Errorf("unsupported Redis mode '%s' (supported: %s, %s, %s)",
+			cfg.Mode, ModeStandalone, ModeCluster, ModeSentinel)
+	}
+
+	tlsCfg, err := cfg.TLS.Build()
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid Redis TLS config: %w", err)
+	}
+
+	opts := &redis.UniversalOptions{
+		Addrs:            addrs,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		DB:               cfg.DB,
+		TLSConfig:        tlsCfg,
+		IsClusterMode:    mode == ModeCluster,
+	}
+	if mode == ModeSentinel {
+		opts.MasterName = cfg.MasterName
+	}
+
+	return opts, mode, nil
+}
+
+// NodeFor returns the address of the node serving key: the slot master in cluster mode,
+// the current master discovered through sentinels in sentinel mode, and Addr otherwise.
+func (c *Client) NodeFor(ctx context.Context, key string) string {
+	switch c.Mode() {
+	case ModeCluster:
+		if cluster, ok := c.rdb.(*redis.ClusterClient); ok {
+			if node, err := cluster.MasterForKey(ctx, key); err == nil {
+				return node.Options().Addr
+			}
+		}
+	case ModeSentinel:
+		if addr := c.nodes.last(); addr != "" {
+			return addr
+		}
+	}
+	return c.addr
+}
+
+// nodeTracker remembers the remote address of the latest dialed connection.
+// A sentinel-managed client dials a placeholder address, so the real master
+// is only visible on the established connection.
+type nodeTracker struct {
+	addr atomic.Value
+}
+
+func (t *nodeTracker) last() string {
+	if t == nil {
+		return ""
+	}
+	addr, _ := t.addr.Load().(string)
+	return addr
+}
+
+func (t *nodeTracker) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := next(ctx, network, addr)
+		if err == nil && conn.RemoteAddr() != nil {
+			t.addr.Store(conn.RemoteAddr().String())
+		}
+		return conn, err
+	}
+}
+
+func (t *nodeTracker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return next
+}
+
+func (t *nodeTracker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
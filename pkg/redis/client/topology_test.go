@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/config"
+)
+
+func TestUniversalOptions_InfersMode(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		mode Mode
+	}{
+		{"single addr", Config{Addr: "localhost:6379"}, ModeStandalone},
+		{"seed list", Config{Addrs: []string{"node1:7000", "node2:7000"}}, ModeCluster},
+		{"single seed with explicit mode", Config{Addrs: []string{"node1:7000"}, Mode: "Cluster"}, ModeCluster},
+		{"master name", Config{Addrs: []string{"sentinel1:26379", "sentinel2:26379"}, MasterName: "mymaster"}, ModeSentinel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, mode, err := universalOptions(tt.cfg)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.mode, mode)
+			assert.Equal(t, tt.mode == ModeCluster, opts.IsClusterMode)
+			assert.Equal(t, tt.mode == ModeSentinel, opts.MasterName != "")
+		})
+	}
+}
+
+func TestUniversalOptions_Credentials(t *testing.T) {
+	opts, _, err := universalOptions(Config{
+		Addrs:            []string{"sentinel:26379"},
+		MasterName:       "mymaster",
+		Username:         "app",
+		Password:         "secret",
+		SentinelUsername: "sentinel-user",
+		SentinelPassword: "sentinel-secret",
+		DB:               2,
+		TLS:              config.TLSConfig{Enabled: true, ServerName: "redis.internal"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "app", opts.Username)
+	assert.Equal(t, "secret", opts.Password)
+	assert.Equal(t, "sentinel-user", opts.SentinelUsername)
+	assert.Equal(t, "sentinel-secret", opts.SentinelPassword)
+	assert.Equal(t, 2, opts.DB)
+	require.NotNil(t, opts.TLSConfig)
+	assert.Equal(t, "redis.internal", opts.TLSConfig.ServerName)
+}
+
+func TestUniversalOptions_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		err  string
+	}{
+		{"no address", Config{}, "Redis address is required"},
+		{"addr and addrs", Config{Addr: "a:1", Addrs: []string{"b:1"}}, "either addr or addrs"},
+		{"standalone with seeds", Config{Addrs: []string{"a:1", "b:1"}, Mode: ModeStandalone}, "single address"},
+		{"cluster db", Config{Addrs: []string{"a:1", "b:1"}, DB: 1}, "only db 0"},
+		{"sentinel without master", Config{Addr: "a:26379", Mode: ModeSentinel}, "requires masterName"},
+		{"unknown mode", Config{Addr: "a:1", Mode: "ring"}, "unsupported Redis mode 'ring'"},
+		{"bad tls", Config{Addr: "a:1", TLS: config.TLSConfig{Enabled: true, CertFile: "cert.pem"}}, "invalid Redis TLS config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := universalOptions(tt.cfg)
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestNew_StandaloneWithACLUser(t *testing.T) {
+	mr := miniredis.RunT(t)
+	mr.RequireUserAuth("app", "secret")
+
+	_, err := New(Config{Addr: mr.Addr(), Username: "app", Password: "wrong"})
+	require.Error(t, err)
+
+	c, err := New(Config{Addr: mr.Addr(), Username: "app", Password: "secret"})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	assert.Equal(t, ModeStandalone, c.Mode())
+	require.NoError(t, c.Set(context.Background(), "k", "v", 0))
+
+	result := c.Read(context.Background(), "k", TypeString)
+	assert.Equal(t, "v", result.Value)
+	assert.Equal(t, mr.Addr(), result.Node)
+}
+
+func TestNew_ClusterReportsSlotMaster(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	c, err := New(Config{Addrs: []string{mr.Addr()}, Mode: ModeCluster})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	_, ok := c.RDB().(*redis.ClusterClient)
+	assert.True(t, ok)
+	assert.Equal(t, mr.Addr(), c.NodeFor(context.Background(), "player:1"))
+}
+
+func TestNew_TLS(t *testing.T) {
+	mr, err := miniredis.RunTLS(&tls.Config{Certificates: []tls.Certificate{selfSignedCert(t)}})
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	c, err := New(Config{Addr: mr.Addr(), TLS: config.TLSConfig{Enabled: true, InsecureSkipVerify: true}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+
+	assert.NoError(t, c.Read(context.Background(), "missing", TypeString).Error)
+}
+
+func TestNodeFor_SentinelUsesDialedAddress(t *testing.T) {
+	c := &Client{addr: "sentinel:26379", mode: ModeSentinel, nodes: &nodeTracker{}}
+	assert.Equal(t, "sentinel:26379", c.NodeFor(context.Background(), "k"))
+
+	c.nodes.addr.Store("10.0.0.5:6379")
+	assert.Equal(t, "10.0.0.5:6379", c.NodeFor(context.Background(), "k"))
+}
+
+func selfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
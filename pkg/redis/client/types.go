@@ -7,9 +7,71 @@ import (
 	"github.com/gorelov-m-v/go-test-framework/internal/polling"
 )
 
+// KeyType is the Redis data type a query reads.
+type KeyType string
+
+const (
+	TypeString KeyType = "string"
+	TypeHash   KeyType = "hash"
+	TypeList   KeyType = "list"
+	TypeSet    KeyType = "set"
+	TypeZSet   KeyType = "zset"
+	TypeStream KeyType = "stream"
+)
+
+// Command returns the Redis command used to read a key of this type.
+func (t KeyType) Command() string {
+	switch t {
+	case TypeHash:
+		return "HGETALL"
+	case TypeList:
+		return "LRANGE"
+	case TypeSet:
+		return "SMEMBERS"
+	case TypeZSet:
+		return "ZRANGE"
+	case TypeStream:
+		return "XRANGE"
+	default:
+		return "GET"
+	}
+}
+
+// ZMember is a sorted set member with its score.
+type ZMember struct {
+	Member string
+	Score  float64
+}
+
+// StreamEntry is a single stream entry.
+type StreamEntry struct {
+	ID     string
+	Values map[string]string
+}
+
+// Result represents the state of a Redis key.
+//
+// Fields:
+//   - Type: Data type the key was read as (string by default)
+//   - Value: String value (TypeString)
+//   - Hash: Field values (TypeHash)
+//   - List: Elements in order (TypeList)
+//   - Members: Members sorted alphabetically (TypeSet)
+//   - ZMembers: Members with scores in ascending score order (TypeZSet)
+//   - Stream: Latest entries in ID order, at most StreamReadLimit (TypeStream)
+//   - Length: Number of fields, elements, members or stream entries
+//   - Node: Address of the node that served the key (set by Read)
 type Result struct {
 	Key      string
+	Node     string
+	Type     KeyType
 	Value    string
+	Hash     map[string]string
+	List     []string
+	Members  []string
+	ZMembers []ZMember
+	Stream   []StreamEntry
+	Length   int64
 	Exists   bool
 	TTL      time.Duration
 	Error    error
@@ -16,11 +16,68 @@ func attachRedisReport(
 	result *client.Result,
 	pollingSummary polling.PollingSummary,
 ) {
+	request := allure.ToRedisRequestDTO(q.client.Addr(), q.key)
+	request.Command = q.keyType.Command()
+	request.Mode = string(q.client.Mode())
+	if result != nil {
+		request.Node = result.Node
+	}
+
 	report := allure.RedisReportDTO{
-		Request: allure.ToRedisRequestDTO(q.client.Addr(), q.key),
+		Request: request,
 		Result:  allure.ToRedisResultDTO(result),
 		Polling: allure.ToPollingSummaryDTO(pollingSummary),
 	}
 
 	redisReporter.AttachRedisReport(stepCtx, report)
 }
+
+func attachRedisScanReport(
+	stepCtx provider.StepCtx,
+	s *ScanQuery,
+	result *client.ScanResult,
+	pollingSummary polling.PollingSummary,
+) {
+	report := allure.RedisScanReportDTO{
+		Request: allure.RedisScanRequestDTO{
+			Server:  s.client.Addr(),
+			Mode:    string(s.client.Mode()),
+			Pattern: s.pattern,
+			Limit:   s.limit,
+		},
+		Result:  allure.ToRedisScanResultDTO(result),
+		Polling: allure.ToPollingSummaryDTO(pollingSummary),
+	}
+
+	redisReporter.AttachRedisScanReport(stepCtx, report)
+}
+
+func attachRedisPubSubReport(
+	stepCtx provider.StepCtx,
+	q *SubscribeQuery,
+	result *PubSubResult,
+	pollingSummary polling.PollingSummary,
+) {
+	dto := allure.RedisPubSubResultDTO{}
+	if result != nil {
+		dto = allure.RedisPubSubResultDTO{
+			Found:   result.Found,
+			Checked: result.Checked,
+			Message: result.Message,
+			Matches: result.Matches,
+			Error:   result.Error,
+		}
+	}
+
+	report := allure.RedisPubSubReportDTO{
+		Request: allure.RedisPubSubRequestDTO{
+			Server:        q.client.Addr(),
+			Subscriptions: q.client.Subscriptions(),
+			Filters:       q.describeFilters(),
+		},
+		Result:  dto,
+		Polling: allure.ToPollingSummaryDTO(pollingSummary),
+	}
+
+	redisReporter.AttachRedisPubSubReport(stepCtx, report)
+}
@@ -0,0 +1,475 @@
+package dsl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/errors"
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+type typeCheck struct {
+	method  string
+	keyType client.KeyType
+}
+
+// Hash queries key as a hash (HGETALL).
+//
+// Example:
+//
+//	dsl.NewQuery(sCtx, redisClient).
+//	    Hash("player:123:profile").
+//	    ExpectHashFieldEquals("status", "active").
+//	    Send()
+func (q *Query) Hash(key string) *Query {
+	return q.withType(key, client.TypeHash)
+}
+
+// List queries key as a list (LRANGE 0 -1).
+func (q *Query) List(key string) *Query {
+	return q.withType(key, client.TypeList)
+}
+
+// Set queries key as a set (SMEMBERS).
+func (q *Query) Set(key string) *Query {
+	return q.withType(key, client.TypeSet)
+}
+
+// ZSet queries key as a sorted set (ZRANGE 0 -1 WITHSCORES).
+func (q *Query) ZSet(key string) *Query {
+	return q.withType(key, client.TypeZSet)
+}
+
+// Stream queries key as a stream (latest client.StreamReadLimit entries).
+func (q *Query) Stream(key string) *Query {
+	return q.withType(key, client.TypeStream)
+}
+
+func (q *Query) withType(key string, keyType client.KeyType) *Query {
+	q.key = key
+	q.keyType = keyType
+	return q
+}
+
+func (q *Query) addTypedExpectation(method string, keyType client.KeyType, exp *expect.Expectation[*client.Result]) {
+	q.typeChecks = append(q.typeChecks, typeCheck{method: method, keyType: keyType})
+	q.addExpectation(exp)
+}
+
+func (q *Query) validateTypeChecks() {
+	v := validation.New(q.stepCtx, "Redis")
+	actual := q.keyType
+	if actual == "" {
+		actual = client.TypeString
+	}
+	for _, check := range q.typeChecks {
+		v.Require(check.keyType == actual, errors.ConflictingExpectations("Redis", check.method,
+			fmt.Sprintf("a %s query. Use %s", actual, typeBuilderHint(check.keyType))))
+	}
+}
+
+func typeBuilderHint(keyType client.KeyType) string {
+	switch keyType {
+	case client.TypeHash:
+		return ".Hash(key)"
+	case client.TypeList:
+		return ".List(key)"
+	case client.TypeSet:
+		return ".Set(key)"
+	case client.TypeZSet:
+		return ".ZSet(key)"
+	case client.TypeStream:
+		return ".Stream(key)"
+	default:
+		return ".Key(key)"
+	}
+}
+
+// ExpectHashFieldEquals checks that the hash field equals expected (compared as strings).
+func (q *Query) ExpectHashFieldEquals(field string, expected any) *Query {
+	q.addTypedExpectation("ExpectHashFieldEquals()", client.TypeHash, makeHashFieldEqualsExpectation(field, expected))
+	return q
+}
+
+// ExpectHashFieldExists checks that the hash has the field.
+func (q *Query) ExpectHashFieldExists(field string) *Query {
+	q.addTypedExpectation("ExpectHashFieldExists()", client.TypeHash, makeHashFieldExistsExpectation(field))
+	return q
+}
+
+// ExpectHashLength checks the number of hash fields.
+func (q *Query) ExpectHashLength(n int) *Query {
+	q.addTypedExpectation("ExpectHashLength()", client.TypeHash, makeLengthExpectation("Hash fields", n))
+	return q
+}
+
+// ExpectListLength checks the number of list elements.
+func (q *Query) ExpectListLength(n int) *Query {
+	q.addTypedExpectation("ExpectListLength()", client.TypeList, makeLengthExpectation("List length", n))
+	return q
+}
+
+// ExpectListContains checks that the list has an element equal to value.
+func (q *Query) ExpectListContains(value any) *Query {
+	q.addTypedExpectation("ExpectListContains()", client.TypeList, makeListContainsExpectation(value))
+	return q
+}
+
+// ExpectListElementEquals checks the element at index; negative indexes count from the end as in LINDEX.
+func (q *Query) ExpectListElementEquals(index int, expected any) *Query {
+	q.addTypedExpectation("ExpectListElementEquals()", client.TypeList, makeListElementExpectation(index, expected))
+	return q
+}
+
+// ExpectSetContains checks that member is in the set.
+func (q *Query) ExpectSetContains(member any) *Query {
+	q.addTypedExpectation("ExpectSetContains()", client.TypeSet, makeSetContainsExpectation(member, true))
+	return q
+}
+
+// ExpectSetNotContains checks that member is not in the set. A missing key counts as an empty set.
+func (q *Query) ExpectSetNotContains(member any) *Query {
+	q.addTypedExpectation("ExpectSetNotContains()", client.TypeSet, makeSetContainsExpectation(member, false))
+	return q
+}
+
+// ExpectSetSize checks the number of set members.
+func (q *Query) ExpectSetSize(n int) *Query {
+	q.addTypedExpectation("ExpectSetSize()", client.TypeSet, makeLengthExpectation("Set size", n))
+	return q
+}
+
+// ExpectZScore checks the score of a sorted set member.
+func (q *Query) ExpectZScore(member any, score float64) *Query {
+	q.addTypedExpectation("ExpectZScore()", client.TypeZSet, makeZScoreExpectation(member, score))
+	return q
+}
+
+// ExpectZRank checks the 0-based rank of a member, lowest score first (ZRANK).
+func (q *Query) ExpectZRank(member any, rank int) *Query {
+	q.addTypedExpectation("ExpectZRank()", client.TypeZSet, makeZRankExpectation(member, rank))
+	return q
+}
+
+// ExpectZSetSize checks the number of sorted set members.
+func (q *Query) ExpectZSetSize(n int) *Query {
+	q.addTypedExpectation("ExpectZSetSize()", client.TypeZSet, makeLengthExpectation("Sorted set size", n))
+	return q
+}
+
+// ExpectStreamEntry checks that the stream has an entry containing all given fields and values.
+//
+// Example:
+//
+//	dsl.NewQuery(sCtx, redisClient).
+//	    Stream("events:payments").
+//	    ExpectStreamEntry(map[string]any{"paymentId": paymentID, "status": "CONFIRMED"}).
+//	    Send()
+func (q *Query) ExpectStreamEntry(fields map[string]any) *Query {
+	q.addTypedExpectation("ExpectStreamEntry()", client.TypeStream, makeStreamEntryExpectation(fields))
+	return q
+}
+
+// ExpectStreamLength checks the number of stream entries (XLEN).
+func (q *Query) ExpectStreamLength(n int) *Query {
+	q.addTypedExpectation("ExpectStreamLength()", client.TypeStream, makeLengthExpectation("Stream length", n))
+	return q
+}
+
+func toRedisString(v any) string {
+	if b, ok := v.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(v)
+}
+
+func makeHashFieldEqualsExpectation(field string, expected any) *expect.Expectation[*client.Result] {
+	want := toRedisString(expected)
+	name := fmt.Sprintf("Expect: Hash field '%s' = %q", field, want)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			actual, found := result.Hash[field]
+			if !found {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Hash field '%s' does not exist", field),
+				}
+			}
+			if actual != want {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Hash field '%s': expected %q, got %q", field, want, actual),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func makeHashFieldExistsExpectation(field string) *expect.Expectation[*client.Result] {
+	name := fmt.Sprintf("Expect: Hash field '%s' exists", field)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			if _, found := result.Hash[field]; !found {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Hash field '%s' does not exist", field),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+// makeLengthExpectation treats a missing key as an empty collection, so ExpectXLength(0) passes for it.
+func makeLengthExpectation(what string, expected int) *expect.Expectation[*client.Result] {
+	name := fmt.Sprintf("Expect: %s = %d", what, expected)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheck(err, result); !ok {
+				return res
+			}
+			if result.Length != int64(expected) {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("%s: expected %d, got %d", what, expected, result.Length),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func makeListContainsExpectation(value any) *expect.Expectation[*client.Result] {
+	want := toRedisString(value)
+	name := fmt.Sprintf("Expect: List contains %q", want)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			for _, element := range result.List {
+				if element == want {
+					return polling.CheckResult{Ok: true}
+				}
+			}
+			return polling.CheckResult{
+				Ok:        false,
+				Retryable: true,
+				Reason:    fmt.Sprintf("List does not contain %q (%d elements)", want, len(result.List)),
+			}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func makeListElementExpectation(index int, expected any) *expect.Expectation[*client.Result] {
+	want := toRedisString(expected)
+	name := fmt.Sprintf("Expect: List[%d] = %q", index, want)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			i := index
+			if i < 0 {
+				i += len(result.List)
+			}
+			if i < 0 || i >= len(result.List) {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("List index %d out of range (%d elements)", index, len(result.List)),
+				}
+			}
+			if result.List[i] != want {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("List[%d]: expected %q, got %q", index, want, result.List[i]),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func makeSetContainsExpectation(member any, contains bool) *expect.Expectation[*client.Result] {
+	want := toRedisString(member)
+	name := fmt.Sprintf("Expect: Set contains %q", want)
+	if !contains {
+		name = fmt.Sprintf("Expect: Set does not contain %q", want)
+	}
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if contains {
+				if res, ok := preCheckKeyExists(err, result); !ok {
+					return res
+				}
+			} else if res, ok := preCheck(err, result); !ok {
+				return res
+			}
+
+			found := false
+			for _, m := range result.Members {
+				if m == want {
+					found = true
+					break
+				}
+			}
+			if found != contains {
+				reason := fmt.Sprintf("Set does not contain %q", want)
+				if !contains {
+					reason = fmt.Sprintf("Set contains %q but expected not to", want)
+				}
+				return polling.CheckResult{Ok: false, Retryable: true, Reason: reason}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func findZMember(result *client.Result, member string) (int, *client.ZMember) {
+	for i := range result.ZMembers {
+		if result.ZMembers[i].Member == member {
+			return i, &result.ZMembers[i]
+		}
+	}
+	return -1, nil
+}
+
+func makeZScoreExpectation(member any, score float64) *expect.Expectation[*client.Result] {
+	want := toRedisString(member)
+	name := fmt.Sprintf("Expect: ZSCORE %q = %v", want, score)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			_, z := findZMember(result, want)
+			if z == nil {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Sorted set has no member %q", want),
+				}
+			}
+			if z.Score != score {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Member %q: expected score %v, got %v", want, score, z.Score),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func makeZRankExpectation(member any, rank int) *expect.Expectation[*client.Result] {
+	want := toRedisString(member)
+	name := fmt.Sprintf("Expect: ZRANK %q = %d", want, rank)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			actual, z := findZMember(result, want)
+			if z == nil {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Sorted set has no member %q", want),
+				}
+			}
+			if actual != rank {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Member %q: expected rank %d, got %d (score %v)", want, rank, actual, z.Score),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func makeStreamEntryExpectation(fields map[string]any) *expect.Expectation[*client.Result] {
+	want := make(map[string]string, len(fields))
+	parts := make([]string, 0, len(fields))
+	for field, value := range fields {
+		want[field] = toRedisString(value)
+	}
+	for _, field := range sortedKeys(want) {
+		parts = append(parts, fmt.Sprintf("%s=%s", field, want[field]))
+	}
+	description := strings.Join(parts, ", ")
+
+	name := fmt.Sprintf("Expect: Stream entry {%s}", description)
+	return expect.New(
+		name,
+		func(err error, result *client.Result) polling.CheckResult {
+			if res, ok := preCheckKeyExists(err, result); !ok {
+				return res
+			}
+			for _, entry := range result.Stream {
+				if streamEntryMatches(entry, want) {
+					return polling.CheckResult{Ok: true}
+				}
+			}
+			return polling.CheckResult{
+				Ok:        false,
+				Retryable: true,
+				Reason:    fmt.Sprintf("No stream entry matches {%s} (%d entries checked)", description, len(result.Stream)),
+			}
+		},
+		expect.StandardReport[*client.Result](name),
+	)
+}
+
+func streamEntryMatches(entry client.StreamEntry, want map[string]string) bool {
+	for field, value := range want {
+		if actual, ok := entry.Values[field]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
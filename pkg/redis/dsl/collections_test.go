@@ -0,0 +1,115 @@
+package dsl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+func TestMakeHashFieldEqualsExpectation(t *testing.T) {
+	exp := makeHashFieldEqualsExpectation("level", 7)
+	result := &client.Result{Key: "player", Exists: true, Type: client.TypeHash, Hash: map[string]string{"level": "7"}}
+
+	assert.True(t, exp.Check(nil, result).Ok)
+
+	result.Hash["level"] = "8"
+	checkResult := exp.Check(nil, result)
+	assert.False(t, checkResult.Ok)
+	assert.True(t, checkResult.Retryable)
+	assert.Contains(t, checkResult.Reason, `expected "7", got "8"`)
+
+	delete(result.Hash, "level")
+	assert.Contains(t, exp.Check(nil, result).Reason, "does not exist")
+}
+
+func TestMakeHashFieldExistsExpectation_MissingKey(t *testing.T) {
+	exp := makeHashFieldExistsExpectation("status")
+
+	checkResult := exp.Check(nil, &client.Result{Key: "player", Exists: false})
+
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "does not exist")
+}
+
+func TestMakeLengthExpectation_MissingKeyIsEmpty(t *testing.T) {
+	exp := makeLengthExpectation("List length", 0)
+
+	assert.True(t, exp.Check(nil, &client.Result{Key: "queue", Exists: false}).Ok)
+
+	checkResult := makeLengthExpectation("List length", 2).Check(nil, &client.Result{Key: "queue", Exists: true, Length: 3})
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "expected 2, got 3")
+}
+
+func TestMakeListElementExpectation_NegativeIndex(t *testing.T) {
+	result := &client.Result{Key: "queue", Exists: true, List: []string{"a", "b", "c"}}
+
+	assert.True(t, makeListElementExpectation(-1, "c").Check(nil, result).Ok)
+	assert.True(t, makeListElementExpectation(0, "a").Check(nil, result).Ok)
+	assert.Contains(t, makeListElementExpectation(3, "d").Check(nil, result).Reason, "out of range")
+	assert.Contains(t, makeListElementExpectation(1, "x").Check(nil, result).Reason, `expected "x", got "b"`)
+}
+
+func TestMakeListContainsExpectation(t *testing.T) {
+	result := &client.Result{Key: "queue", Exists: true, List: []string{"1", "2"}}
+
+	assert.True(t, makeListContainsExpectation(2).Check(nil, result).Ok)
+	assert.False(t, makeListContainsExpectation(3).Check(nil, result).Ok)
+}
+
+func TestMakeSetContainsExpectation(t *testing.T) {
+	result := &client.Result{Key: "online", Exists: true, Members: []string{"alice", "bob"}}
+
+	assert.True(t, makeSetContainsExpectation("alice", true).Check(nil, result).Ok)
+	assert.False(t, makeSetContainsExpectation("carol", true).Check(nil, result).Ok)
+
+	checkResult := makeSetContainsExpectation("alice", false).Check(nil, result)
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "expected not to")
+
+	assert.True(t, makeSetContainsExpectation("alice", false).Check(nil, &client.Result{Key: "online"}).Ok)
+}
+
+func TestMakeZScoreAndRankExpectations(t *testing.T) {
+	result := &client.Result{Key: "leaderboard", Exists: true, ZMembers: []client.ZMember{
+		{Member: "alice", Score: 100},
+		{Member: "carol", Score: 300},
+	}}
+
+	assert.True(t, makeZScoreExpectation("carol", 300).Check(nil, result).Ok)
+	assert.Contains(t, makeZScoreExpectation("carol", 200).Check(nil, result).Reason, "expected score 200, got 300")
+	assert.Contains(t, makeZScoreExpectation("bob", 1).Check(nil, result).Reason, "no member")
+
+	assert.True(t, makeZRankExpectation("carol", 1).Check(nil, result).Ok)
+	assert.Contains(t, makeZRankExpectation("alice", 1).Check(nil, result).Reason, "expected rank 1, got 0")
+}
+
+func TestMakeStreamEntryExpectation(t *testing.T) {
+	result := &client.Result{Key: "events", Exists: true, Stream: []client.StreamEntry{
+		{ID: "1-0", Values: map[string]string{"event": "created", "id": "42"}},
+	}}
+
+	assert.True(t, makeStreamEntryExpectation(map[string]any{"event": "created", "id": 42}).Check(nil, result).Ok)
+
+	checkResult := makeStreamEntryExpectation(map[string]any{"event": "paid"}).Check(nil, result)
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "No stream entry matches {event=paid}")
+
+	assert.False(t, makeStreamEntryExpectation(map[string]any{"missing": ""}).Check(nil, result).Ok)
+}
+
+func TestQuery_TypeModes(t *testing.T) {
+	q := NewQuery(nil, nil).Hash("player:1")
+	assert.Equal(t, client.TypeHash, q.keyType)
+	assert.Equal(t, "player:1", q.key)
+	assert.Equal(t, "Redis HGETALL player:1", q.stepName())
+
+	q = NewQuery(nil, nil).Key("session")
+	assert.Equal(t, "Redis GET session", q.stepName())
+
+	q = NewQuery(nil, nil).Stream("events").ExpectStreamLength(1)
+	assert.Len(t, q.typeChecks, 1)
+	assert.Equal(t, client.TypeStream, q.typeChecks[0].keyType)
+}
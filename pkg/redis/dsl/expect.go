@@ -36,22 +36,22 @@ func (q *Query) ExpectNotExists() *Query {
 }
 
 func (q *Query) ExpectValueEquals(expected string) *Query {
-	q.addExpectation(makeValueExpectation(expected))
+	q.addTypedExpectation("ExpectValueEquals()", client.TypeString, makeValueExpectation(expected))
 	return q
 }
 
 func (q *Query) ExpectValueNotEmpty() *Query {
-	q.addExpectation(makeValueNotEmptyExpectation())
+	q.addTypedExpectation("ExpectValueNotEmpty()", client.TypeString, makeValueNotEmptyExpectation())
 	return q
 }
 
 func (q *Query) ExpectFieldEquals(path string, expected any) *Query {
-	q.addExpectation(jsonSource.FieldEquals(path, expected))
+	q.addTypedExpectation("ExpectFieldEquals()", client.TypeString, jsonSource.FieldEquals(path, expected))
 	return q
 }
 
 func (q *Query) ExpectFieldNotEmpty(path string) *Query {
-	q.addExpectation(jsonSource.FieldNotEmpty(path))
+	q.addTypedExpectation("ExpectFieldNotEmpty()", client.TypeString, jsonSource.FieldNotEmpty(path))
 	return q
 }
 
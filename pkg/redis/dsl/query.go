@@ -28,7 +28,11 @@ type Query struct {
 	client  *client.Client
 	ctx     context.Context
 
-	key string
+	key     string
+	keyType client.KeyType
+
+	// typeChecks records type-specific expectations, validated against keyType on Send.
+	typeChecks []typeCheck
 
 	result *client.Result
 	sent   bool
@@ -80,7 +84,7 @@ func (q *Query) Send() *client.Result {
 }
 
 func (q *Query) stepName() string {
-	return fmt.Sprintf("Redis GET %s", q.key)
+	return fmt.Sprintf("Redis %s %s", q.keyType.Command(), q.key)
 }
 
 func (q *Query) assertResults(stepCtx provider.StepCtx, err error) {
@@ -97,4 +101,5 @@ func (q *Query) validate() {
 	v := validation.New(q.stepCtx, "Redis")
 	v.RequireNotNil(q.client, "Redis client")
 	v.RequireNotEmptyWithHint(q.key, "Redis key", "Use .Key(\"key_name\").")
+	q.validateTypeChecks()
 }
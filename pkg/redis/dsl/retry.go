@@ -29,7 +29,7 @@ func (q *Query) execute(
 }
 
 func (q *Query) doQuery(ctx context.Context) (*client.Result, error) {
-	result := q.client.Get(ctx, q.key)
+	result := q.client.Read(ctx, q.key, q.keyType)
 	if result.Exists {
 		ttlResult := q.client.TTL(ctx, q.key)
 		result.TTL = ttlResult.TTL
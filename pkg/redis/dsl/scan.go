@@ -0,0 +1,136 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/constants"
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+// DefaultScanLimit is the maximum number of keys collected by a ScanQuery unless Limit is set.
+const DefaultScanLimit = 1000
+
+// ScanQuery checks the set of keys matching a pattern. Keys are collected with SCAN,
+// so it is safe to run against shared environments, and retried in async mode.
+//
+// Example:
+//
+//	dsl.Scan(sCtx, redisClient).
+//	    Pattern("session:" + playerID + ":*").
+//	    ExpectAnyKey().
+//	    ExpectEachFieldEquals("playerId", playerID).
+//	    Send()
+type ScanQuery struct {
+	stepCtx provider.StepCtx
+	client  *client.Client
+	ctx     context.Context
+
+	pattern string
+	limit   int
+
+	// fetchValues is set by per-key expectations, which need the value of every matched key.
+	fetchValues bool
+
+	result *client.ScanResult
+	sent   bool
+
+	expectations []*expect.Expectation[*client.ScanResult]
+}
+
+// Scan creates a new key pattern scan builder.
+func Scan(stepCtx provider.StepCtx, redisClient *client.Client) *ScanQuery {
+	return &ScanQuery{
+		stepCtx: stepCtx,
+		client:  redisClient,
+		ctx:     context.Background(),
+		limit:   DefaultScanLimit,
+	}
+}
+
+// Pattern sets the glob-style MATCH pattern, e.g. "session:*".
+func (s *ScanQuery) Pattern(pattern string) *ScanQuery {
+	s.pattern = pattern
+	return s
+}
+
+// Limit caps the number of collected keys (default DefaultScanLimit, 0 for no limit).
+func (s *ScanQuery) Limit(n int) *ScanQuery {
+	s.limit = n
+	return s
+}
+
+func (s *ScanQuery) addExpectation(exp *expect.Expectation[*client.ScanResult]) {
+	expect.AddExpectation(s.stepCtx, s.sent, &s.expectations, exp, "Redis")
+}
+
+// Send scans the keys and validates all expectations.
+// In async mode (AsyncStep), automatically retries with backoff until expectations pass.
+func (s *ScanQuery) Send() *client.ScanResult {
+	s.validate()
+
+	s.stepCtx.WithNewStep(s.stepName(), func(stepCtx provider.StepCtx) {
+		result, err, summary := s.execute(stepCtx)
+		s.result = result
+		s.sent = true
+
+		attachRedisScanReport(stepCtx, s, s.result, summary)
+		expect.AssertExpectations(stepCtx, s.expectations, err, s.result, s.assertNoExpectations)
+	})
+
+	return s.result
+}
+
+func (s *ScanQuery) stepName() string {
+	return fmt.Sprintf("Redis SCAN %s", s.pattern)
+}
+
+func (s *ScanQuery) assertNoExpectations(stepCtx provider.StepCtx, mode polling.AssertionMode, err error) {
+	if err != nil {
+		polling.NoError(stepCtx, mode, err, "Redis scan failed: %v", err)
+	}
+}
+
+func (s *ScanQuery) validate() {
+	v := validation.New(s.stepCtx, "Redis")
+	v.RequireNotNil(s.client, "Redis client")
+	v.RequireNotEmptyWithHint(s.pattern, "Redis scan pattern", "Use .Pattern(\"prefix:*\").")
+	v.Require(s.limit >= 0, "Redis scan limit must not be negative")
+}
+
+func (s *ScanQuery) execute(stepCtx provider.StepCtx) (*client.ScanResult, error, polling.PollingSummary) {
+	return retry.ExecuteDSLSimple(retry.DSLConfig[*client.ScanResult, *client.ScanResult]{
+		Ctx:              s.ctx,
+		StepCtx:          stepCtx,
+		AsyncConfig:      s.client.AsyncConfig,
+		Expectations:     s.expectations,
+		Executor:         s.doScan,
+		PostProcess:      postProcessRedisScan,
+		NilResultFactory: s.newScanErrorResult,
+	})
+}
+
+func (s *ScanQuery) doScan(ctx context.Context) (*client.ScanResult, error) {
+	result := s.client.Scan(ctx, s.pattern, s.limit)
+	if result.Error == nil && s.fetchValues {
+		result.Values = s.client.GetMany(ctx, result.Keys)
+	}
+	return result, result.Error
+}
+
+func postProcessRedisScan(result *client.ScanResult, err error, summary *polling.PollingSummary) {
+	retry.PostProcessSummary(result, err, summary)
+}
+
+func (s *ScanQuery) newScanErrorResult(err error) *client.ScanResult {
+	return &client.ScanResult{
+		Pattern: s.pattern,
+		Error:   fmt.Errorf("%s: %w", constants.ErrNilResult, err),
+	}
+}
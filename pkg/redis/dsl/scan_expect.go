@@ -0,0 +1,169 @@
+package dsl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+var scanPreCheck = client.BuildScanPreCheck()
+
+// ExpectKeyCount checks that exactly n keys match the pattern.
+func (s *ScanQuery) ExpectKeyCount(n int) *ScanQuery {
+	s.addExpectation(makeKeyCountExpectation(n))
+	return s
+}
+
+// ExpectAnyKey checks that at least one key matches the pattern.
+func (s *ScanQuery) ExpectAnyKey() *ScanQuery {
+	s.addExpectation(makeAnyKeyExpectation())
+	return s
+}
+
+// ExpectNoKeys checks that no key matches the pattern.
+func (s *ScanQuery) ExpectNoKeys() *ScanQuery {
+	s.addExpectation(makeNoKeysExpectation())
+	return s
+}
+
+// ExpectEachValueEquals checks that every matched key holds the string value expected.
+// Per-key expectations fail when no key matches.
+func (s *ScanQuery) ExpectEachValueEquals(expected string) *ScanQuery {
+	return s.addEachKeyExpectation(makeValueExpectation(expected))
+}
+
+// ExpectEachValueNotEmpty checks that every matched key holds a non-empty string value.
+func (s *ScanQuery) ExpectEachValueNotEmpty() *ScanQuery {
+	return s.addEachKeyExpectation(makeValueNotEmptyExpectation())
+}
+
+// ExpectEachFieldEquals checks a JSON field (GJSON path) in the value of every matched key.
+func (s *ScanQuery) ExpectEachFieldEquals(path string, expected any) *ScanQuery {
+	return s.addEachKeyExpectation(jsonSource.FieldEquals(path, expected))
+}
+
+// ExpectEachFieldNotEmpty checks that a JSON field is not empty in the value of every matched key.
+func (s *ScanQuery) ExpectEachFieldNotEmpty(path string) *ScanQuery {
+	return s.addEachKeyExpectation(jsonSource.FieldNotEmpty(path))
+}
+
+func (s *ScanQuery) addEachKeyExpectation(exp *expect.Expectation[*client.Result]) *ScanQuery {
+	s.fetchValues = true
+	s.addExpectation(makeEachKeyExpectation(exp))
+	return s
+}
+
+func makeKeyCountExpectation(expected int) *expect.Expectation[*client.ScanResult] {
+	name := fmt.Sprintf("Expect: Key count = %d", expected)
+	return expect.New(
+		name,
+		func(err error, result *client.ScanResult) polling.CheckResult {
+			if res, ok := scanPreCheck(err, result); !ok {
+				return res
+			}
+			if result.Truncated {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("More than %d keys match %q, raise .Limit()", len(result.Keys), result.Pattern),
+				}
+			}
+			if len(result.Keys) != expected {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected %d keys matching %q, got %d", expected, result.Pattern, len(result.Keys)),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.ScanResult](name),
+	)
+}
+
+func makeAnyKeyExpectation() *expect.Expectation[*client.ScanResult] {
+	name := "Expect: Any key matches"
+	return expect.New(
+		name,
+		func(err error, result *client.ScanResult) polling.CheckResult {
+			if res, ok := scanPreCheck(err, result); !ok {
+				return res
+			}
+			if len(result.Keys) == 0 {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("No keys match %q", result.Pattern),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.ScanResult](name),
+	)
+}
+
+func makeNoKeysExpectation() *expect.Expectation[*client.ScanResult] {
+	name := "Expect: No keys match"
+	return expect.New(
+		name,
+		func(err error, result *client.ScanResult) polling.CheckResult {
+			if res, ok := scanPreCheck(err, result); !ok {
+				return res
+			}
+			if len(result.Keys) > 0 {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("%d keys match %q but expected none: %s", len(result.Keys), result.Pattern, previewKeys(result.Keys)),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.ScanResult](name),
+	)
+}
+
+// makeEachKeyExpectation applies a single-key expectation to the value of every matched key.
+// A key deleted between SCAN and GET fails the inner expectation like a missing key would.
+func makeEachKeyExpectation(inner *expect.Expectation[*client.Result]) *expect.Expectation[*client.ScanResult] {
+	name := "Expect: Each key: " + strings.TrimPrefix(strings.TrimPrefix(inner.Name, "Expect: "), "Expect ")
+	return expect.New(
+		name,
+		func(err error, result *client.ScanResult) polling.CheckResult {
+			if res, ok := scanPreCheck(err, result); !ok {
+				return res
+			}
+			if len(result.Keys) == 0 {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("No keys match %q", result.Pattern),
+				}
+			}
+			for _, key := range result.Keys {
+				value, ok := result.Values[key]
+				if !ok {
+					value = &client.Result{Key: key}
+				}
+				if res := inner.Check(nil, value); !res.Ok {
+					res.Reason = fmt.Sprintf("Key '%s': %s", key, res.Reason)
+					return res
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*client.ScanResult](name),
+	)
+}
+
+const previewKeysLimit = 5
+
+func previewKeys(keys []string) string {
+	if len(keys) <= previewKeysLimit {
+		return strings.Join(keys, ", ")
+	}
+	return fmt.Sprintf("%s, ... (+%d)", strings.Join(keys[:previewKeysLimit], ", "), len(keys)-previewKeysLimit)
+}
@@ -0,0 +1,88 @@
+package dsl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+func TestMakeKeyCountExpectation(t *testing.T) {
+	exp := makeKeyCountExpectation(2)
+
+	assert.True(t, exp.Check(nil, &client.ScanResult{Pattern: "s:*", Keys: []string{"s:1", "s:2"}}).Ok)
+
+	checkResult := exp.Check(nil, &client.ScanResult{Pattern: "s:*", Keys: []string{"s:1"}})
+	assert.False(t, checkResult.Ok)
+	assert.True(t, checkResult.Retryable)
+	assert.Contains(t, checkResult.Reason, "Expected 2 keys matching \"s:*\", got 1")
+
+	checkResult = exp.Check(nil, &client.ScanResult{Pattern: "s:*", Keys: []string{"s:1", "s:2"}, Truncated: true})
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "raise .Limit()")
+}
+
+func TestMakeAnyKeyAndNoKeysExpectations(t *testing.T) {
+	empty := &client.ScanResult{Pattern: "s:*"}
+	found := &client.ScanResult{Pattern: "s:*", Keys: []string{"s:1"}}
+
+	assert.False(t, makeAnyKeyExpectation().Check(nil, empty).Ok)
+	assert.True(t, makeAnyKeyExpectation().Check(nil, found).Ok)
+
+	assert.True(t, makeNoKeysExpectation().Check(nil, empty).Ok)
+	checkResult := makeNoKeysExpectation().Check(nil, found)
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "1 keys match \"s:*\" but expected none: s:1")
+}
+
+func TestScanExpectations_Error(t *testing.T) {
+	checkResult := makeAnyKeyExpectation().Check(errors.New("connection refused"), nil)
+
+	assert.False(t, checkResult.Ok)
+	assert.True(t, checkResult.Retryable)
+}
+
+func TestMakeEachKeyExpectation(t *testing.T) {
+	exp := makeEachKeyExpectation(jsonSource.FieldEquals("playerId", "42"))
+	assert.Equal(t, "Expect: Each key: JSON field 'playerId' == 42", exp.Name)
+	assert.Equal(t, `Expect: Each key: Value = "on"`, makeEachKeyExpectation(makeValueExpectation("on")).Name)
+
+	result := &client.ScanResult{
+		Pattern: "session:42:*",
+		Keys:    []string{"session:42:a", "session:42:b"},
+		Values: map[string]*client.Result{
+			"session:42:a": {Key: "session:42:a", Exists: true, Value: `{"playerId":"42"}`},
+			"session:42:b": {Key: "session:42:b", Exists: true, Value: `{"playerId":"42"}`},
+		},
+	}
+	assert.True(t, exp.Check(nil, result).Ok)
+
+	result.Values["session:42:b"].Value = `{"playerId":"7"}`
+	checkResult := exp.Check(nil, result)
+	assert.False(t, checkResult.Ok)
+	assert.Contains(t, checkResult.Reason, "Key 'session:42:b'")
+
+	delete(result.Values, "session:42:b")
+	assert.Contains(t, exp.Check(nil, result).Reason, "does not exist")
+
+	assert.Contains(t, exp.Check(nil, &client.ScanResult{Pattern: "session:42:*"}).Reason, "No keys match")
+}
+
+func TestScanQuery_Builder(t *testing.T) {
+	s := Scan(nil, nil).Pattern("session:*")
+	assert.Equal(t, DefaultScanLimit, s.limit)
+	assert.Equal(t, "Redis SCAN session:*", s.stepName())
+	assert.False(t, s.fetchValues)
+
+	s.Limit(10).ExpectEachValueNotEmpty()
+	assert.Equal(t, 10, s.limit)
+	assert.True(t, s.fetchValues)
+	assert.Len(t, s.expectations, 1)
+}
+
+func TestPreviewKeys(t *testing.T) {
+	assert.Equal(t, "a, b", previewKeys([]string{"a", "b"}))
+	assert.Equal(t, "a, b, c, d, e, ... (+2)", previewKeys([]string{"a", "b", "c", "d", "e", "f", "g"}))
+}
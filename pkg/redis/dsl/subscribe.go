@@ -0,0 +1,225 @@
+package dsl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/constants"
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/jsonutil"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+	"github.com/gorelov-m-v/go-test-framework/internal/redis/pubsub"
+	"github.com/gorelov-m-v/go-test-framework/internal/retry"
+	"github.com/gorelov-m-v/go-test-framework/internal/validation"
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+// SubscribeQuery searches pub/sub messages collected by the client's background subscriber
+// (redis.<name>.subscribe in config). Like Kafka Consume, it only sees messages received
+// after the client started, and retries in async mode until expectations pass.
+//
+// Example:
+//
+//	dsl.Subscribe(sCtx, redisClient).
+//	    Channel("cache:invalidate").
+//	    With("playerId", playerID).
+//	    ExpectPublished().
+//	    Send()
+type SubscribeQuery struct {
+	stepCtx provider.StepCtx
+	client  *client.Client
+	ctx     context.Context
+
+	channel string
+	filters map[string]any
+	payload *string
+
+	result *PubSubResult
+	sent   bool
+
+	expectations []*expect.Expectation[*PubSubResult]
+}
+
+// PubSubResult represents the outcome of a pub/sub message search.
+//
+// Fields:
+//   - Found: Whether a matching message was found
+//   - Message: The latest matching message
+//   - Matches: All matching messages, oldest first
+//   - Checked: Number of buffered messages searched
+type PubSubResult struct {
+	Found   bool
+	Message *client.Message
+	Matches []*client.Message
+	Checked int
+	Error   error
+}
+
+func (r *PubSubResult) GetError() error {
+	if r == nil {
+		return nil
+	}
+	return r.Error
+}
+
+// Subscribe creates a pub/sub message search over the client's subscriber buffer.
+func Subscribe(stepCtx provider.StepCtx, redisClient *client.Client) *SubscribeQuery {
+	return &SubscribeQuery{
+		stepCtx: stepCtx,
+		client:  redisClient,
+		ctx:     context.Background(),
+		filters: make(map[string]any),
+	}
+}
+
+// Channel restricts the search to channels matching a Redis glob pattern, as in PSUBSCRIBE:
+// "*" (also across "/" and ":"), "?", "[a-z]" classes and "\" escapes.
+func (q *SubscribeQuery) Channel(pattern string) *SubscribeQuery {
+	q.channel = pattern
+	return q
+}
+
+// Keyspace searches keyspace notifications for keys matching a glob pattern in any database.
+// The payload of such a notification is the event name, e.g. "set", "del" or "expired".
+func (q *SubscribeQuery) Keyspace(keyPattern string) *SubscribeQuery {
+	q.channel = "__keyspace@*__:" + keyPattern
+	return q
+}
+
+// With adds a filter to match messages whose JSON payload has value at path (GJSON syntax).
+// Multiple With calls use AND logic.
+func (q *SubscribeQuery) With(path string, value any) *SubscribeQuery {
+	q.filters[path] = value
+	return q
+}
+
+// WithPayload adds a filter to match messages whose payload equals payload exactly.
+func (q *SubscribeQuery) WithPayload(payload string) *SubscribeQuery {
+	q.payload = &payload
+	return q
+}
+
+func (q *SubscribeQuery) addExpectation(exp *expect.Expectation[*PubSubResult]) {
+	expect.AddExpectation(q.stepCtx, q.sent, &q.expectations, exp, "Redis")
+}
+
+// Send searches the buffered messages and validates all expectations.
+// In async mode (AsyncStep), automatically retries with backoff until expectations pass.
+func (q *SubscribeQuery) Send() *PubSubResult {
+	q.validate()
+
+	q.stepCtx.WithNewStep(q.stepName(), func(stepCtx provider.StepCtx) {
+		result, err, summary := q.execute(stepCtx)
+		q.result = result
+		q.sent = true
+
+		attachRedisPubSubReport(stepCtx, q, q.result, summary)
+		expect.AssertExpectations(stepCtx, q.expectations, err, q.result, q.assertNoExpectations)
+	})
+
+	return q.result
+}
+
+func (q *SubscribeQuery) stepName() string {
+	channel := q.channel
+	if channel == "" {
+		channel = "*"
+	}
+	return fmt.Sprintf("Redis SUBSCRIBE %s", channel)
+}
+
+func (q *SubscribeQuery) assertNoExpectations(stepCtx provider.StepCtx, mode polling.AssertionMode, err error) {
+	if err != nil {
+		polling.NoError(stepCtx, mode, err, "Redis pub/sub search failed: %v", err)
+	}
+}
+
+func (q *SubscribeQuery) validate() {
+	v := validation.New(q.stepCtx, "Redis")
+	v.RequireNotNil(q.client, "Redis client")
+	if q.client != nil {
+		v.Require(len(q.client.Subscriptions()) > 0,
+			"Redis client has no subscriptions. Configure 'subscribe.channels' or 'subscribe.patterns' for it.")
+	}
+}
+
+func (q *SubscribeQuery) execute(stepCtx provider.StepCtx) (*PubSubResult, error, polling.PollingSummary) {
+	return retry.ExecuteDSLSimple(retry.DSLConfig[*PubSubResult, *PubSubResult]{
+		Ctx:              q.ctx,
+		StepCtx:          stepCtx,
+		AsyncConfig:      q.client.AsyncConfig,
+		Expectations:     q.expectations,
+		Executor:         q.doSearch,
+		PostProcess:      postProcessPubSub,
+		NilResultFactory: newPubSubErrorResult,
+	})
+}
+
+func (q *SubscribeQuery) doSearch(ctx context.Context) (*PubSubResult, error) {
+	return q.search(q.client.Messages()), nil
+}
+
+func (q *SubscribeQuery) search(messages []*client.Message) *PubSubResult {
+	result := &PubSubResult{Checked: len(messages)}
+	for _, msg := range messages {
+		if q.matches(msg) {
+			result.Matches = append(result.Matches, msg)
+		}
+	}
+	if len(result.Matches) > 0 {
+		result.Found = true
+		result.Message = result.Matches[len(result.Matches)-1]
+	}
+	return result
+}
+
+func (q *SubscribeQuery) matches(msg *client.Message) bool {
+	if msg == nil {
+		return false
+	}
+	if q.channel != "" {
+		if !pubsub.MatchPattern(q.channel, msg.Channel) {
+			return false
+		}
+	}
+	if q.payload != nil && msg.Payload != *q.payload {
+		return false
+	}
+	for path, expected := range q.filters {
+		field, err := jsonutil.GetFieldFromString(msg.Payload, path)
+		if err != nil || !field.Exists() {
+			return false
+		}
+		if ok, _ := jsonutil.Compare(field, expected); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// describeFilters lists channel, payload and JSON filters for reports and failure messages.
+func (q *SubscribeQuery) describeFilters() map[string]string {
+	all := make(map[string]string, len(q.filters)+2)
+	for path, value := range q.filters {
+		all[path] = fmt.Sprintf("%v", value)
+	}
+	if q.channel != "" {
+		all["<channel>"] = q.channel
+	}
+	if q.payload != nil {
+		all["<payload>"] = *q.payload
+	}
+	return all
+}
+
+func postProcessPubSub(result *PubSubResult, err error, summary *polling.PollingSummary) {
+	retry.PostProcessSummary(result, err, summary)
+}
+
+func newPubSubErrorResult(err error) *PubSubResult {
+	return &PubSubResult{
+		Error: fmt.Errorf("%s: %w", constants.ErrNilResult, err),
+	}
+}
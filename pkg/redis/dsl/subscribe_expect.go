@@ -0,0 +1,122 @@
+package dsl
+
+import (
+	"fmt"
+
+	"github.com/gorelov-m-v/go-test-framework/internal/expect"
+	"github.com/gorelov-m-v/go-test-framework/internal/polling"
+)
+
+var pubSubPreCheck = expect.BuildPreCheck(expect.PreCheckConfig[*PubSubResult]{
+	IsNil:    func(r *PubSubResult) bool { return r == nil },
+	HasError: func(r *PubSubResult) error { return r.Error },
+})
+
+func pubSubFoundPreCheck(err error, result *PubSubResult) (polling.CheckResult, bool) {
+	if res, ok := pubSubPreCheck(err, result); !ok {
+		return res, false
+	}
+	if !result.Found {
+		return polling.CheckResult{
+			Ok:        false,
+			Retryable: true,
+			Reason:    fmt.Sprintf("No matching message among %d received", result.Checked),
+		}, false
+	}
+	return polling.CheckResult{}, true
+}
+
+var pubSubJSONSource = &expect.JSONExpectationSource[*PubSubResult]{
+	GetJSON: func(result *PubSubResult) ([]byte, error) {
+		return []byte(result.Message.Payload), nil
+	},
+	PreCheck:         pubSubFoundPreCheck,
+	PreCheckWithBody: pubSubFoundPreCheck,
+}
+
+// ExpectPublished checks that at least one matching message was received.
+func (q *SubscribeQuery) ExpectPublished() *SubscribeQuery {
+	q.addExpectation(makePublishedExpectation(q))
+	return q
+}
+
+// ExpectPublishedCount checks that exactly n matching messages were received.
+func (q *SubscribeQuery) ExpectPublishedCount(n int) *SubscribeQuery {
+	q.addExpectation(makePublishedCountExpectation(n))
+	return q
+}
+
+// ExpectPayloadEquals checks the payload of the latest matching message.
+func (q *SubscribeQuery) ExpectPayloadEquals(expected string) *SubscribeQuery {
+	q.addExpectation(makePayloadEqualsExpectation(expected))
+	return q
+}
+
+// ExpectFieldEquals checks a JSON field (GJSON path) of the latest matching message.
+func (q *SubscribeQuery) ExpectFieldEquals(path string, expected any) *SubscribeQuery {
+	q.addExpectation(pubSubJSONSource.FieldEquals(path, expected))
+	return q
+}
+
+// ExpectFieldNotEmpty checks that a JSON field of the latest matching message is not empty.
+func (q *SubscribeQuery) ExpectFieldNotEmpty(path string) *SubscribeQuery {
+	q.addExpectation(pubSubJSONSource.FieldNotEmpty(path))
+	return q
+}
+
+func makePublishedExpectation(q *SubscribeQuery) *expect.Expectation[*PubSubResult] {
+	name := "Expect: Message published"
+	return expect.New(
+		name,
+		func(err error, result *PubSubResult) polling.CheckResult {
+			if res, ok := pubSubFoundPreCheck(err, result); !ok {
+				res.Reason = fmt.Sprintf("%s. Filters: %v", res.Reason, q.describeFilters())
+				return res
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*PubSubResult](name),
+	)
+}
+
+func makePublishedCountExpectation(expected int) *expect.Expectation[*PubSubResult] {
+	name := fmt.Sprintf("Expect: %d messages published", expected)
+	return expect.New(
+		name,
+		func(err error, result *PubSubResult) polling.CheckResult {
+			if res, ok := pubSubPreCheck(err, result); !ok {
+				return res
+			}
+			if len(result.Matches) != expected {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected %d matching messages, got %d", expected, len(result.Matches)),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*PubSubResult](name),
+	)
+}
+
+func makePayloadEqualsExpectation(expected string) *expect.Expectation[*PubSubResult] {
+	name := fmt.Sprintf("Expect: Payload = %q", expected)
+	return expect.New(
+		name,
+		func(err error, result *PubSubResult) polling.CheckResult {
+			if res, ok := pubSubFoundPreCheck(err, result); !ok {
+				return res
+			}
+			if result.Message.Payload != expected {
+				return polling.CheckResult{
+					Ok:        false,
+					Retryable: true,
+					Reason:    fmt.Sprintf("Expected payload %q, got %q", expected, result.Message.Payload),
+				}
+			}
+			return polling.CheckResult{Ok: true}
+		},
+		expect.StandardReport[*PubSubResult](name),
+	)
+}
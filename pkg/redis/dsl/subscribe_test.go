@@ -0,0 +1,84 @@
+package dsl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gorelov-m-v/go-test-framework/pkg/redis/client"
+)
+
+func pubSubMessages() []*client.Message {
+	return []*client.Message{
+		{Channel: "cache:invalidate", Payload: `{"playerId":42,"reason":"update"}`},
+		{Channel: "cache:invalidate", Payload: `{"playerId":7,"reason":"update"}`},
+		{Channel: "__keyspace@0__:player:42", Pattern: "__keyspace@*__:player:*", Payload: "del"},
+		{Channel: "cache:invalidate", Payload: `{"playerId":42,"reason":"logout"}`},
+		{Channel: "cache:invalidate", Payload: "not json"},
+	}
+}
+
+func TestSubscribeQuery_SearchFilters(t *testing.T) {
+	result := Subscribe(nil, nil).Channel("cache:*").With("playerId", 42).search(pubSubMessages())
+
+	require.True(t, result.Found)
+	assert.Equal(t, 5, result.Checked)
+	assert.Len(t, result.Matches, 2)
+	assert.Equal(t, `{"playerId":42,"reason":"logout"}`, result.Message.Payload)
+
+	result = Subscribe(nil, nil).Keyspace("player:42").WithPayload("del").search(pubSubMessages())
+	require.True(t, result.Found)
+	assert.Equal(t, "__keyspace@0__:player:42", result.Message.Channel)
+
+	result = Subscribe(nil, nil).Channel("cache:invalidate").With("playerId", 100).search(pubSubMessages())
+	assert.False(t, result.Found)
+	assert.Nil(t, result.Message)
+}
+
+func TestSubscribeQuery_StepNameAndFilters(t *testing.T) {
+	assert.Equal(t, "Redis SUBSCRIBE *", Subscribe(nil, nil).stepName())
+
+	q := Subscribe(nil, nil).Keyspace("player:*").WithPayload("expired").With("a.b", true)
+	assert.Equal(t, "Redis SUBSCRIBE __keyspace@*__:player:*", q.stepName())
+	assert.Equal(t, map[string]string{
+		"<channel>": "__keyspace@*__:player:*",
+		"<payload>": "expired",
+		"a.b":       "true",
+	}, q.describeFilters())
+}
+
+func TestMakePublishedExpectation(t *testing.T) {
+	q := Subscribe(nil, nil).Channel("cache:invalidate")
+	exp := makePublishedExpectation(q)
+
+	assert.True(t, exp.Check(nil, q.search(pubSubMessages())).Ok)
+
+	checkResult := exp.Check(nil, &PubSubResult{Checked: 3})
+	assert.False(t, checkResult.Ok)
+	assert.True(t, checkResult.Retryable)
+	assert.Contains(t, checkResult.Reason, "No matching message among 3 received")
+	assert.Contains(t, checkResult.Reason, "<channel>:cache:invalidate")
+
+	assert.False(t, exp.Check(errors.New("boom"), nil).Ok)
+}
+
+func TestMakePublishedCountExpectation(t *testing.T) {
+	result := Subscribe(nil, nil).Channel("cache:invalidate").With("playerId", 42).search(pubSubMessages())
+
+	assert.True(t, makePublishedCountExpectation(2).Check(nil, result).Ok)
+	assert.Contains(t, makePublishedCountExpectation(1).Check(nil, result).Reason, "Expected 1 matching messages, got 2")
+	assert.True(t, makePublishedCountExpectation(0).Check(nil, &PubSubResult{}).Ok)
+}
+
+func TestSubscribeQuery_PayloadExpectations(t *testing.T) {
+	result := Subscribe(nil, nil).Channel("cache:invalidate").With("playerId", 42).search(pubSubMessages())
+
+	assert.True(t, pubSubJSONSource.FieldEquals("reason", "logout").Check(nil, result).Ok)
+	assert.False(t, pubSubJSONSource.FieldEquals("reason", "update").Check(nil, result).Ok)
+	assert.True(t, pubSubJSONSource.FieldNotEmpty("reason").Check(nil, result).Ok)
+
+	assert.True(t, makePayloadEqualsExpectation(`{"playerId":42,"reason":"logout"}`).Check(nil, result).Ok)
+	assert.Contains(t, makePayloadEqualsExpectation("x").Check(nil, &PubSubResult{}).Reason, "No matching message")
+}